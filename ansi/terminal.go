@@ -0,0 +1,21 @@
+package ansi
+
+import (
+	"io"
+	"os"
+)
+
+// IsTerminal returns if a given writer is a terminal (i.e. a character device).
+//
+// Writers that are not an `*os.File`, such as buffers or network connections, are never terminals.
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok || file == nil {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,24 @@
+package ansi
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestIsTerminal(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(IsTerminal(nil))
+	assert.False(IsTerminal(new(bytes.Buffer)))
+	assert.False(IsTerminal((*os.File)(nil)))
+
+	file, err := ioutil.TempFile("", "go-sdk-ansi")
+	assert.Nil(err)
+	defer os.Remove(file.Name())
+	defer file.Close()
+	assert.False(IsTerminal(file))
+}
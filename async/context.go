@@ -0,0 +1,95 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CancelCauseFunc cancels a context with a given cause.
+//
+// Only the first cause is kept; a nil cause is recorded as `context.Canceled`.
+type CancelCauseFunc func(cause error)
+
+// WithCancelCause returns a context and a cancel function that cancels it with the cause it is called with.
+//
+// The cause is returned by `Cause(ctx)` once the context is done.
+func WithCancelCause(parent context.Context) (context.Context, CancelCauseFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	return withCause(parent, ctx, cancel, nil)
+}
+
+// WithTimeoutCause returns a context that is cancelled after a given duration with a given cause,
+// and a cancel function that cancels it with the cause it is called with.
+//
+// The cause is returned by `Cause(ctx)` once the context is done, so work can report
+// why it was cancelled (e.g. a timeout versus a shutdown); if the cancel function is called with a nil
+// cause, the cause is `context.Canceled`. If the duration is zero or less, the context is only cancelled by the cancel function.
+func WithTimeoutCause(parent context.Context, d time.Duration, cause error) (context.Context, CancelCauseFunc) {
+	if d <= 0 {
+		return WithCancelCause(parent)
+	}
+	ctx, cancel := context.WithTimeout(parent, d)
+	return withCause(parent, ctx, cancel, cause)
+}
+
+// Cause returns the cause a context was cancelled with.
+//
+// It returns nil if the context is not done, and `ctx.Err()` if the context was
+// not created by `WithCancelCause` or `WithTimeoutCause`.
+func Cause(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	state, ok := ctx.Value(causeKey{}).(*causeState)
+	if !ok {
+		return ctx.Err()
+	}
+	if cause := state.get(); cause != nil {
+		return cause
+	}
+	if state.timeoutCause != nil && state.ctx.Err() == context.DeadlineExceeded && state.parent.Err() != context.DeadlineExceeded {
+		return state.timeoutCause
+	}
+	// the context was cancelled by its parent
+	return Cause(state.parent)
+}
+
+func withCause(parent, ctx context.Context, cancel context.CancelFunc, timeoutCause error) (context.Context, CancelCauseFunc) {
+	state := &causeState{parent: parent, ctx: ctx, timeoutCause: timeoutCause}
+	return context.WithValue(ctx, causeKey{}, state), func(cause error) {
+		if ctx.Err() == nil {
+			state.set(cause)
+		}
+		cancel()
+	}
+}
+
+type causeKey struct{}
+
+// causeState holds the cause a context was cancelled with.
+type causeState struct {
+	parent       context.Context
+	ctx          context.Context
+	timeoutCause error
+
+	mu    sync.Mutex
+	cause error
+}
+
+func (cs *causeState) set(cause error) {
+	if cause == nil {
+		cause = context.Canceled
+	}
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.cause == nil {
+		cs.cause = cause
+	}
+}
+
+func (cs *causeState) get() error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.cause
+}
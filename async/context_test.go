@@ -0,0 +1,55 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestWithTimeoutCause(t *testing.T) {
+	assert := assert.New(t)
+
+	errTimeout := errors.New("timed out")
+	errShutdown := errors.New("shutting down")
+
+	ctx, cancel := WithTimeoutCause(context.Background(), time.Millisecond, errTimeout)
+	defer cancel(nil)
+	<-ctx.Done()
+	assert.Equal(context.DeadlineExceeded, ctx.Err())
+	assert.Equal(errTimeout, Cause(ctx))
+
+	ctx, cancel = WithTimeoutCause(context.Background(), time.Minute, errTimeout)
+	cancel(errShutdown)
+	<-ctx.Done()
+	assert.Equal(context.Canceled, ctx.Err())
+	assert.Equal(errShutdown, Cause(ctx))
+	cancel(errTimeout)
+	assert.Equal(errShutdown, Cause(ctx), "the first cause should be kept")
+
+	ctx, cancel = WithTimeoutCause(context.Background(), 0, errTimeout)
+	assert.Nil(ctx.Err())
+	_, hasDeadline := ctx.Deadline()
+	assert.False(hasDeadline)
+	cancel(nil)
+	assert.Equal(context.Canceled, Cause(ctx))
+
+	parent, cancelParent := WithCancelCause(context.Background())
+	ctx, cancel = WithTimeoutCause(parent, time.Minute, errTimeout)
+	defer cancel(nil)
+	cancelParent(errShutdown)
+	<-ctx.Done()
+	assert.Equal(errShutdown, Cause(ctx))
+
+	ctx, cancel = WithTimeoutCause(context.Background(), time.Minute, errTimeout)
+	defer cancel(nil)
+	assert.Nil(Cause(ctx))
+	_, hasDeadline = ctx.Deadline()
+	assert.True(hasDeadline)
+
+	plain, cancelPlain := context.WithCancel(context.Background())
+	cancelPlain()
+	assert.Equal(context.Canceled, Cause(plain))
+}
@@ -64,3 +64,95 @@ func TestLatch(t *testing.T) {
 	assert.False(l.IsStarted())
 	assert.True(l.IsStopped())
 }
+
+// signaled returns if a notify channel has a pending signal, consuming it.
+func signaled(notify <-chan struct{}) bool {
+	select {
+	case <-notify:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestLatchTransitions(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewLatch()
+	assert.True(l.IsStopped())
+	assert.True(l.CanStart())
+	assert.False(l.CanStop())
+
+	l.Starting()
+	assert.True(l.IsStarting())
+	assert.False(l.CanStart())
+	assert.False(l.CanStop())
+
+	l.Started()
+	assert.True(l.IsStarted())
+	assert.False(l.CanStart())
+	assert.True(l.CanStop())
+
+	l.Stopping()
+	assert.True(l.IsStopping())
+	assert.False(l.CanStart())
+	assert.False(l.CanStop())
+
+	l.Stopped()
+	assert.True(l.IsStopped())
+	assert.True(l.CanStart())
+	assert.False(l.CanStop())
+}
+
+func TestLatchNotifyOnce(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewLatch()
+	assert.False(signaled(l.NotifyStarting()))
+	assert.False(signaled(l.NotifyStarted()))
+	assert.False(signaled(l.NotifyStopping()))
+	assert.False(signaled(l.NotifyStopped()))
+
+	l.Starting()
+	l.Starting()
+	assert.True(signaled(l.NotifyStarting()))
+	assert.False(signaled(l.NotifyStarting()), "repeated transitions should not signal again")
+	assert.False(signaled(l.NotifyStarted()))
+
+	l.Started()
+	l.Started()
+	assert.True(signaled(l.NotifyStarted()))
+	assert.False(signaled(l.NotifyStarted()), "repeated transitions should not signal again")
+	assert.False(signaled(l.NotifyStopping()))
+
+	l.Stopping()
+	l.Stopping()
+	assert.True(signaled(l.NotifyStopping()))
+	assert.False(signaled(l.NotifyStopping()), "repeated transitions should not signal again")
+	assert.False(signaled(l.NotifyStopped()))
+
+	l.Stopped()
+	l.Stopped()
+	assert.True(signaled(l.NotifyStopped()))
+	assert.False(signaled(l.NotifyStopped()), "repeated transitions should not signal again")
+}
+
+func TestLatchWaitStartedStopped(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewLatch()
+	go func() {
+		<-l.NotifyStarting()
+		l.Started()
+		<-l.NotifyStopping()
+		l.Stopped()
+	}()
+
+	l.WaitStarted()
+	assert.True(l.IsStarted())
+	l.WaitStarted() // already started, should not block
+
+	l.WaitStopped()
+	assert.True(l.IsStopped())
+	l.WaitStopped() // already stopped, should not block
+}
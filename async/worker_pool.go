@@ -0,0 +1,103 @@
+package async
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// NewWorkerPool creates a new worker pool.
+// Worker pools run submitted actions in the background, with at most a fixed number running at once.
+// The default size is the number of cpus.
+func NewWorkerPool(options ...WorkerPoolOption) *WorkerPool {
+	wp := WorkerPool{
+		Size: runtime.NumCPU(),
+	}
+	for _, option := range options {
+		option(&wp)
+	}
+	if wp.Size < 1 {
+		wp.Size = 1
+	}
+	wp.slots = make(chan struct{}, wp.Size)
+	return &wp
+}
+
+// WorkerPoolOption is an option for worker pools.
+type WorkerPoolOption func(*WorkerPool)
+
+// OptWorkerPoolSize sets the worker pool size, or the maximum number of actions that run at once.
+func OptWorkerPoolSize(size int) WorkerPoolOption {
+	return func(wp *WorkerPool) {
+		wp.Size = size
+	}
+}
+
+// OptWorkerPoolErrors sets the worker pool error return channel.
+func OptWorkerPoolErrors(errors chan error) WorkerPoolOption {
+	return func(wp *WorkerPool) {
+		wp.Errors = errors
+	}
+}
+
+// WorkerPool runs actions in the background with bounded concurrency.
+type WorkerPool struct {
+	// Size is the maximum number of actions that run at once.
+	// It is fixed when the pool is created.
+	Size int
+	// Errors is an optional channel that action errors, including panics, are sent to.
+	// Actions hold their slot in the pool until their error is received, so it should be buffered or drained.
+	Errors chan error
+
+	slots    chan struct{}
+	inFlight int32
+	wg       sync.WaitGroup
+}
+
+// Submit runs an action in the background once there is a free slot in the pool.
+// It blocks until the action is started, or returns the context error if the context is done first.
+// The action is given the submitted context.
+func (wp *WorkerPool) Submit(ctx context.Context, action ContextAction) error {
+	select {
+	case wp.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	wp.wg.Add(1)
+	atomic.AddInt32(&wp.inFlight, 1)
+	go func() {
+		defer func() {
+			atomic.AddInt32(&wp.inFlight, -1)
+			<-wp.slots
+			wp.wg.Done()
+		}()
+		defer func() {
+			if r := recover(); r != nil {
+				wp.handleError(ex.New(r))
+			}
+		}()
+		wp.handleError(action(ctx))
+	}()
+	return nil
+}
+
+// InFlight returns the number of actions that are running.
+func (wp *WorkerPool) InFlight() int {
+	return int(atomic.LoadInt32(&wp.inFlight))
+}
+
+// Wait blocks until all submitted actions have finished.
+func (wp *WorkerPool) Wait() {
+	wp.wg.Wait()
+}
+
+func (wp *WorkerPool) handleError(err error) {
+	if err == nil || wp.Errors == nil {
+		return
+	}
+	wp.Errors <- err
+}
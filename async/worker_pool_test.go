@@ -0,0 +1,94 @@
+package async
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	assert := assert.New(t)
+
+	const size, count = 4, 64
+	wp := NewWorkerPool(OptWorkerPoolSize(size))
+	assert.Equal(size, wp.Size)
+
+	var running, maxRunning, maxInFlight, completed int32
+	for x := 0; x < count; x++ {
+		assert.Nil(wp.Submit(context.Background(), func(_ context.Context) error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				previous := atomic.LoadInt32(&maxRunning)
+				if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+					break
+				}
+			}
+			if inFlight := int32(wp.InFlight()); inFlight > atomic.LoadInt32(&maxInFlight) {
+				atomic.StoreInt32(&maxInFlight, inFlight)
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&completed, 1)
+			return nil
+		}))
+	}
+	wp.Wait()
+
+	assert.Equal(count, atomic.LoadInt32(&completed))
+	assert.True(atomic.LoadInt32(&maxRunning) <= size, fmt.Sprint(maxRunning))
+	assert.True(atomic.LoadInt32(&maxInFlight) <= size, fmt.Sprint(maxInFlight))
+	assert.Zero(wp.InFlight())
+}
+
+func TestWorkerPoolErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	errors := make(chan error, 2)
+	wp := NewWorkerPool(OptWorkerPoolSize(1), OptWorkerPoolErrors(errors))
+
+	assert.Nil(wp.Submit(context.Background(), func(_ context.Context) error { return fmt.Errorf("only a test") }))
+	assert.Nil(wp.Submit(context.Background(), func(_ context.Context) error { panic("only a panic") }))
+	assert.Nil(wp.Submit(context.Background(), func(_ context.Context) error { return nil }))
+	wp.Wait()
+
+	assert.Len(errors, 2)
+	assert.Equal("only a test", (<-errors).Error())
+	assert.Contains((<-errors).Error(), "only a panic")
+}
+
+func TestWorkerPoolSubmitContextDone(t *testing.T) {
+	assert := assert.New(t)
+
+	wp := NewWorkerPool(OptWorkerPoolSize(1))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	release := make(chan struct{})
+	assert.Nil(wp.Submit(context.Background(), func(_ context.Context) error {
+		wg.Done()
+		<-release
+		return nil
+	}))
+	wg.Wait()
+	assert.Equal(1, wp.InFlight())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(context.DeadlineExceeded, wp.Submit(ctx, func(_ context.Context) error { return nil }))
+
+	close(release)
+	wp.Wait()
+	assert.Zero(wp.InFlight())
+}
+
+func TestWorkerPoolDefaultSize(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(NewWorkerPool().Size > 0)
+	assert.Equal(1, NewWorkerPool(OptWorkerPoolSize(0)).Size)
+}
@@ -86,8 +86,9 @@ type Breaker struct {
 // Do returns an error instantly if the Breaker rejects the request.
 // Otherwise, Do returns the result of the request.
 // If a panic occurs in the request, the Breaker handles it as an error.
-func (b *Breaker) Do(ctx context.Context, action Action) (interface{}, error) {
-	generation, err := b.beforeAction(ctx)
+func (b *Breaker) Do(ctx context.Context, action Action) (res interface{}, err error) {
+	var generation int64
+	generation, err = b.beforeAction(ctx)
 	if err != nil {
 		if b.OpenAction != nil {
 			return b.OpenAction(ctx)
@@ -97,10 +98,11 @@ func (b *Breaker) Do(ctx context.Context, action Action) (interface{}, error) {
 	defer func() {
 		if r := recover(); r != nil {
 			b.afterAction(ctx, generation, false)
+			res, err = nil, ex.New(r)
 		}
 	}()
 
-	res, err := action(ctx)
+	res, err = action(ctx)
 	b.afterAction(ctx, generation, err == nil)
 	return res, err
 }
@@ -110,11 +112,25 @@ func (b *Breaker) EvaluateState(ctx context.Context) State {
 	b.Lock()
 	defer b.Unlock()
 
-	now := time.Now()
+	now := b.now()
 	state, _ := b.evaluateState(ctx, now)
 	return state
 }
 
+// Snapshot returns the current state, generation and counts of the breaker.
+func (b *Breaker) Snapshot(ctx context.Context) Snapshot {
+	b.Lock()
+	defer b.Unlock()
+
+	now := b.now()
+	state, generation := b.evaluateState(ctx, now)
+	return Snapshot{
+		State:      state,
+		Generation: generation,
+		Counts:     b.Counts,
+	}
+}
+
 func (b *Breaker) beforeAction(ctx context.Context) (int64, error) {
 	b.Lock()
 	defer b.Unlock()
@@ -137,8 +153,9 @@ func (b *Breaker) afterAction(ctx context.Context, generation int64, success boo
 	defer b.Unlock()
 
 	now := b.now()
-	state, generation := b.evaluateState(ctx, now)
-	if generation != generation {
+	state, currentGeneration := b.evaluateState(ctx, now)
+	// ignore the results of actions started before the counts were cleared.
+	if currentGeneration != generation {
 		return
 	}
 
@@ -7,4 +7,7 @@ type Config struct {
 	HalfOpenMaxActions   int64         `json:"halfOpenMaxActions" yaml:"halfOpenMaxActions"`
 	ClosedExpiryInterval time.Duration `json:"closedExpiryInterval" yaml:"closedExpiryInterval"`
 	OpenExpiryInterval   time.Duration `json:"openExpiryInterval" yaml:"openExpiryInterval"`
+	// FailureRatio, if set, opens the breaker once the given ratio of at least `FailureRatioMinActions` actions fail.
+	FailureRatio           float64 `json:"failureRatio" yaml:"failureRatio"`
+	FailureRatioMinActions int64   `json:"failureRatioMinActions" yaml:"failureRatioMinActions"`
 }
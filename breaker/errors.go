@@ -7,6 +7,8 @@ var (
 	ErrTooManyRequests ex.Class = "too many requests"
 	// ErrOpenState is returned when the CB state is open
 	ErrOpenState ex.Class = "circuit breaker is open"
+	// ErrInvalidFailureRatio is returned when a failure ratio is not greater than 0 and at most 1.
+	ErrInvalidFailureRatio ex.Class = "circuit breaker failure ratio is invalid; it must be greater than 0 and at most 1"
 )
 
 // ErrIsOpen returns if the error is an ErrOpenState.
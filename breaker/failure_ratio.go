@@ -0,0 +1,18 @@
+package breaker
+
+import "context"
+
+// ShouldOpenFailureRatio returns a ShouldOpenProvider that opens the breaker once the ratio of
+// failed actions to finished actions reaches a given ratio, e.g. `0.5`.
+//
+// Breakers do not open until at least a given number of actions have finished,
+// so a single early failure does not open the breaker.
+func ShouldOpenFailureRatio(ratio float64, minActions int64) ShouldOpenProvider {
+	return func(_ context.Context, counts Counts) bool {
+		finished := counts.TotalSuccesses + counts.TotalFailures
+		if finished == 0 || finished < minActions {
+			return false
+		}
+		return float64(counts.TotalFailures)/float64(finished) >= ratio
+	}
+}
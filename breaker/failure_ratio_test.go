@@ -0,0 +1,130 @@
+package breaker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestShouldOpenFailureRatio(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	shouldOpen := ShouldOpenFailureRatio(0.5, 4)
+	assert.False(shouldOpen(ctx, Counts{}))
+	assert.False(shouldOpen(ctx, Counts{TotalFailures: 3}), "should require the minimum number of actions")
+	assert.True(shouldOpen(ctx, Counts{TotalFailures: 2, TotalSuccesses: 2}))
+	assert.False(shouldOpen(ctx, Counts{TotalFailures: 2, TotalSuccesses: 3}))
+	assert.True(ShouldOpenFailureRatio(0.5, 0)(ctx, Counts{TotalFailures: 1}))
+}
+
+func TestOptFailureRatio(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := New(OptFailureRatio(0, 1))
+	assert.True(ex.Is(err, ErrInvalidFailureRatio))
+	_, err = New(OptFailureRatio(1.5, 1))
+	assert.True(ex.Is(err, ErrInvalidFailureRatio))
+
+	b, err := New(OptFailureRatio(0.5, 1))
+	assert.Nil(err)
+	assert.NotNil(b.ShouldOpenProvider)
+
+	b, err = New(OptConfig(Config{FailureRatio: 0.5}))
+	assert.Nil(err)
+	assert.NotNil(b.ShouldOpenProvider)
+}
+
+func TestBreakerFailureRatioTransitions(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	now := time.Date(2020, 01, 02, 03, 04, 05, 0, time.UTC)
+	var transitions []string
+	b := MustNew(
+		OptClosedExpiryInterval(0),
+		OptOpenExpiryInterval(time.Minute),
+		OptHalfOpenMaxActions(2),
+		OptFailureRatio(0.5, 4),
+		OptNowProvider(func() time.Time { return now }),
+		OptOnStateChange(func(_ context.Context, from, to State, _ int64) {
+			transitions = append(transitions, fmt.Sprintf("%v->%v", from, to))
+		}),
+	)
+
+	// closed; under the ratio
+	assert.Nil(succeed(ctx, b))
+	assert.Nil(succeed(ctx, b))
+	assert.Nil(fail(ctx, b))
+	assert.Equal(StateClosed, b.EvaluateState(ctx))
+
+	// closed -> open; 2 of 4 actions failed
+	assert.Nil(fail(ctx, b))
+	snapshot := b.Snapshot(ctx)
+	assert.Equal(StateOpen, snapshot.State)
+	assert.Equal(Counts{}, snapshot.Counts)
+
+	// open; actions are rejected
+	err := succeed(ctx, b)
+	assert.True(ErrIsOpen(err))
+
+	// open -> half-open; after the open expiry interval
+	now = now.Add(time.Minute + time.Second)
+	assert.Equal(StateHalfOpen, b.EvaluateState(ctx))
+
+	// half-open -> closed; after the max actions succeed
+	assert.Nil(succeed(ctx, b))
+	snapshot = b.Snapshot(ctx)
+	assert.Equal(StateHalfOpen, snapshot.State)
+	assert.Equal(1, snapshot.Counts.TotalSuccesses)
+	assert.Nil(succeed(ctx, b))
+	assert.Equal(StateClosed, b.EvaluateState(ctx))
+
+	assert.Equal([]string{"closed->open", "open->half-open", "half-open->closed"}, transitions)
+}
+
+func TestBreakerPanic(t *testing.T) {
+	assert := assert.New(t)
+
+	b := createTestBreaker()
+	res, err := b.Do(context.Background(), func(_ context.Context) (interface{}, error) {
+		panic("only a test")
+	})
+	assert.Nil(res)
+	assert.NotNil(err)
+	assert.Equal(1, b.Snapshot(context.Background()).Counts.TotalFailures)
+}
+
+func TestBreakerConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	b := MustNew(OptClosedExpiryInterval(0), OptFailureRatio(0.9, 100))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if index%2 == 0 {
+					_ = succeed(ctx, b)
+				} else {
+					_ = fail(ctx, b)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	snapshot := b.Snapshot(ctx)
+	assert.Equal(StateClosed, snapshot.State)
+	assert.Equal(80, snapshot.Counts.Requests)
+	assert.Equal(40, snapshot.Counts.TotalSuccesses)
+	assert.Equal(40, snapshot.Counts.TotalFailures)
+}
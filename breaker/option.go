@@ -2,6 +2,8 @@ package breaker
 
 import (
 	"time"
+
+	"github.com/blend/go-sdk/ex"
 )
 
 // Option is a mutator for a breaker.
@@ -37,6 +39,9 @@ func OptConfig(cfg Config) Option {
 		b.HalfOpenMaxActions = cfg.HalfOpenMaxActions
 		b.ClosedExpiryInterval = cfg.ClosedExpiryInterval
 		b.OpenExpiryInterval = cfg.OpenExpiryInterval
+		if cfg.FailureRatio > 0 {
+			b.ShouldOpenProvider = ShouldOpenFailureRatio(cfg.FailureRatio, cfg.FailureRatioMinActions)
+		}
 		return nil
 	}
 }
@@ -65,6 +70,18 @@ func OptShouldOpenProvider(provider ShouldOpenProvider) Option {
 	}
 }
 
+// OptFailureRatio sets the ShouldOpenProvider on the breaker to open once a given ratio of
+// at least a given number of actions fail; see `ShouldOpenFailureRatio`.
+func OptFailureRatio(ratio float64, minActions int64) Option {
+	return func(b *Breaker) error {
+		if ratio <= 0 || ratio > 1 {
+			return ex.New(ErrInvalidFailureRatio, ex.OptMessagef("failure ratio: %v", ratio))
+		}
+		b.ShouldOpenProvider = ShouldOpenFailureRatio(ratio, minActions)
+		return nil
+	}
+}
+
 // OptNowProvider sets the now provider on the breaker.
 func OptNowProvider(provider NowProvider) Option {
 	return func(b *Breaker) error {
@@ -0,0 +1,8 @@
+package breaker
+
+// Snapshot is the state and counts of a breaker at a point in time, e.g. for reporting metrics.
+type Snapshot struct {
+	State      State  `json:"state"`
+	Generation int64  `json:"generation"`
+	Counts     Counts `json:"counts"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -28,6 +29,10 @@ var (
 	flagVerbose              *bool
 	flagDebug                *bool
 	flagFailFast             *bool
+	flagSkipDirs             *[]string
+	flagBaseline             *string
+	flagFormat               *string
+	flagDocs                 *bool
 )
 
 var (
@@ -47,6 +52,10 @@ func (c *config) Resolve(ctx context.Context) error {
 		configutil.SetString(&c.RulesFile, configutil.String(*flagRulesFile), configutil.String(c.RulesFile), configutil.String(profanity.DefaultRulesFile)),
 		configutil.SetStrings(&c.Include, configutil.Strings(*flagInclude), configutil.Strings(c.Include)),
 		configutil.SetStrings(&c.Exclude, configutil.Strings(*flagExclude), configutil.Strings(c.Exclude)),
+		configutil.SetStrings(&c.SkipDirs, configutil.Strings(*flagSkipDirs), configutil.Strings(c.SkipDirs), configutil.Strings(profanity.DefaultSkipDirs)),
+		configutil.SetString(&c.Baseline, configutil.String(*flagBaseline), configutil.String(c.Baseline)),
+		configutil.SetString(&c.Format, configutil.String(*flagFormat), configutil.String(c.Format), configutil.String(profanity.FormatText)),
+		configutil.SetBool(&c.Docs, configutil.Bool(flagDocs), configutil.Bool(c.Docs), configutil.Bool(ref.Bool(false))),
 	)
 }
 
@@ -59,16 +68,123 @@ EXCLUDES_EXAMPLE:
   pattern: [ "HerpDerp$" ]
   excludeFiles: [ "*_test.go" ]
 
+PATTERN_MESSAGE_EXAMPLE: # pattern failure messages can reference named capture groups
+  description: "please use the io and os equivalents"
+  pattern: [ "ioutil\\.(?P<name>[A-Za-z]+)\\(" ]
+  message: "found deprecated api ioutil.{{ .name }}"
+
 IMPORTS_EXAMPLE: # you can assert a go AST doesnt contains a given import by glob
   description: "dont include command stuff"
   importsContain: [ "github.com/blend/go-sdk/cmd/*" ]
+
+WARN_EXAMPLE: # warn severity rules are reported but do not fail the run
+  description: "prefer 'ex.New' to 'fmt.Errorf'"
+  severity: warn
+  contains: [ "fmt.Errorf" ]
+
+MAX_SIZE_EXAMPLE: # you can limit the size of files in bytes or lines
+  description: "please dont check in giant generated files"
+  includeFiles: [ "*.go" ]
+  maxBytes: 524288
+  maxLines: 10000
+
+MAX_PATH_EXAMPLE: # you can limit the length of file paths, e.g. for windows compatibility, and how deeply files are nested
+  description: "please keep paths short"
+  maxPathLength: 200
+  maxPathDepth: 8
+
+MAX_FUNCTION_LINES_EXAMPLE: # you can limit the length of go function bodies; other files are skipped
+  description: "please break up long functions"
+  excludeFiles: [ "*_test.go" ]
+  maxFunctionLines: 100
+
+GO_FMT_EXAMPLE: # you can require go files are gofmt clean; other files are skipped
+  description: "please run gofmt"
+  goFmt: true
+
+GO_IMPORTS_EXAMPLE: # you can require go imports are grouped and sorted as goimports would; other files are skipped
+  description: "please run goimports -local github.com/blend/go-sdk"
+  goImportsGrouped: true
+  goImportsLocalPrefix: "github.com/blend/go-sdk" # can be a csv
+
+MUST_PARSE_EXAMPLE: # you can require yaml and json files parse; files with other extensions are skipped
+  description: "please fix the malformed config file"
+  mustParseYAML: true
+  mustParseJSON: true
+
+FORBID_BINARY_EXAMPLE: # you can forbid binary files, optionally only those larger than a given size in bytes
+  description: "please dont check in binaries; use the artifact store"
+  forbidBinary: true
+  maxBinaryBytes: 65536
+  forbidBinaryAllowExtensions: [ ".png", ".ico" ]
+
+FORBID_EXAMPLE: # you can forbid files by name or extension regardless of their contents
+  description: "please dont check in binaries"
+  forbidExtension: ".exe"
+
+GO_MOD_REPLACE_EXAMPLE: # you can forbid go.mod replace directives that point at local paths, or that replace given modules by glob
+  description: "please dont commit local replace directives"
+  forbidGoModLocalReplace: true
+  forbidGoModReplace: [ "github.com/blend/go-sdk" ]
+
+HEADER_EXAMPLE: # you can require the first lines of a file contain a string, e.g. a copyright notice
+  description: "please add the copyright header"
+  includeFiles: [ "*.go" ]
+  requireHeaderContains: "Copyright"
+  requireHeaderWithinLines: 5 # defaults to 10
+
+GENERATED_MARKER_EXAMPLE: # you can require generated files have a "// Code generated ... DO NOT EDIT." comment before their first non-comment text
+  description: "please regenerate the file with go generate"
+  includeFiles: [ "*.gen.go" ]
+  requireGeneratedMarker: true
+
+BUILD_TAGS_EXAMPLE: # you can forbid go files gated behind build tags, e.g. "//go:build wip"
+  description: "please remove the wip build tag before merging"
+  forbidBuildTags: [ "wip" ]
+
+TODO_EXAMPLE: # you can require todo and fixme comments have an owner, e.g. "TODO(user):"
+  description: "please add an owner to the todo"
+  includeFiles: [ "*.go" ]
+  requireAnnotatedTodos: true
+
+INDENTATION_EXAMPLE: # you can require lines are indented with "tabs" or "spaces"; blank lines are ignored
+  description: "please indent yaml with two spaces"
+  includeFiles: [ "*.yml", "*.yaml" ]
+  indentation: spaces
+  indentationWidth: 2 # optional, requires space indentation is a multiple of the width
+
+DEBUG_STATEMENTS_EXAMPLE: # you can forbid debug statements by file extension, e.g. "console.log(" in ".js" files; lines with a "profanity:allow" comment are exempt
+  description: "please remove debugging statements"
+  forbidDebugStatements: true
+  debugStatements: # replaces the default statements for an extension
+    ".go": [ "fmt.Println(", "log.Printf(\"DEBUG" ]
+
+REQUIRE_TEST_FILE_EXAMPLE: # you can require go files have a matching test file, e.g. "foo_test.go" for "foo.go"
+  description: "please add tests"
+  requireTestFile: true
+  requireTestFileExempt: [ "main.go", "doc.go" ] # defaults to "main.go"
+
+SECRETS_EXAMPLE: # you can detect hardcoded secrets, e.g. aws access key ids, private keys, and high entropy values assigned to names like "apiKey"
+  description: "please dont commit credentials"
+  detectSecrets: true
+  detectSecretsAllow: [ "EXAMPLE$" ] # regex patterns for known safe matches
+
+PACKAGE_NAME_EXAMPLE: # you can require go package names match a pattern, or the name of their directory; "main" packages are allowed in any directory
+  description: "please use lowercase package names without underscores"
+  packageName: "^[a-z][a-z0-9]*$"
+  packageNameMatchesDir: true
+
+LINE_ENDINGS_EXAMPLE: # you can require a line ending style, either "lf" or "crlf"
+  description: "please use unix line endings"
+  excludeFiles: [ "*.png" ]
+  lineEndings: "lf"
 `
 
 func command() *cobra.Command {
 	root := &cobra.Command{
 		Use:   "profanity",
 		Short: "Enforce profanity rules in a directory tree.",
-		Long:  "Enforce profanity rules in a directory tree with inherited rules for each child directory.",
+		Long:  "Enforce profanity rules in a directory tree with inherited rules for each child directory. A rule can be suppressed inline with a `profanity:disable=RULE_ID` comment for a whole file, or a `profanity:disable-next-line=RULE_ID` comment for the line after it.",
 		Example: fmt.Sprintf(`
 # Run a basic rules set
 profanity --rules=PROFANITY_RULES
@@ -79,12 +195,29 @@ profanity --rules=PROFANITY_RULES --exclude="*_test.go"
 # Run a basic rules set with included and excluded files by glob
 profanity --rules=PROFANITY_RULES --include="*.go" --exclude="*_test.go"
 
+# Run a basic rules set, suppressing the violations recorded in a baseline file
+# (the baseline file is created with the current violations if it does not exist)
+profanity --rules=PROFANITY_RULES --baseline=.profanity_baseline.yml
+
+# Run a basic rules set, streaming violations to stdout as json lines
+profanity --rules=PROFANITY_RULES --format=jsonl
+
+# Write a markdown table of the rules defined in every rules file, e.g. to publish them
+profanity --rules=PROFANITY_RULES --docs
+
 # An example rule file looks like
 
 """ yaml
 %s
 """
 
+# A rule file can opt its directory (and its children) out of the rules inherited
+# from parent directories, e.g. for vendored code, with the file level directive
+
+""" yaml
+inherit: false
+"""
+
 For more example rule files, see https://github.com/blend/go-sdk/tree/master/PROFANITY_RULES.yml
 `, configExample),
 	}
@@ -95,6 +228,10 @@ For more example rule files, see https://github.com/blend/go-sdk/tree/master/PRO
 	flagVerbose = root.Flags().BoolP("verbose", "v", false, "If we should show verbose output.")
 	flagDebug = root.Flags().BoolP("debug", "d", false, "If we should show debug output.")
 	flagFailFast = root.Flags().Bool("fail-fast", false, "If we should fail the run after the first error.")
+	flagSkipDirs = root.Flags().StringSlice("skip-dirs", nil, "Directory names to skip as a csv; defaults to "+strings.Join(profanity.DefaultSkipDirs, ",")+", and "+strings.Join(profanity.AlwaysSkipDirs, ",")+" is always skipped")
+	flagBaseline = root.Flags().String("baseline", "", "A baseline file of violations to suppress; it is created with the current violations if it does not exist.")
+	flagFormat = root.Flags().String("format", profanity.FormatText, "The output format, either text or jsonl; jsonl streams one json object per violation to stdout.")
+	flagDocs = root.Flags().Bool("docs", false, "If we should write a markdown table of the rules in every rules file to stdout instead of checking files.")
 	return root
 }
 
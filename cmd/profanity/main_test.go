@@ -6,6 +6,7 @@ import (
 
 	"github.com/blend/go-sdk/assert"
 	"github.com/blend/go-sdk/profanity"
+	"github.com/blend/go-sdk/yaml"
 )
 
 func TestConfigExampleParses(t *testing.T) {
@@ -15,5 +16,13 @@ func TestConfigExampleParses(t *testing.T) {
 
 	rules, err := p.RulesFromReader("config.yml", bytes.NewReader([]byte(configExample)))
 	assert.Nil(err)
-	assert.Len(rules, 3)
+
+	var examples map[string]interface{}
+	assert.Nil(yaml.Unmarshal([]byte(configExample), &examples))
+	assert.NotEmpty(examples)
+	for id := range examples {
+		_, ok := rules[id]
+		assert.True(ok, "example should parse as a rule: "+id)
+	}
+	assert.Len(rules, len(examples))
 }
@@ -0,0 +1,37 @@
+package configutil
+
+import (
+	"io"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// DeserializeStream decodes a stream of documents with a given extension one at a time, passing each
+// to a given handler, e.g. for multi-document yaml separated by `---`, or for concatenated json values.
+//
+// Each document is decoded into a new value from a given constructor, e.g. `func() configutil.Any { return new(Manifest) }`,
+// or into a generic value if the constructor is nil. Decoding stops at the end of the stream, or at the first error the handler returns.
+func DeserializeStream(ext string, r io.Reader, newDoc func() Any, handler func(doc Any) error) error {
+	decoder, err := newDecoder(ext, r)
+	if err != nil {
+		return err
+	}
+	for {
+		var doc Any
+		if newDoc != nil {
+			doc = newDoc()
+			err = decoder.Decode(doc)
+		} else {
+			err = decoder.Decode(&doc)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return ex.New(err)
+		}
+		if err = handler(doc); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package configutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestDeserializeStreamYAML(t *testing.T) {
+	assert := assert.New(t)
+
+	contents := `env: first
+other: foo
+---
+env: second
+---
+env: third
+other: bar
+`
+	var docs []config
+	err := DeserializeStream(ExtensionYAML, strings.NewReader(contents), func() Any { return new(config) }, func(doc Any) error {
+		docs = append(docs, *doc.(*config))
+		return nil
+	})
+	assert.Nil(err)
+	assert.Equal([]config{
+		{Environment: "first", Other: "foo"},
+		{Environment: "second"},
+		{Environment: "third", Other: "bar"},
+	}, docs)
+}
+
+func TestDeserializeStreamJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var docs []Any
+	err := DeserializeStream("json", strings.NewReader(`{"env":"first"} {"env":"second"}`+"\n"+`[1,2]`), nil, func(doc Any) error {
+		docs = append(docs, doc)
+		return nil
+	})
+	assert.Nil(err)
+	assert.Len(docs, 3)
+	assert.Equal(map[string]interface{}{"env": "first"}, docs[0])
+	assert.Equal(map[string]interface{}{"env": "second"}, docs[1])
+	assert.Equal([]interface{}{1.0, 2.0}, docs[2])
+}
+
+func TestDeserializeStreamErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	handler := func(doc Any) error { return nil }
+	assert.True(IsInvalidConfigExtension(DeserializeStream(".toml", strings.NewReader(""), nil, handler)))
+	assert.NotNil(DeserializeStream(ExtensionJSON, strings.NewReader(`{"env":"first"} {`), nil, handler))
+
+	var count int
+	err := DeserializeStream(ExtensionYAML, strings.NewReader("env: first\n---\nenv: second\n"), nil, func(doc Any) error {
+		count++
+		return fmt.Errorf("stop")
+	})
+	assert.Equal("stop", err.Error())
+	assert.Equal(1, count)
+}
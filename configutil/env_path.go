@@ -0,0 +1,55 @@
+package configutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnvPath returns the environment specific variant of a config path, that is the
+// service environment inserted before the extension, e.g. `config.prod.yaml` for
+// `config.yaml` and the `prod` service environment.
+// If the service environment is unset, the path is returned unchanged.
+func EnvPath(path, serviceEnv string) string {
+	if serviceEnv == "" {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + serviceEnv + ext
+}
+
+// EnvPaths returns the environment specific variant of a config path followed by the path itself,
+// which is the order they should be tested in.
+func EnvPaths(path, serviceEnv string) []string {
+	if envPath := EnvPath(path, serviceEnv); envPath != path {
+		return []string{envPath, path}
+	}
+	return []string{path}
+}
+
+// ResolveEnvPath returns the environment specific variant of a config path if a file exists
+// at it, and the path itself otherwise.
+func ResolveEnvPath(path, serviceEnv string) string {
+	envPath := EnvPath(path, serviceEnv)
+	if _, err := os.Stat(envPath); err == nil {
+		return envPath
+	}
+	return path
+}
+
+// OptAddPreferredEnvFilePaths adds paths, each preceded by its environment specific variant,
+// to search first for the config file.
+//
+// The service environment is read from the `SERVICE_ENV` variable of the options environment,
+// so this option should be given after `OptEnv` if both are used.
+func OptAddPreferredEnvFilePaths(paths ...string) Option {
+	return func(co *ConfigOptions) error {
+		serviceEnv := co.Env.ServiceEnv()
+		var envPaths []string
+		for _, path := range paths {
+			envPaths = append(envPaths, EnvPaths(path, serviceEnv)...)
+		}
+		co.FilePaths = append(envPaths, co.FilePaths...)
+		return nil
+	}
+}
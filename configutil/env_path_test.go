@@ -0,0 +1,49 @@
+package configutil
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/env"
+)
+
+func TestEnvPath(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("config.prod.yaml", EnvPath("config.yaml", "prod"))
+	assert.Equal("_config/config.dev.json", EnvPath("_config/config.json", "dev"))
+	assert.Equal("config.yaml", EnvPath("config.yaml", ""))
+
+	assert.Equal([]string{"config.prod.yaml", "config.yaml"}, EnvPaths("config.yaml", "prod"))
+	assert.Equal([]string{"config.yaml"}, EnvPaths("config.yaml", ""))
+}
+
+func TestResolveEnvPath(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("testdata/env/config.prod.yaml", ResolveEnvPath("testdata/env/config.yaml", "prod"))
+	assert.Equal("testdata/env/config.yaml", ResolveEnvPath("testdata/env/config.yaml", "dev"))
+	assert.Equal("testdata/env/config.yaml", ResolveEnvPath("testdata/env/config.yaml", ""))
+}
+
+func TestReadEnvFilePaths(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg config
+	path, err := Read(&cfg,
+		OptEnv(env.Vars{env.VarServiceEnv: "prod"}),
+		OptAddPreferredEnvFilePaths("testdata/env/config.yaml"),
+	)
+	assert.Nil(err)
+	assert.Equal("testdata/env/config.prod.yaml", path)
+	assert.Equal("prod", cfg.Other)
+
+	cfg = config{}
+	path, err = Read(&cfg,
+		OptEnv(env.Vars{env.VarServiceEnv: "sandbox"}),
+		OptAddPreferredEnvFilePaths("testdata/env/config.yaml"),
+	)
+	assert.Nil(err)
+	assert.Equal("testdata/env/config.yaml", path)
+	assert.Equal("base", cfg.Other)
+}
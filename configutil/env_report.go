@@ -0,0 +1,166 @@
+package configutil
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/reflectutil"
+)
+
+const (
+	// ErrInvalidEnvReportTarget is returned if the env report target is not a struct or a pointer to a struct.
+	ErrInvalidEnvReportTarget = ex.Class("config env report target must be a struct or a pointer to a struct")
+)
+
+const (
+	// EnvReportMaxDistance is the maximum edit distance between an unmapped env var
+	// and a mapped env var for the unmapped env var to be reported as a likely typo.
+	EnvReportMaxDistance = 2
+)
+
+// EnvReportField is a config field that is mapped to an env var.
+type EnvReportField struct {
+	// EnvVar is the env var name from the field `env` tag.
+	EnvVar string
+	// Field is the path to the field in the config, e.g. `Web.BindAddr`.
+	Field string
+}
+
+// EnvReportUnmapped is an env var that is set but not mapped to a config field.
+type EnvReportUnmapped struct {
+	// EnvVar is the env var name.
+	EnvVar string
+	// Suggestion is the closest mapped env var, if the env var is likely a typo of it.
+	Suggestion string
+}
+
+// EnvReport is a report of how a set of env vars map to the `env` tagged fields of a config.
+type EnvReport struct {
+	// Populated are the mapped fields whose env vars are set.
+	Populated []EnvReportField
+	// Unset are the mapped fields whose env vars are not set.
+	Unset []EnvReportField
+	// Unmapped are the set env vars that look like config env vars but are not mapped to a field.
+	Unmapped []EnvReportUnmapped
+}
+
+// ReportEnv returns a report of the env vars a config would be populated from by `env.Vars.ReadInto`,
+// without modifying the config; it is meant to catch silent misconfiguration, e.g. setting `PORTT` instead of `PORT`.
+//
+// A set env var is reported as unmapped if it starts with any of the given prefixes (e.g. the prefix of an app's
+// env vars), or if it is a few edits from a mapped env var, in which case that env var is suggested.
+// The report lists are sorted by env var.
+func ReportEnv(ref Any, vars env.Vars, prefixes ...string) (*EnvReport, error) {
+	refType := reflect.TypeOf(ref)
+	if refType != nil && refType.Kind() == reflect.Ptr {
+		refType = refType.Elem()
+	}
+	if refType == nil || refType.Kind() != reflect.Struct {
+		return nil, ex.New(ErrInvalidEnvReportTarget, ex.OptMessagef("type: %T", ref))
+	}
+
+	var report EnvReport
+	mapped := make(map[string]bool)
+	for _, field := range envReportFields(refType, "") {
+		mapped[field.EnvVar] = true
+		if vars.Has(field.EnvVar) {
+			report.Populated = append(report.Populated, field)
+		} else {
+			report.Unset = append(report.Unset, field)
+		}
+	}
+
+	for envVar := range vars {
+		if mapped[envVar] {
+			continue
+		}
+		if suggestion := envReportSuggestion(envVar, mapped); suggestion != "" {
+			report.Unmapped = append(report.Unmapped, EnvReportUnmapped{EnvVar: envVar, Suggestion: suggestion})
+			continue
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(envVar, prefix) {
+				report.Unmapped = append(report.Unmapped, EnvReportUnmapped{EnvVar: envVar})
+				break
+			}
+		}
+	}
+
+	sort.Slice(report.Populated, func(i, j int) bool { return report.Populated[i].EnvVar < report.Populated[j].EnvVar })
+	sort.Slice(report.Unset, func(i, j int) bool { return report.Unset[i].EnvVar < report.Unset[j].EnvVar })
+	sort.Slice(report.Unmapped, func(i, j int) bool { return report.Unmapped[i].EnvVar < report.Unmapped[j].EnvVar })
+	return &report, nil
+}
+
+// envReportFields returns the env tagged fields of a struct type, recursing into nested structs as `ReadInto` does.
+func envReportFields(t reflect.Type, path string) (output []EnvReportField) {
+	for index := 0; index < t.NumField(); index++ {
+		field := t.Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		if field.Type.Kind() == reflect.Struct {
+			output = append(output, envReportFields(field.Type, fieldPath)...)
+			continue
+		}
+		tag := field.Tag.Get(reflectutil.FieldTagEnv)
+		if envVar := strings.Split(tag, ",")[0]; envVar != "" {
+			output = append(output, EnvReportField{EnvVar: envVar, Field: fieldPath})
+		}
+	}
+	return
+}
+
+// envReportSuggestion returns the closest mapped env var to a given env var, if any are close enough to be a likely typo.
+// Shorter env vars allow fewer edits, one per three characters up to `EnvReportMaxDistance`, so e.g. `HOME` is not a typo of `PORT`.
+func envReportSuggestion(envVar string, mapped map[string]bool) (suggestion string) {
+	best := -1
+	for candidate := range mapped {
+		maxDistance := len(candidate) / 3
+		if maxDistance > EnvReportMaxDistance {
+			maxDistance = EnvReportMaxDistance
+		}
+		distance := editDistance(envVar, candidate)
+		if distance > maxDistance {
+			continue
+		}
+		if best < 0 || distance < best || (distance == best && candidate < suggestion) {
+			best, suggestion = distance, candidate
+		}
+	}
+	return
+}
+
+// editDistance returns the levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if deletion := previous[j] + 1; deletion < current[j] {
+				current[j] = deletion
+			}
+			if insertion := current[j-1] + 1; insertion < current[j] {
+				current[j] = insertion
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
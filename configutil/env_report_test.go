@@ -0,0 +1,72 @@
+package configutil
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+)
+
+type envReportConfig struct {
+	Port     int      `env:"PORT"`
+	BindAddr string   `env:"BIND_ADDR"`
+	Hosts    []string `env:"APP_HOSTS,csv"`
+	Untagged string
+	Web      struct {
+		LogLevel string `env:"APP_LOG_LEVEL"`
+	}
+}
+
+func TestReportEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	vars := env.Vars{
+		"BIND_ADDR":    ":8080",
+		"APP_HOSTS":    "a,b",
+		"PORTT":        "8080",
+		"APP_LOG_LEVL": "debug",
+		"APP_UNKNOWN":  "true",
+		"HOME":         "/root",
+		"PATH":         "/usr/bin",
+	}
+	var cfg envReportConfig
+	report, err := ReportEnv(&cfg, vars, "APP_")
+	assert.Nil(err)
+	assert.Zero(cfg.Port, "the config should not be modified")
+
+	assert.Equal([]EnvReportField{
+		{EnvVar: "APP_HOSTS", Field: "Hosts"},
+		{EnvVar: "BIND_ADDR", Field: "BindAddr"},
+	}, report.Populated)
+	assert.Equal([]EnvReportField{
+		{EnvVar: "APP_LOG_LEVEL", Field: "Web.LogLevel"},
+		{EnvVar: "PORT", Field: "Port"},
+	}, report.Unset)
+	assert.Equal([]EnvReportUnmapped{
+		{EnvVar: "APP_LOG_LEVL", Suggestion: "APP_LOG_LEVEL"},
+		{EnvVar: "APP_UNKNOWN"},
+		{EnvVar: "PORTT", Suggestion: "PORT"},
+	}, report.Unmapped)
+
+	report, err = ReportEnv(envReportConfig{}, env.Vars{"PORT": "8080", "APP_UNKNOWN": "true"})
+	assert.Nil(err)
+	assert.Equal([]EnvReportField{{EnvVar: "PORT", Field: "Port"}}, report.Populated)
+	assert.Empty(report.Unmapped, "without prefixes only likely typos are unmapped")
+
+	_, err = ReportEnv("not a struct", vars)
+	assert.True(ex.Is(err, ErrInvalidEnvReportTarget))
+	_, err = ReportEnv(nil, vars)
+	assert.True(ex.Is(err, ErrInvalidEnvReportTarget))
+}
+
+func TestEditDistance(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, editDistance("PORT", "PORT"))
+	assert.Equal(1, editDistance("PORTT", "PORT"))
+	assert.Equal(1, editDistance("PRT", "PORT"))
+	assert.Equal(1, editDistance("PORX", "PORT"))
+	assert.Equal(3, editDistance("HOME", "PORT"))
+	assert.Equal(4, editDistance("", "PORT"))
+}
@@ -12,6 +12,9 @@ const (
 
 	// ErrInvalidConfigExtension is a common error.
 	ErrInvalidConfigExtension = ex.Class("config extension invalid")
+
+	// ErrConfigRemoteStatus is returned when a remote config read returns a non-2xx status.
+	ErrConfigRemoteStatus = ex.Class("config remote read failed; non-2xx status")
 )
 
 // IsIgnored returns if we should ignore the config read error.
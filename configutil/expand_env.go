@@ -0,0 +1,88 @@
+package configutil
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+)
+
+const (
+	// ErrInvalidExpandEnvTarget is returned if the expand env target is not a pointer to a struct.
+	ErrInvalidExpandEnvTarget = ex.Class("config expand env target must be a pointer to a struct")
+)
+
+// FieldTagExpand is the struct tag that opts config fields into env var expansion, i.e. `expand:"true"`.
+const FieldTagExpand = "expand"
+
+// ExpandEnv expands `${VAR}` references in the string fields of a config that are tagged `expand:"true"`,
+// using a given set of env vars; references to unset env vars expand to the empty string.
+//
+// It is called by `Read` after the config file is deserialized. Only braced references are expanded, so
+// values that contain a bare `$`, e.g. passwords, are not changed. Tagged fields can be `string`, `*string`
+// or `[]string`, and nested structs, or pointers to structs, are expanded as well.
+func ExpandEnv(ref Any, vars env.Vars) error {
+	refValue := reflect.ValueOf(ref)
+	if refValue.Kind() != reflect.Ptr || refValue.IsNil() || refValue.Elem().Kind() != reflect.Struct {
+		return ex.New(ErrInvalidExpandEnvTarget, ex.OptMessagef("type: %T", ref))
+	}
+	expandEnvFields(refValue.Elem(), vars)
+	return nil
+}
+
+// expandEnvFields expands the tagged fields of a struct value.
+func expandEnvFields(value reflect.Value, vars env.Vars) {
+	valueType := value.Type()
+	for index := 0; index < valueType.NumField(); index++ {
+		field := valueType.Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldValue := value.Field(index)
+		if fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() && fieldValue.Elem().Kind() == reflect.Struct {
+			fieldValue = fieldValue.Elem()
+		}
+		if fieldValue.Kind() == reflect.Struct {
+			expandEnvFields(fieldValue, vars)
+			continue
+		}
+		if field.Tag.Get(FieldTagExpand) != "true" {
+			continue
+		}
+		switch {
+		case fieldValue.Kind() == reflect.String:
+			fieldValue.SetString(expandEnvReferences(fieldValue.String(), vars))
+		case fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() && fieldValue.Elem().Kind() == reflect.String:
+			fieldValue.Elem().SetString(expandEnvReferences(fieldValue.Elem().String(), vars))
+		case fieldValue.Kind() == reflect.Slice && fieldValue.Type().Elem().Kind() == reflect.String:
+			for element := 0; element < fieldValue.Len(); element++ {
+				fieldValue.Index(element).SetString(expandEnvReferences(fieldValue.Index(element).String(), vars))
+			}
+		}
+	}
+}
+
+// expandEnvReferences replaces `${VAR}` references in a value with the values of the env vars.
+// Unterminated references are left as is.
+func expandEnvReferences(value string, vars env.Vars) string {
+	if !strings.Contains(value, "${") {
+		return value
+	}
+	var output strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(value[start:], '}')
+		if end < 0 {
+			break
+		}
+		output.WriteString(value[:start])
+		output.WriteString(vars.Get(value[start+2 : start+end]))
+		value = value[start+end+1:]
+	}
+	output.WriteString(value)
+	return output.String()
+}
@@ -0,0 +1,88 @@
+package configutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/ref"
+)
+
+type expandEnvDatabaseConfig struct {
+	DSN      string `yaml:"dsn" expand:"true"`
+	Password string `yaml:"password"`
+}
+
+type expandEnvConfig struct {
+	Name     string                   `yaml:"name" expand:"true"`
+	Literal  string                   `yaml:"literal"`
+	Optional *string                  `yaml:"optional" expand:"true"`
+	Hosts    []string                 `yaml:"hosts" expand:"true"`
+	Count    int                      `yaml:"count" expand:"true"`
+	DB       expandEnvDatabaseConfig  `yaml:"db"`
+	Replica  *expandEnvDatabaseConfig `yaml:"replica"`
+}
+
+func TestExpandEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	vars := env.Vars{
+		"APP_NAME": "test-app",
+		"DB_HOST":  "db.local",
+		"REGION":   "us-east-1",
+	}
+
+	cfg := expandEnvConfig{
+		Name:     "${APP_NAME}-${REGION}",
+		Literal:  "${APP_NAME}",
+		Optional: ref.String("${REGION}"),
+		Hosts:    []string{"${DB_HOST}:5432", "static:5432"},
+		DB: expandEnvDatabaseConfig{
+			DSN:      "postgres://${DB_HOST}/${UNSET}",
+			Password: "pa$$word${APP_NAME}",
+		},
+		Replica: &expandEnvDatabaseConfig{
+			DSN: "postgres://replica.${DB_HOST}",
+		},
+	}
+	assert.Nil(ExpandEnv(&cfg, vars))
+
+	assert.Equal("test-app-us-east-1", cfg.Name)
+	assert.Equal("${APP_NAME}", cfg.Literal, "untagged fields should not be expanded")
+	assert.Equal("us-east-1", *cfg.Optional)
+	assert.Equal([]string{"db.local:5432", "static:5432"}, cfg.Hosts)
+	assert.Equal("postgres://db.local/", cfg.DB.DSN)
+	assert.Equal("pa$$word${APP_NAME}", cfg.DB.Password, "untagged nested fields should not be expanded")
+	assert.Equal("postgres://replica.db.local", cfg.Replica.DSN)
+
+	assert.True(ex.Is(ExpandEnv(cfg, vars), ErrInvalidExpandEnvTarget))
+	assert.True(ex.Is(ExpandEnv(ref.String("${APP_NAME}"), vars), ErrInvalidExpandEnvTarget))
+}
+
+func TestExpandEnvReferences(t *testing.T) {
+	assert := assert.New(t)
+
+	vars := env.Vars{"FOO": "foo", "BAR": "bar"}
+	assert.Equal("", expandEnvReferences("", vars))
+	assert.Equal("foo", expandEnvReferences("${FOO}", vars))
+	assert.Equal("foo/bar", expandEnvReferences("${FOO}/${BAR}", vars))
+	assert.Equal("$FOO $ $$", expandEnvReferences("$FOO $ $$", vars), "bare references should not be expanded")
+	assert.Equal("foo ${BAR", expandEnvReferences("${FOO} ${BAR", vars), "unterminated references should not be expanded")
+}
+
+func TestReadExpandEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg expandEnvConfig
+	_, err := Read(&cfg,
+		OptEnv(env.Vars{"APP_NAME": "test-app", "DB_HOST": "db.local"}),
+		OptContents(ExtensionYAML, bytes.NewBufferString("name: ${APP_NAME}\nliteral: ${APP_NAME}\ndb:\n  dsn: postgres://${DB_HOST}\n  password: pa$${APP_NAME}\n")),
+	)
+	assert.Nil(err)
+	assert.Equal("test-app", cfg.Name)
+	assert.Equal("${APP_NAME}", cfg.Literal)
+	assert.Equal("postgres://db.local", cfg.DB.DSN)
+	assert.Equal("pa$${APP_NAME}", cfg.DB.Password)
+}
@@ -0,0 +1,138 @@
+package configutil
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/reflectutil"
+)
+
+const (
+	// ErrInvalidExplainTarget is returned if the explain target is not a pointer to a struct.
+	ErrInvalidExplainTarget = ex.Class("config explain target must be a pointer to a struct")
+)
+
+// Explain sources.
+const (
+	// ExplainSourceDefault is the source of fields that are not set by the file or the environment.
+	ExplainSourceDefault = "default"
+	// ExplainSourceFile is the source of fields that are set by the config file.
+	ExplainSourceFile = "file"
+	// ExplainSourceEnv is the source of fields that are set by the environment.
+	ExplainSourceEnv = "env"
+)
+
+// ExplainField is where the value of a config field came from.
+type ExplainField struct {
+	// Field is the path to the field in the config, e.g. `Web.BindAddr`.
+	Field string
+	// Source is where the value came from, one of `default`, `file`, or `env`.
+	Source string
+	// EnvVar is the env var name from the field `env` tag, if any.
+	EnvVar string
+	// Value is the final value of the field.
+	Value interface{}
+}
+
+// ExplainReport is a report of where the values of the fields of a config came from.
+type ExplainReport struct {
+	// Path is the config file path.
+	Path string
+	// Fields are the config fields, in declaration order.
+	Fields []ExplainField
+}
+
+// Source returns the source of a given field, or an empty string if the field is not in the report.
+func (er ExplainReport) Source(field string) string {
+	for _, explained := range er.Fields {
+		if explained.Field == field {
+			return explained.Source
+		}
+	}
+	return ""
+}
+
+// String returns a human readable report, one field per line, e.g. `Web.BindAddr = :8080 (env: BIND_ADDR)`.
+func (er ExplainReport) String() string {
+	lines := []string{fmt.Sprintf("config file: %s", er.Path)}
+	for _, field := range er.Fields {
+		source := field.Source
+		if field.Source == ExplainSourceEnv {
+			source = fmt.Sprintf("%s: %s", field.Source, field.EnvVar)
+		}
+		lines = append(lines, fmt.Sprintf("%s = %v (%s)", field.Field, field.Value, source))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Explain reads a config file at a given path into a config, then overlays the environment on it, as
+// `env.Env().ReadInto` would, and returns a report of where each field's final value came from.
+//
+// A field's source is `env` if its `env` tagged env var is set, `file` if the file sets it to a non-zero value,
+// and `default` otherwise, i.e. it kept the value the config had before it was read. Resolvers are not called.
+func Explain(ref Any, path string) (*ExplainReport, error) {
+	refValue := reflect.ValueOf(ref)
+	if refValue.Kind() != reflect.Ptr || refValue.IsNil() || refValue.Elem().Kind() != reflect.Struct {
+		return nil, ex.New(ErrInvalidExplainTarget, ex.OptMessagef("type: %T", ref))
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, ex.New(err)
+	}
+	// the file is read into an empty config as well so the fields it sets can be told apart from the defaults.
+	fileValue := reflect.New(refValue.Elem().Type())
+	if err = deserialize(filepath.Ext(path), bytes.NewReader(contents), fileValue.Interface()); err != nil {
+		return nil, err
+	}
+	if err = deserialize(filepath.Ext(path), bytes.NewReader(contents), ref); err != nil {
+		return nil, err
+	}
+	vars := env.Env()
+	if err = vars.ReadInto(ref); err != nil {
+		return nil, err
+	}
+
+	return &ExplainReport{
+		Path:   path,
+		Fields: explainFields(vars, refValue.Elem(), fileValue.Elem(), ""),
+	}, nil
+}
+
+// explainFields returns the sources of the fields of a struct value, recursing into nested structs.
+func explainFields(vars env.Vars, value, fileValue reflect.Value, path string) (output []ExplainField) {
+	for index := 0; index < value.NumField(); index++ {
+		field := value.Type().Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		if field.Type.Kind() == reflect.Struct {
+			output = append(output, explainFields(vars, value.Field(index), fileValue.Field(index), fieldPath)...)
+			continue
+		}
+
+		explained := ExplainField{
+			Field:  fieldPath,
+			Source: ExplainSourceDefault,
+			EnvVar: strings.Split(field.Tag.Get(reflectutil.FieldTagEnv), ",")[0],
+			Value:  value.Field(index).Interface(),
+		}
+		if explained.EnvVar != "" && vars.Has(explained.EnvVar) {
+			explained.Source = ExplainSourceEnv
+		} else if !fileValue.Field(index).IsZero() {
+			explained.Source = ExplainSourceFile
+		}
+		output = append(output, explained)
+	}
+	return
+}
@@ -0,0 +1,67 @@
+package configutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+)
+
+type explainConfig struct {
+	BindAddr string `yaml:"bindAddr" env:"BIND_ADDR"`
+	Port     int    `yaml:"port" env:"PORT"`
+	Name     string `yaml:"name"`
+	Web      struct {
+		LogLevel string `yaml:"logLevel" env:"LOG_LEVEL"`
+	} `yaml:"web"`
+}
+
+func TestExplain(t *testing.T) {
+	assert := assert.New(t)
+
+	defer env.Restore()
+	env.SetEnv(env.New())
+	env.Env().Set("PORT", "9090")
+	env.Env().Set("LOG_LEVEL", "debug")
+
+	dir, err := ioutil.TempDir("", "configutil-explain")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.yml")
+	assert.Nil(ioutil.WriteFile(path, []byte("bindAddr: :8080\nport: 8080\nweb:\n  logLevel: info\n"), 0644))
+
+	cfg := explainConfig{Name: "default-name"}
+	report, err := Explain(&cfg, path)
+	assert.Nil(err)
+	assert.Equal(":8080", cfg.BindAddr)
+	assert.Equal(9090, cfg.Port)
+	assert.Equal("debug", cfg.Web.LogLevel)
+
+	assert.Equal(path, report.Path)
+	assert.Equal([]ExplainField{
+		{Field: "BindAddr", Source: ExplainSourceFile, EnvVar: "BIND_ADDR", Value: ":8080"},
+		{Field: "Port", Source: ExplainSourceEnv, EnvVar: "PORT", Value: 9090},
+		{Field: "Name", Source: ExplainSourceDefault, Value: "default-name"},
+		{Field: "Web.LogLevel", Source: ExplainSourceEnv, EnvVar: "LOG_LEVEL", Value: "debug"},
+	}, report.Fields)
+	assert.Equal(ExplainSourceFile, report.Source("BindAddr"))
+	assert.Empty(report.Source("Unknown"))
+	assert.Contains(report.String(), "Port = 9090 (env: PORT)")
+	assert.Contains(report.String(), "BindAddr = :8080 (file)")
+	assert.Contains(report.String(), "Name = default-name (default)")
+}
+
+func TestExplainErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg explainConfig
+	_, err := Explain(cfg, "testdata/config.yml")
+	assert.True(ex.Is(err, ErrInvalidExplainTarget))
+
+	_, err = Explain(&cfg, "testdata/not-a-config.yml")
+	assert.True(IsNotExist(err))
+}
@@ -0,0 +1,193 @@
+package configutil
+
+import (
+	"reflect"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// Merge strategies.
+const (
+	// MergeSlicesReplace replaces a base slice wholesale with a non-empty overlay slice.
+	MergeSlicesReplace = "replace"
+	// MergeSlicesAppend appends the overlay slice elements to the base slice.
+	MergeSlicesAppend = "append"
+
+	// MergeMapsDeep merges overlay map entries into the base map, merging the values of keys present in both.
+	MergeMapsDeep = "deep"
+	// MergeMapsReplace replaces a base map wholesale with a non-nil overlay map.
+	MergeMapsReplace = "replace"
+)
+
+const (
+	// ErrInvalidMergeTarget is returned if the merge destination is not a non-nil pointer.
+	ErrInvalidMergeTarget = ex.Class("config merge target must be a non-nil pointer")
+	// ErrMergeTypeMismatch is returned if the merge source and destination types differ.
+	ErrMergeTypeMismatch = ex.Class("config merge source and target types differ")
+	// ErrInvalidMergeStrategy is returned if a merge strategy is not known.
+	ErrInvalidMergeStrategy = ex.Class("config merge strategy invalid")
+)
+
+// MergeOptions are options for merging configs.
+type MergeOptions struct {
+	// Slices is the slice strategy, it defaults to `MergeSlicesReplace`.
+	Slices string
+	// Maps is the map strategy, it defaults to `MergeMapsDeep`.
+	Maps string
+}
+
+// SlicesOrDefault returns the slice strategy or a default.
+func (mo MergeOptions) SlicesOrDefault() string {
+	if mo.Slices != "" {
+		return mo.Slices
+	}
+	return MergeSlicesReplace
+}
+
+// MapsOrDefault returns the map strategy or a default.
+func (mo MergeOptions) MapsOrDefault() string {
+	if mo.Maps != "" {
+		return mo.Maps
+	}
+	return MergeMapsDeep
+}
+
+// MergeOption mutates merge options.
+type MergeOption func(*MergeOptions)
+
+// OptMergeSlices sets the slice merge strategy.
+func OptMergeSlices(strategy string) MergeOption {
+	return func(mo *MergeOptions) { mo.Slices = strategy }
+}
+
+// OptMergeMaps sets the map merge strategy.
+func OptMergeMaps(strategy string) MergeOption {
+	return func(mo *MergeOptions) { mo.Maps = strategy }
+}
+
+// Merge merges an overlay config onto a base config, which must be a pointer.
+//
+// By default non-zero overlay scalars override base values, non-empty overlay slices
+// replace base slices, and overlay maps are deep merged into base maps; the slice and map
+// strategies can be changed with options. Non-nil overlay pointers always override,
+// so pointer fields can be used to override with zero values (e.g. `*bool`).
+func Merge(base, overlay interface{}, options ...MergeOption) error {
+	var mo MergeOptions
+	for _, option := range options {
+		option(&mo)
+	}
+	switch mo.SlicesOrDefault() {
+	case MergeSlicesReplace, MergeSlicesAppend:
+	default:
+		return ex.New(ErrInvalidMergeStrategy, ex.OptMessagef("slices: %s", mo.Slices))
+	}
+	switch mo.MapsOrDefault() {
+	case MergeMapsDeep, MergeMapsReplace:
+	default:
+		return ex.New(ErrInvalidMergeStrategy, ex.OptMessagef("maps: %s", mo.Maps))
+	}
+
+	baseValue := reflect.ValueOf(base)
+	if baseValue.Kind() != reflect.Ptr || baseValue.IsNil() {
+		return ex.New(ErrInvalidMergeTarget, ex.OptMessagef("type: %T", base))
+	}
+	overlayValue := reflect.ValueOf(overlay)
+	if overlayValue.Kind() == reflect.Ptr {
+		if overlayValue.IsNil() {
+			return nil
+		}
+		overlayValue = overlayValue.Elem()
+	}
+	if baseValue.Elem().Type() != overlayValue.Type() {
+		return ex.New(ErrMergeTypeMismatch, ex.OptMessagef("base: %T, overlay: %T", base, overlay))
+	}
+	mo.merge(baseValue.Elem(), overlayValue)
+	return nil
+}
+
+func (mo MergeOptions) merge(base, overlay reflect.Value) {
+	switch base.Kind() {
+	case reflect.Struct:
+		if !hasExportedFields(base.Type()) { // e.g. time.Time, treat these as scalars.
+			if !overlay.IsZero() {
+				base.Set(overlay)
+			}
+			return
+		}
+		for index := 0; index < base.NumField(); index++ {
+			if field := base.Field(index); field.CanSet() {
+				mo.merge(field, overlay.Field(index))
+			}
+		}
+	case reflect.Ptr:
+		if overlay.IsNil() {
+			return
+		}
+		// merge into a copy so values the base pointed to are not modified.
+		value := reflect.New(base.Type().Elem())
+		if !base.IsNil() {
+			value.Elem().Set(base.Elem())
+		}
+		if isComposite(value.Elem()) {
+			mo.merge(value.Elem(), overlay.Elem())
+		} else {
+			value.Elem().Set(overlay.Elem())
+		}
+		base.Set(value)
+	case reflect.Slice:
+		if overlay.Len() == 0 {
+			return
+		}
+		if mo.SlicesOrDefault() == MergeSlicesAppend {
+			base.Set(reflect.AppendSlice(reflect.MakeSlice(base.Type(), 0, base.Len()+overlay.Len()), base))
+			base.Set(reflect.AppendSlice(base, overlay))
+			return
+		}
+		base.Set(reflect.AppendSlice(reflect.MakeSlice(base.Type(), 0, overlay.Len()), overlay))
+	case reflect.Map:
+		if overlay.IsNil() {
+			return
+		}
+		if base.IsNil() || mo.MapsOrDefault() == MergeMapsReplace {
+			base.Set(reflect.MakeMapWithSize(base.Type(), overlay.Len()))
+		}
+		for _, key := range overlay.MapKeys() {
+			// map values are not addressable, so merge into a copy of the existing value.
+			value := reflect.New(base.Type().Elem()).Elem()
+			if existing := base.MapIndex(key); existing.IsValid() {
+				value.Set(existing)
+			}
+			mo.merge(value, overlay.MapIndex(key))
+			base.SetMapIndex(key, value)
+		}
+	case reflect.Interface:
+		if !overlay.IsNil() {
+			base.Set(overlay)
+		}
+	default:
+		if !overlay.IsZero() {
+			base.Set(overlay)
+		}
+	}
+}
+
+func hasExportedFields(t reflect.Type) bool {
+	for index := 0; index < t.NumField(); index++ {
+		if t.Field(index).PkgPath == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// isComposite returns if a value is merged field by field or element by element, rather than overridden.
+func isComposite(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Struct:
+		return hasExportedFields(value.Type())
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,127 @@
+package configutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/ref"
+)
+
+type mergeConfig struct {
+	Name       string
+	Enabled    *bool
+	Timeout    time.Duration
+	Started    time.Time
+	Tags       []string
+	Labels     map[string]string
+	Nested     mergeNestedConfig
+	Pointer    *mergeNestedConfig
+	Children   map[string]mergeNestedConfig
+	unexported string
+}
+
+type mergeNestedConfig struct {
+	Hosts []string
+	Port  int
+}
+
+func mergeFixtures() (base, overlay mergeConfig) {
+	base = mergeConfig{
+		Name:       "base",
+		Enabled:    ref.Bool(true),
+		Timeout:    time.Second,
+		Tags:       []string{"a", "b"},
+		Labels:     map[string]string{"foo": "bar", "moo": "loo"},
+		Nested:     mergeNestedConfig{Hosts: []string{"base-0"}, Port: 80},
+		Children:   map[string]mergeNestedConfig{"one": {Hosts: []string{"one-0"}, Port: 1}},
+		unexported: "base",
+	}
+	overlay = mergeConfig{
+		Enabled:    ref.Bool(false),
+		Started:    time.Date(2019, 06, 01, 0, 0, 0, 0, time.UTC),
+		Tags:       []string{"c"},
+		Labels:     map[string]string{"foo": "buzz"},
+		Nested:     mergeNestedConfig{Hosts: []string{"overlay-0"}},
+		Pointer:    &mergeNestedConfig{Port: 443},
+		Children:   map[string]mergeNestedConfig{"one": {Hosts: []string{"one-1"}}, "two": {Port: 2}},
+		unexported: "overlay",
+	}
+	return
+}
+
+func TestMergeDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	base, overlay := mergeFixtures()
+	assert.Nil(Merge(&base, overlay))
+
+	assert.Equal("base", base.Name, "zero overlay scalars should not override")
+	assert.False(*base.Enabled, "non-nil overlay pointers should override")
+	assert.Equal(time.Second, base.Timeout)
+	assert.Equal(overlay.Started, base.Started)
+	assert.Equal([]string{"c"}, base.Tags)
+	assert.Equal(map[string]string{"foo": "buzz", "moo": "loo"}, base.Labels)
+	assert.Equal([]string{"overlay-0"}, base.Nested.Hosts)
+	assert.Equal(80, base.Nested.Port)
+	assert.Equal(443, base.Pointer.Port)
+	assert.False(base.Pointer == overlay.Pointer, "overlay pointers should be copied")
+	assert.Equal([]string{"one-1"}, base.Children["one"].Hosts)
+	assert.Equal(1, base.Children["one"].Port)
+	assert.Equal(2, base.Children["two"].Port)
+	assert.Equal("base", base.unexported)
+}
+
+func TestMergeSlicesAppend(t *testing.T) {
+	assert := assert.New(t)
+
+	base, overlay := mergeFixtures()
+	baseTags := base.Tags
+	assert.Nil(Merge(&base, &overlay, OptMergeSlices(MergeSlicesAppend)))
+
+	assert.Equal([]string{"a", "b", "c"}, base.Tags)
+	assert.Equal([]string{"a", "b"}, baseTags, "the original base slice should not be modified")
+	assert.Equal([]string{"base-0", "overlay-0"}, base.Nested.Hosts)
+	assert.Equal([]string{"one-0", "one-1"}, base.Children["one"].Hosts)
+}
+
+func TestMergeSlicesReplace(t *testing.T) {
+	assert := assert.New(t)
+
+	base, overlay := mergeFixtures()
+	assert.Nil(Merge(&base, &overlay, OptMergeSlices(MergeSlicesReplace)))
+	assert.Equal([]string{"c"}, base.Tags)
+	assert.Equal([]string{"overlay-0"}, base.Nested.Hosts)
+	assert.Equal([]string{"one-1"}, base.Children["one"].Hosts)
+
+	overlay.Tags[0] = "changed"
+	assert.Equal([]string{"c"}, base.Tags, "overlay slices should be copied")
+
+	base, overlay = mergeFixtures()
+	overlay.Tags = nil
+	assert.Nil(Merge(&base, &overlay))
+	assert.Equal([]string{"a", "b"}, base.Tags, "empty overlay slices should not replace")
+}
+
+func TestMergeMapsReplace(t *testing.T) {
+	assert := assert.New(t)
+
+	base, overlay := mergeFixtures()
+	assert.Nil(Merge(&base, &overlay, OptMergeMaps(MergeMapsReplace)))
+	assert.Equal(map[string]string{"foo": "buzz"}, base.Labels)
+	assert.Equal(0, base.Children["one"].Port)
+	assert.Equal(2, base.Children["two"].Port)
+}
+
+func TestMergeErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	base, overlay := mergeFixtures()
+	assert.True(ex.Is(Merge(base, overlay), ErrInvalidMergeTarget))
+	assert.True(ex.Is(Merge((*mergeConfig)(nil), overlay), ErrInvalidMergeTarget))
+	assert.True(ex.Is(Merge(&base, mergeNestedConfig{}), ErrMergeTypeMismatch))
+	assert.True(ex.Is(Merge(&base, overlay, OptMergeSlices("bogus")), ErrInvalidMergeStrategy))
+	assert.True(ex.Is(Merge(&base, overlay, OptMergeMaps("bogus")), ErrInvalidMergeStrategy))
+	assert.Nil(Merge(&base, (*mergeConfig)(nil)))
+}
@@ -55,6 +55,9 @@ func Read(ref Any, options ...Option) (path string, err error) {
 		}
 	}
 
+	// configs that are not structs have no tagged fields to expand, so the error is ignored.
+	_ = ExpandEnv(ref, configOptions.Env)
+
 	if typed, ok := ref.(BareResolver); ok {
 		MaybeDebugf(configOptions.Log, "calling legacy config resolver")
 		MaybeWarningf(configOptions.Log, "deprecated; the legacy config resolver should be replaced with `.Resolve(context.Context) error`")
@@ -90,6 +93,20 @@ func createConfigOptions(options ...Option) (configOptions ConfigOptions, err er
 
 // deserialize deserializes a config.
 func deserialize(ext string, r io.Reader, ref Any) error {
+	decoder, err := newDecoder(ext, r)
+	if err != nil {
+		return err
+	}
+	return ex.New(decoder.Decode(ref))
+}
+
+// decoder is a json or yaml decoder.
+type decoder interface {
+	Decode(interface{}) error
+}
+
+// newDecoder returns a decoder for a given extension.
+func newDecoder(ext string, r io.Reader) (decoder, error) {
 	// make sure the extension starts with a "."
 	if !strings.HasPrefix(ext, ".") {
 		ext = "." + ext
@@ -98,10 +115,10 @@ func deserialize(ext string, r io.Reader, ref Any) error {
 	// based off the extension, use the appropriate deserializer
 	switch strings.ToLower(ext) {
 	case ExtensionJSON:
-		return ex.New(json.NewDecoder(r).Decode(ref))
+		return json.NewDecoder(r), nil
 	case ExtensionYAML, ExtensionYML:
-		return ex.New(yaml.NewDecoder(r).Decode(ref))
+		return yaml.NewDecoder(r), nil
 	default: // return an error if we're passed a weird extension
-		return ex.New(ErrInvalidConfigExtension, ex.OptMessagef("extension: %s", ext))
+		return nil, ex.New(ErrInvalidConfigExtension, ex.OptMessagef("extension: %s", ext))
 	}
 }
@@ -0,0 +1,119 @@
+package configutil
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// ReadWithContext reads a config from a given path, which can be a local file
+// or an `http://` or `https://` url, and then calls the config resolvers.
+//
+// The read respects the context's cancellation and deadline; if the context is
+// done before or during the read, the context error is returned wrapped as an exception.
+func ReadWithContext(ctx context.Context, ref Any, path string) error {
+	if path == "" {
+		return ex.New(ErrConfigPathUnset)
+	}
+	if err := ctx.Err(); err != nil {
+		return ex.New(err)
+	}
+
+	var err error
+	if isRemotePath(path) {
+		err = readRemote(ctx, ref, path)
+	} else {
+		err = readFile(ctx, ref, path)
+	}
+	if err != nil {
+		return err
+	}
+	if err = ctx.Err(); err != nil {
+		return ex.New(err)
+	}
+
+	if typed, ok := ref.(BareResolver); ok {
+		if err = typed.Resolve(); err != nil {
+			return err
+		}
+	}
+	if typed, ok := ref.(Resolver); ok {
+		if err = typed.Resolve(WithConfigFilePaths(ctx, []string{path})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isRemotePath(path string) bool {
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+func readFile(ctx context.Context, ref Any, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return ex.New(err)
+	}
+	defer f.Close()
+	if err = deserialize(filepath.Ext(path), &contextReader{ctx: ctx, Reader: f}, ref); err != nil {
+		if ctx.Err() != nil {
+			return ex.New(ctx.Err())
+		}
+		return err
+	}
+	return nil
+}
+
+// contextReader is a reader that fails with the context error once the context is done.
+type contextReader struct {
+	io.Reader
+	ctx context.Context
+}
+
+// Read implements io.Reader.
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.Reader.Read(p)
+}
+
+func readRemote(ctx context.Context, ref Any, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ex.New(err)
+	}
+	req, err := http.NewRequest(http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return ex.New(err)
+	}
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		if ctx.Err() != nil {
+			return ex.New(ctx.Err())
+		}
+		return ex.New(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return ex.New(os.ErrNotExist, ex.OptMessagef("url: %s", rawURL))
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return ex.New(ErrConfigRemoteStatus, ex.OptMessagef("url: %s, status: %d", rawURL, res.StatusCode))
+	}
+	if err = deserialize(path.Ext(parsed.Path), res.Body, ref); err != nil {
+		if ctx.Err() != nil {
+			return ex.New(ctx.Err())
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package configutil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestReadWithContextFile(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg config
+	assert.Nil(ReadWithContext(context.Background(), &cfg, "./testdata/config.yaml"))
+	assert.Equal("test_yaml", cfg.Environment)
+	assert.Equal("foo", cfg.Other)
+}
+
+func TestReadWithContextFileCancelled(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var cfg config
+	err := ReadWithContext(ctx, &cfg, "./testdata/config.yaml")
+	assert.True(ex.Is(err, context.Canceled))
+	assert.Empty(cfg.Environment)
+}
+
+func TestContextReader(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &contextReader{ctx: ctx, Reader: strings.NewReader("env: test\n")}
+
+	buffer := make([]byte, 4)
+	n, err := r.Read(buffer)
+	assert.Nil(err)
+	assert.Equal("env:", string(buffer[:n]))
+
+	cancel()
+	n, err = r.Read(buffer)
+	assert.Zero(n)
+	assert.Equal(context.Canceled, err)
+}
+
+func TestReadWithContextNotExist(t *testing.T) {
+	assert := assert.New(t)
+
+	var cfg config
+	err := ReadWithContext(context.Background(), &cfg, "./testdata/not_a_config.yaml")
+	assert.True(IsNotExist(err))
+	assert.True(IsIgnored(err))
+	assert.True(IsConfigPathUnset(ReadWithContext(context.Background(), &cfg, "")))
+}
+
+func TestReadWithContextRemote(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/config.yml" {
+			http.NotFound(rw, r)
+			return
+		}
+		fmt.Fprint(rw, "env: remote\nother: bar\n")
+	}))
+	defer server.Close()
+
+	var cfg config
+	assert.Nil(ReadWithContext(context.Background(), &cfg, server.URL+"/config.yml"))
+	assert.Equal("remote", cfg.Environment)
+	assert.Equal("bar", cfg.Other)
+
+	assert.True(IsNotExist(ReadWithContext(context.Background(), &cfg, server.URL+"/missing.yml")))
+}
+
+func TestReadWithContextRemoteCancelled(t *testing.T) {
+	assert := assert.New(t)
+
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	var cfg config
+	err := ReadWithContext(ctx, &cfg, server.URL+"/config.yml")
+	assert.True(ex.Is(err, context.Canceled))
+}
+
+func TestReadWithContextRemoteSlowBody(t *testing.T) {
+	assert := assert.New(t)
+
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(rw, "env: remote\n")
+		rw.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	var cfg config
+	err := ReadWithContext(ctx, &cfg, server.URL+"/config.yml")
+	assert.True(ex.Is(err, context.DeadlineExceeded))
+}
+
+func TestReadWithContextRemoteStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var cfg config
+	err := ReadWithContext(context.Background(), &cfg, server.URL+"/config.yml")
+	assert.True(ex.Is(err, ErrConfigRemoteStatus))
+}
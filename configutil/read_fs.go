@@ -0,0 +1,44 @@
+//go:build go1.16
+// +build go1.16
+
+package configutil
+
+import (
+	"context"
+	"io/fs"
+	"path"
+
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+)
+
+// ReadFromFS reads a config from a given path in a filesystem, e.g. an `embed.FS` of default configs,
+// calls the config resolvers, and then overlays values from the environment with `env.Env().ReadInto`.
+//
+// Paths are slash separated as they are for `fs.FS`; if the path does not exist
+// in the filesystem the error will satisfy `IsNotExist`.
+func ReadFromFS(ref Any, fsys fs.FS, filePath string) error {
+	if filePath == "" {
+		return ex.New(ErrConfigPathUnset)
+	}
+	f, err := fsys.Open(filePath)
+	if err != nil {
+		return ex.New(err)
+	}
+	defer f.Close()
+	if err = deserialize(path.Ext(filePath), f, ref); err != nil {
+		return err
+	}
+
+	if typed, ok := ref.(BareResolver); ok {
+		if err = typed.Resolve(); err != nil {
+			return err
+		}
+	}
+	if typed, ok := ref.(Resolver); ok {
+		if err = typed.Resolve(WithConfigFilePaths(context.Background(), []string{filePath})); err != nil {
+			return err
+		}
+	}
+	return env.Env().ReadInto(ref)
+}
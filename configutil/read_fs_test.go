@@ -0,0 +1,65 @@
+//go:build go1.16
+// +build go1.16
+
+package configutil
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+)
+
+type fsResolvedConfig struct {
+	Environment string   `yaml:"env"`
+	Paths       []string `yaml:"-"`
+}
+
+// Resolve implements configutil.Resolver.
+func (r *fsResolvedConfig) Resolve(ctx context.Context) error {
+	r.Paths = GetConfigFilePaths(ctx)
+	return nil
+}
+
+func TestReadFromFS(t *testing.T) {
+	assert := assert.New(t)
+
+	defer env.Restore()
+	env.SetEnv(env.New())
+
+	fsys := fstest.MapFS{
+		"config/config.json": &fstest.MapFile{Data: []byte(`{"env":"test_json","other":"foo"}`)},
+		"config/config.yaml": &fstest.MapFile{Data: []byte("env: test_yaml\nother: bar\n")},
+		"config/config.txt":  &fstest.MapFile{Data: []byte("env=test")},
+	}
+
+	var jsonConfig config
+	assert.Nil(ReadFromFS(&jsonConfig, fsys, "config/config.json"))
+	assert.Equal("test_json", jsonConfig.Environment)
+	assert.Equal("foo", jsonConfig.Other)
+
+	var yamlConfig config
+	assert.Nil(ReadFromFS(&yamlConfig, fsys, "config/config.yaml"))
+	assert.Equal("test_yaml", yamlConfig.Environment)
+	assert.Equal("bar", yamlConfig.Other)
+
+	// the environment is overlaid onto the filesystem config.
+	env.Env().Set("OTHER", "from_env")
+	var overlaid config
+	assert.Nil(ReadFromFS(&overlaid, fsys, "config/config.yaml"))
+	assert.Equal("test_yaml", overlaid.Environment)
+	assert.Equal("from_env", overlaid.Other)
+
+	var resolved fsResolvedConfig
+	assert.Nil(ReadFromFS(&resolved, fsys, "config/config.yaml"))
+	assert.Equal("test_yaml", resolved.Environment)
+	assert.Equal([]string{"config/config.yaml"}, resolved.Paths)
+
+	var missing config
+	assert.True(IsNotExist(ReadFromFS(&missing, fsys, "config/missing.yaml")))
+	assert.True(ex.Is(ReadFromFS(&missing, fsys, "config/config.txt"), ErrInvalidConfigExtension))
+	assert.True(IsConfigPathUnset(ReadFromFS(&missing, fsys, "")))
+}
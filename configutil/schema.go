@@ -0,0 +1,284 @@
+package configutil
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/reflectutil"
+)
+
+const (
+	// ErrInvalidSchemaTarget is returned if the schema target is not a struct or a pointer to a struct.
+	ErrInvalidSchemaTarget = ex.Class("config schema target must be a struct or a pointer to a struct")
+	// ErrInvalidSchemaTag is returned if a `default` or `validate` tag value cannot be read for its field type.
+	ErrInvalidSchemaTag = ex.Class("config schema tag invalid")
+)
+
+// Schema field tags.
+const (
+	// FieldTagDefault is the struct tag for a field's default value, e.g. `default:"8080"`.
+	FieldTagDefault = "default"
+	// FieldTagValidate is the struct tag for a field's validation rules, e.g. `validate:"required,min=1"`.
+	FieldTagValidate = "validate"
+)
+
+// SchemaDraft is the json schema draft the schemas returned by `Schema` conform to.
+const SchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// Schema returns a json schema for a config struct, so the config can be documented and validated externally.
+//
+// Properties are named by the field `json` tag, falling back to the `yaml` tag and then the field name,
+// and fields tagged `-` are skipped. The schema includes:
+//   - the `default` tag as the property default, e.g. `default:"8080"`.
+//   - the `env` tag as an `x-env` annotation with the env var the field is read from.
+//   - the `validate` tag rules `required`, `min=N`, `max=N` and `oneof=a b c`, e.g. `validate:"required,oneof=debug info"`.
+//
+// Durations are strings, e.g. "5s", and nested structs are objects with their own properties.
+func Schema(ref Any) (string, error) {
+	refType := reflect.TypeOf(ref)
+	if refType != nil && refType.Kind() == reflect.Ptr {
+		refType = refType.Elem()
+	}
+	if refType == nil || refType.Kind() != reflect.Struct {
+		return "", ex.New(ErrInvalidSchemaTarget, ex.OptMessagef("type: %T", ref))
+	}
+	schema, err := schemaForType(refType, make(map[reflect.Type]bool))
+	if err != nil {
+		return "", err
+	}
+	schema.Schema = SchemaDraft
+	schema.Title = refType.Name()
+	contents, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", ex.New(err)
+	}
+	return string(contents), nil
+}
+
+// schemaProperty is a (subset of a) json schema.
+type schemaProperty struct {
+	Schema               string                     `json:"$schema,omitempty"`
+	Title                string                     `json:"title,omitempty"`
+	Type                 string                     `json:"type,omitempty"`
+	Format               string                     `json:"format,omitempty"`
+	Env                  string                     `json:"x-env,omitempty"`
+	Default              interface{}                `json:"default,omitempty"`
+	Enum                 []interface{}              `json:"enum,omitempty"`
+	Minimum              *float64                   `json:"minimum,omitempty"`
+	Maximum              *float64                   `json:"maximum,omitempty"`
+	MinLength            *int                       `json:"minLength,omitempty"`
+	MaxLength            *int                       `json:"maxLength,omitempty"`
+	MinItems             *int                       `json:"minItems,omitempty"`
+	MaxItems             *int                       `json:"maxItems,omitempty"`
+	Items                *schemaProperty            `json:"items,omitempty"`
+	Properties           map[string]*schemaProperty `json:"properties,omitempty"`
+	AdditionalProperties *schemaProperty            `json:"additionalProperties,omitempty"`
+	Required             []string                   `json:"required,omitempty"`
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// schemaForType returns the schema for a type; visiting tracks the struct types being expanded so recursive types terminate.
+func schemaForType(t reflect.Type, visiting map[reflect.Type]bool) (*schemaProperty, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == durationType:
+		return &schemaProperty{Type: "string"}, nil
+	case t == timeType:
+		return &schemaProperty{Type: "string", Format: "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &schemaProperty{Type: "string"}, nil
+	case reflect.Bool:
+		return &schemaProperty{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &schemaProperty{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &schemaProperty{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := schemaForType(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return &schemaProperty{Type: "array", Items: items}, nil
+	case reflect.Map:
+		values, err := schemaForType(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return &schemaProperty{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		if visiting[t] {
+			return &schemaProperty{Type: "object"}, nil
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		schema := &schemaProperty{Type: "object", Properties: make(map[string]*schemaProperty)}
+		if err := schemaFields(schema, t, visiting); err != nil {
+			return nil, err
+		}
+		return schema, nil
+	default:
+		// interfaces and other kinds can hold any value.
+		return &schemaProperty{}, nil
+	}
+}
+
+// schemaFields adds the properties for the fields of a struct type to a schema, including the fields of embedded structs.
+func schemaFields(schema *schemaProperty, t reflect.Type, visiting map[reflect.Type]bool) error {
+	for index := 0; index < t.NumField(); index++ {
+		field := t.Field(index)
+		name, ok := schemaFieldName(field)
+		if !ok {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := schemaFields(schema, embedded, visiting); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property, err := schemaForType(field.Type, visiting)
+		if err != nil {
+			return err
+		}
+		property.Env = strings.Split(field.Tag.Get(reflectutil.FieldTagEnv), ",")[0]
+		if value, ok := field.Tag.Lookup(FieldTagDefault); ok {
+			if property.Default, err = schemaValue(property, value); err != nil {
+				return ex.New(ErrInvalidSchemaTag, ex.OptMessagef("field: %s, tag: %s", field.Name, FieldTagDefault), ex.OptInner(err))
+			}
+		}
+		required, err := schemaValidate(property, field.Tag.Get(FieldTagValidate))
+		if err != nil {
+			return ex.New(ErrInvalidSchemaTag, ex.OptMessagef("field: %s, tag: %s", field.Name, FieldTagValidate), ex.OptInner(err))
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+	return nil
+}
+
+// schemaFieldName returns the property name from the `json` or `yaml` tag of a field, and false if the field is skipped.
+func schemaFieldName(field reflect.StructField) (string, bool) {
+	for _, tag := range []string{"json", "yaml"} {
+		if value, ok := field.Tag.Lookup(tag); ok {
+			name := strings.Split(value, ",")[0]
+			if name == "-" {
+				return "", false
+			}
+			if name != "" {
+				return name, true
+			}
+		}
+	}
+	return "", true
+}
+
+// schemaValidate applies the rules of a `validate` tag to a property, and returns if the property is required.
+func schemaValidate(property *schemaProperty, tag string) (required bool, err error) {
+	for _, rule := range strings.Split(tag, ",") {
+		rule = strings.TrimSpace(rule)
+		name, value := rule, ""
+		if index := strings.Index(rule, "="); index >= 0 {
+			name, value = rule[:index], rule[index+1:]
+		}
+		switch name {
+		case "required":
+			required = true
+		case "min", "max":
+			var limit float64
+			if limit, err = strconv.ParseFloat(value, 64); err != nil {
+				return
+			}
+			schemaLimit(property, name, limit)
+		case "oneof":
+			for _, option := range strings.Fields(value) {
+				var typed interface{}
+				if typed, err = schemaValue(property, option); err != nil {
+					return
+				}
+				property.Enum = append(property.Enum, typed)
+			}
+		}
+	}
+	return
+}
+
+// schemaLimit sets the minimum or maximum of a property; it limits the value of numbers, the length of strings and the number of items in arrays.
+func schemaLimit(property *schemaProperty, name string, limit float64) {
+	count := int(limit)
+	switch property.Type {
+	case "string":
+		if name == "min" {
+			property.MinLength = &count
+		} else {
+			property.MaxLength = &count
+		}
+	case "array":
+		if name == "min" {
+			property.MinItems = &count
+		} else {
+			property.MaxItems = &count
+		}
+	default:
+		if name == "min" {
+			property.Minimum = &limit
+		} else {
+			property.Maximum = &limit
+		}
+	}
+}
+
+// schemaValue parses a tag value as a value of the type of a property; array values are comma separated.
+func schemaValue(property *schemaProperty, value string) (interface{}, error) {
+	switch property.Type {
+	case "boolean":
+		return strconv.ParseBool(value)
+	case "integer":
+		return strconv.ParseInt(value, 10, 64)
+	case "number":
+		return strconv.ParseFloat(value, 64)
+	case "array":
+		values := []interface{}{}
+		if value == "" || property.Items == nil {
+			return values, nil
+		}
+		for _, item := range strings.Split(value, ",") {
+			typed, err := schemaValue(property.Items, strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, typed)
+		}
+		return values, nil
+	default:
+		return value, nil
+	}
+}
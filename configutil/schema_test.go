@@ -0,0 +1,131 @@
+package configutil
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+type schemaTestDB struct {
+	Host    string        `json:"host" yaml:"host" env:"DB_HOST" validate:"required"`
+	Port    int           `json:"port" yaml:"port" env:"DB_PORT" default:"5432" validate:"min=1,max=65535"`
+	Timeout time.Duration `yaml:"timeout" default:"5s"`
+}
+
+type schemaTestBase struct {
+	Name string `json:"name" validate:"required,min=1"`
+}
+
+type schemaTestConfig struct {
+	schemaTestBase `yaml:",inline"`
+
+	LogLevel string            `json:"logLevel" yaml:"logLevel" default:"info" validate:"oneof=debug info error"`
+	Debug    bool              `json:"debug" default:"false"`
+	Ratio    float64           `json:"ratio,omitempty" default:"0.5"`
+	Hosts    []string          `json:"hosts" default:"a, b"`
+	Ports    []int             `json:"ports" default:"80,443" validate:"max=4"`
+	Labels   map[string]string `json:"labels"`
+	DB       schemaTestDB      `json:"db" validate:"required"`
+	Replica  *schemaTestDB     `json:"replica,omitempty"`
+	Started  time.Time         `json:"started"`
+	Ignored  string            `json:"-"`
+	NoTags   string
+
+	unexported string
+}
+
+func TestSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	output, err := Schema(&schemaTestConfig{})
+	assert.Nil(err)
+
+	var schema map[string]interface{}
+	assert.Nil(json.Unmarshal([]byte(output), &schema))
+	assert.Equal(SchemaDraft, schema["$schema"])
+	assert.Equal("schemaTestConfig", schema["title"])
+	assert.Equal("object", schema["type"])
+	assert.Equal([]interface{}{"name", "db"}, schema["required"])
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.Len(properties, 11)
+	assert.NotNil(properties["name"], "embedded struct fields should be inlined")
+	assert.Nil(properties["Ignored"])
+	assert.Nil(properties["unexported"])
+	assert.NotNil(properties["NoTags"])
+
+	assert.Equal(map[string]interface{}{"type": "string", "minLength": 1.0}, properties["name"])
+	assert.Equal(map[string]interface{}{
+		"type":    "string",
+		"default": "info",
+		"enum":    []interface{}{"debug", "info", "error"},
+	}, properties["logLevel"])
+	assert.Equal(map[string]interface{}{"type": "boolean", "default": false}, properties["debug"])
+	assert.Equal(map[string]interface{}{"type": "number", "default": 0.5}, properties["ratio"])
+	assert.Equal(map[string]interface{}{
+		"type":    "array",
+		"items":   map[string]interface{}{"type": "string"},
+		"default": []interface{}{"a", "b"},
+	}, properties["hosts"])
+	assert.Equal(map[string]interface{}{
+		"type":     "array",
+		"items":    map[string]interface{}{"type": "integer"},
+		"default":  []interface{}{80.0, 443.0},
+		"maxItems": 4.0,
+	}, properties["ports"])
+	assert.Equal(map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": map[string]interface{}{"type": "string"},
+	}, properties["labels"])
+	assert.Equal(map[string]interface{}{"type": "string", "format": "date-time"}, properties["started"])
+
+	db := properties["db"].(map[string]interface{})
+	assert.Equal("object", db["type"])
+	assert.Equal([]interface{}{"host"}, db["required"])
+	dbProperties := db["properties"].(map[string]interface{})
+	assert.Equal(map[string]interface{}{"type": "string", "x-env": "DB_HOST"}, dbProperties["host"])
+	assert.Equal(map[string]interface{}{
+		"type":    "integer",
+		"x-env":   "DB_PORT",
+		"default": 5432.0,
+		"minimum": 1.0,
+		"maximum": 65535.0,
+	}, dbProperties["port"])
+	assert.Equal(map[string]interface{}{"type": "string", "default": "5s"}, dbProperties["timeout"])
+	assert.Equal(db, properties["replica"])
+}
+
+type schemaTestNode struct {
+	Name     string            `json:"name"`
+	Children []*schemaTestNode `json:"children"`
+}
+
+func TestSchemaRecursive(t *testing.T) {
+	assert := assert.New(t)
+
+	output, err := Schema(schemaTestNode{})
+	assert.Nil(err)
+	assert.Contains(output, `"children"`)
+}
+
+func TestSchemaInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := Schema("not a struct")
+	assert.True(ex.Is(err, ErrInvalidSchemaTarget))
+	_, err = Schema(nil)
+	assert.True(ex.Is(err, ErrInvalidSchemaTarget))
+
+	_, err = Schema(struct {
+		Port int `default:"http"`
+	}{})
+	assert.True(ex.Is(err, ErrInvalidSchemaTag))
+
+	_, err = Schema(struct {
+		Port int `validate:"min=one"`
+	}{})
+	assert.True(ex.Is(err, ErrInvalidSchemaTag))
+}
@@ -4,3 +4,6 @@ import "context"
 
 // Action is an function that can be run as a task
 type Action func(ctx context.Context) error
+
+// PanicHandler is a function that is called with the name of a job and the error recovered when the job panics.
+type PanicHandler func(jobName string, err error)
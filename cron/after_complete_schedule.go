@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	_ Schedule           = (*AfterCompleteSchedule)(nil)
+	_ CompletionSchedule = (*AfterCompleteSchedule)(nil)
+	_ fmt.Stringer       = (*AfterCompleteSchedule)(nil)
+)
+
+// EveryAfterComplete returns a schedule that fires a given interval
+// after the previous invocation completes.
+func EveryAfterComplete(interval time.Duration) AfterCompleteSchedule {
+	return AfterCompleteSchedule{Every: interval}
+}
+
+// EveryAfterCompleteDelayed returns a schedule that fires a given interval
+// after the previous invocation completes with a start delay.
+func EveryAfterCompleteDelayed(interval, delay time.Duration) AfterCompleteSchedule {
+	return AfterCompleteSchedule{Every: interval, StartDelay: delay}
+}
+
+// AfterCompleteSchedule is a schedule that fires a given interval after the previous
+// invocation completes, rather than after it was scheduled to start.
+//
+// Unlike `IntervalSchedule`, long running invocations push back the next runtime,
+// guaranteeing at least `Every` between the end of one invocation and the start of the next.
+type AfterCompleteSchedule struct {
+	Every      time.Duration
+	StartDelay time.Duration
+}
+
+// String returns a string representation of the schedule.
+func (acs AfterCompleteSchedule) String() string {
+	if acs.StartDelay > 0 {
+		return fmt.Sprintf("every %v after complete with an initial delay of %v", acs.Every, acs.StartDelay)
+	}
+	return fmt.Sprintf("every %v after complete", acs.Every)
+}
+
+// Next implements Schedule.
+//
+// It is used to compute the first runtime, and as a fallback if the
+// previous invocation could not be started.
+func (acs AfterCompleteSchedule) Next(after time.Time) time.Time {
+	if after.IsZero() {
+		if acs.StartDelay > 0 {
+			return Now().Add(acs.StartDelay).Add(acs.Every)
+		}
+		return Now().Add(acs.Every)
+	}
+	return after.Add(acs.Every)
+}
+
+// NextAfterComplete implements CompletionSchedule.
+func (acs AfterCompleteSchedule) NextAfterComplete(complete time.Time) time.Time {
+	if complete.IsZero() {
+		return Now().Add(acs.Every)
+	}
+	return complete.Add(acs.Every)
+}
@@ -0,0 +1,77 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestAfterCompleteSchedule(t *testing.T) {
+	assert := assert.New(t)
+
+	schedule := EveryAfterComplete(time.Hour)
+	assert.Equal(time.Hour, schedule.Every)
+	assert.Equal("every 1h0m0s after complete", schedule.String())
+
+	now := time.Now().UTC()
+	firstRun := schedule.Next(Zero)
+	assert.InDelta(float64(firstRun.Sub(now)), float64(time.Hour), float64(time.Second))
+
+	complete := now.Add(-time.Minute)
+	assert.Equal(complete.Add(time.Hour), schedule.NextAfterComplete(complete))
+
+	delay := EveryAfterCompleteDelayed(time.Hour, time.Second)
+	assert.Equal("every 1h0m0s after complete with an initial delay of 1s", delay.String())
+	firstRun = delay.Next(Zero)
+	assert.InDelta(float64(firstRun.Sub(now)), float64(time.Hour+time.Second), float64(2*time.Second))
+}
+
+func TestJobSchedulerAfterCompleteSchedule(t *testing.T) {
+	assert := assert.New(t)
+
+	const interval = 20 * time.Millisecond
+	const duration = 50 * time.Millisecond
+
+	var lock sync.Mutex
+	var starts, completes []time.Time
+	ran := make(chan struct{}, 2)
+
+	js := NewJobScheduler(NewJob(
+		OptJobName("after-complete-test"),
+		OptJobSchedule(EveryAfterComplete(interval)),
+		OptJobAction(func(_ context.Context) error {
+			lock.Lock()
+			starts = append(starts, time.Now().UTC())
+			lock.Unlock()
+			time.Sleep(duration)
+			return nil
+		}),
+		OptJobOnComplete(func(_ context.Context) {
+			lock.Lock()
+			completes = append(completes, time.Now().UTC())
+			lock.Unlock()
+			select {
+			case ran <- struct{}{}:
+			default:
+			}
+		}),
+	))
+
+	go func() { _ = js.Start() }()
+	<-js.NotifyStarted()
+	<-ran
+	<-ran
+	assert.Nil(js.Stop())
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.True(len(starts) >= 2)
+	assert.True(len(completes) >= 2)
+
+	// the second run must be computed from when the first completed, not when it started.
+	assert.True(starts[1].Sub(completes[0]) >= interval, starts[1].Sub(completes[0]).String())
+	assert.True(starts[1].Sub(starts[0]) >= duration+interval, starts[1].Sub(starts[0]).String())
+}
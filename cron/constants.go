@@ -14,10 +14,24 @@ const (
 const (
 	// DefaultDisabled is a default.
 	DefaultDisabled = false
+	// DefaultRunOnStart is a default.
+	DefaultRunOnStart = false
+	// DefaultMissedRuns is a default.
+	DefaultMissedRuns = MissedRunsSkip
 	// DefaultShouldSkipLoggerListeners is a default.
 	DefaultShouldSkipLoggerListeners = false
 	// DefaultShouldSkipLoggerOutput is a default.
 	DefaultShouldSkipLoggerOutput = false
+	// DefaultHistorySize is the default number of runs kept in the history of each job.
+	DefaultHistorySize = 10
+)
+
+// Missed run policies.
+const (
+	// MissedRunsSkip skips runtimes that were missed while the scheduler was not running.
+	MissedRunsSkip = "skip"
+	// MissedRunsCatchUp runs the job once on start if any runtimes were missed while the scheduler was not running.
+	MissedRunsCatchUp = "catchUp"
 )
 
 const (
@@ -15,6 +15,12 @@ const (
 	// ErrJobCancelled is a common error.
 	ErrJobCancelled ex.Class = "job cancelled"
 
+	// ErrJobTimeout is the cause of a job cancellation when the job exceeds its timeout.
+	ErrJobTimeout ex.Class = "job timed out"
+
+	// ErrJobShutdown is the cause of a job cancellation when the job scheduler is stopped.
+	ErrJobShutdown ex.Class = "job scheduler stopped"
+
 	// ErrJobAlreadyRunning is a common error.
 	ErrJobAlreadyRunning ex.Class = "job already running"
 )
@@ -34,9 +40,20 @@ func IsJobNotFound(err error) bool {
 	return ex.Is(err, ErrJobNotFound)
 }
 
-// IsJobCancelled returns if the error is a task not found error.
+// IsJobCancelled returns if the error is a job cancelled error, including
+// a cancellation caused by a timeout or a shutdown.
 func IsJobCancelled(err error) bool {
-	return ex.Is(err, ErrJobCancelled)
+	return ex.Is(err, ErrJobCancelled) || IsJobTimeout(err) || IsJobShutdown(err)
+}
+
+// IsJobTimeout returns if the error is a job timeout error.
+func IsJobTimeout(err error) bool {
+	return ex.Is(err, ErrJobTimeout)
+}
+
+// IsJobShutdown returns if the error is a job shutdown error.
+func IsJobShutdown(err error) bool {
+	return ex.Is(err, ErrJobShutdown)
 }
 
 // IsJobAlreadyRunning returns if the error is a task not found error.
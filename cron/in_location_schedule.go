@@ -0,0 +1,93 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// Interface assertions.
+var (
+	_ Schedule     = (*InLocationSchedule)(nil)
+	_ fmt.Stringer = (*InLocationSchedule)(nil)
+)
+
+// InLocation returns a schedule that computes the runtimes of a given schedule on the wall clock of a given location,
+// e.g. every day at 9am in America/New_York, whether it is daylight saving time or not:
+//
+//	loc, _ := time.LoadLocation("America/New_York")
+//	schedule := cron.InLocation(cron.DailyAtUTC(9, 0, 0), loc)
+//
+// The wrapped schedule is passed the previous runtime as a wall clock time in UTC, and its next runtime is read
+// back as a wall clock time in the location, so it works with schedules that compute runtimes in UTC. Schedules
+// that return absolute times, e.g. `Immediately` or `Every`, should not be wrapped.
+//
+// Across daylight saving time transitions, runtimes that fall in the skipped hour fire once, shifted forward
+// by the transition, e.g. a 2:30am job fires at 3:30am, and runtimes in the repeated hour fire once, not twice.
+func InLocation(schedule Schedule, loc *time.Location) *InLocationSchedule {
+	return &InLocationSchedule{Schedule: schedule, Location: loc}
+}
+
+// InLocationSchedule is a schedule whose runtimes are computed on the wall clock of a location.
+type InLocationSchedule struct {
+	Schedule Schedule
+	Location *time.Location
+}
+
+// Next implements cron.Schedule.
+//
+// If a given previous runtime is zero, the wall clock of the current time is passed to the wrapped schedule.
+func (ils *InLocationSchedule) Next(after time.Time) time.Time {
+	if ils.Schedule == nil {
+		return Zero
+	}
+	loc := ils.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	working := after
+	if working.IsZero() {
+		working = Now()
+	}
+
+	wallAfter := wallClockUTC(working.In(loc))
+	for {
+		wallNext := ils.Schedule.Next(wallAfter)
+		if wallNext.IsZero() {
+			return Zero
+		}
+		next := wallClock(wallNext, loc)
+		// the wall clock of a runtime in the repeated hour of a transition can map to before the previous runtime,
+		// or a runtime in the skipped hour can map to the same time as the previous runtime; use the runtime after it.
+		if next.After(working) || !wallNext.After(wallAfter) {
+			return next.UTC()
+		}
+		wallAfter = wallNext
+	}
+}
+
+// String returns a string representation of the schedule.
+func (ils *InLocationSchedule) String() string {
+	loc := ils.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return fmt.Sprintf("%v in %s", ils.Schedule, loc)
+}
+
+// wallClock returns the time with the same wall clock as a given time in a given location.
+//
+// If the wall clock falls in the hour skipped by a daylight saving time transition, the time is shifted forward by the transition.
+func wallClock(t time.Time, loc *time.Location) time.Time {
+	local := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	requested := wallClockUTC(t)
+	// `time.Date` can normalize a skipped wall clock backward, i.e. read it with the offset after the transition.
+	if actual := wallClockUTC(local); actual.Before(requested) {
+		return local.Add(requested.Sub(actual))
+	}
+	return local
+}
+
+// wallClockUTC returns the time in UTC with the same wall clock as a given time.
+func wallClockUTC(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
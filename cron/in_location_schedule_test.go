@@ -0,0 +1,93 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestInLocationSchedule(t *testing.T) {
+	assert := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	assert.Nil(err)
+
+	schedule := InLocation(DailyAtUTC(9, 0, 0), loc)
+	assert.Contains(schedule.String(), "in America/New_York")
+
+	// 9am EST is 14:00 UTC, and 9am EDT is 13:00 UTC; daylight saving time starts on 2021-03-14.
+	after := time.Date(2021, 03, 12, 15, 0, 0, 0, time.UTC)
+	expected := []time.Time{
+		time.Date(2021, 03, 13, 14, 0, 0, 0, time.UTC),
+		time.Date(2021, 03, 14, 13, 0, 0, 0, time.UTC),
+		time.Date(2021, 03, 15, 13, 0, 0, 0, time.UTC),
+	}
+	for _, next := range expected {
+		after = schedule.Next(after)
+		assert.Equal(next, after)
+	}
+}
+
+func TestInLocationScheduleSpringForward(t *testing.T) {
+	assert := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	assert.Nil(err)
+
+	// 2:30am does not exist on 2021-03-14, when clocks skip from 2am EST to 3am EDT.
+	schedule := InLocation(DailyAtUTC(2, 30, 0), loc)
+	after := time.Date(2021, 03, 13, 2, 30, 0, 0, loc)
+	expected := []time.Time{
+		time.Date(2021, 03, 14, 3, 30, 0, 0, loc).UTC(),
+		time.Date(2021, 03, 15, 2, 30, 0, 0, loc).UTC(),
+	}
+	for _, next := range expected {
+		after = schedule.Next(after)
+		assert.Equal(next, after)
+	}
+
+	// an hourly schedule fires once for the skipped hour.
+	hourly, err := ParseString("0 0 * * * * *")
+	assert.Nil(err)
+	schedule = InLocation(hourly, loc)
+	after = time.Date(2021, 03, 14, 1, 0, 0, 0, loc)
+	after = schedule.Next(after)
+	assert.Equal(time.Date(2021, 03, 14, 3, 0, 0, 0, loc).UTC(), after)
+	after = schedule.Next(after)
+	assert.Equal(time.Date(2021, 03, 14, 4, 0, 0, 0, loc).UTC(), after)
+}
+
+func TestInLocationScheduleFallBack(t *testing.T) {
+	assert := assert.New(t)
+
+	loc, err := time.LoadLocation("America/New_York")
+	assert.Nil(err)
+
+	// 1:30am happens twice on 2021-11-07, when clocks go back from 2am EDT to 1am EST.
+	schedule := InLocation(DailyAtUTC(1, 30, 0), loc)
+	after := time.Date(2021, 11, 06, 1, 30, 0, 0, loc)
+	first := schedule.Next(after)
+	assert.Equal(1, first.In(loc).Hour())
+	assert.Equal(30, first.In(loc).Minute())
+	assert.Equal(7, first.In(loc).Day())
+
+	second := schedule.Next(first)
+	assert.Equal(time.Date(2021, 11, 8, 1, 30, 0, 0, loc).UTC(), second, "the job should not fire again in the repeated hour")
+
+	// scheduled during the repeated hour, after the first 1:30am (EDT) has passed.
+	repeated := time.Date(2021, 11, 07, 6, 10, 0, 0, time.UTC) // 1:10am EST
+	assert.Equal(time.Date(2021, 11, 8, 1, 30, 0, 0, loc).UTC(), schedule.Next(repeated))
+}
+
+func TestInLocationScheduleZero(t *testing.T) {
+	assert := assert.New(t)
+
+	schedule := InLocation(Times(1, DailyAtUTC(9, 0, 0)), time.UTC)
+	next := schedule.Next(Zero)
+	assert.False(next.IsZero())
+	assert.True(next.After(Now()))
+	assert.True(schedule.Next(next).IsZero())
+
+	assert.True(InLocation(nil, time.UTC).Next(Zero).IsZero())
+}
@@ -71,6 +71,16 @@ func OptJobDisabled(disabled bool) JobBuilderOption {
 	return func(jb *JobBuilder) { jb.JobConfig.Disabled = ref.Bool(disabled) }
 }
 
+// OptJobRunOnStart is a job builder sets if the job should run immediately when the scheduler starts.
+func OptJobRunOnStart(runOnStart bool) JobBuilderOption {
+	return func(jb *JobBuilder) { jb.JobConfig.RunOnStart = ref.Bool(runOnStart) }
+}
+
+// OptJobMissedRuns is a job builder sets the policy for runtimes missed while the scheduler was not running.
+func OptJobMissedRuns(policy string) JobBuilderOption {
+	return func(jb *JobBuilder) { jb.JobConfig.MissedRuns = policy }
+}
+
 // OptJobOnBegin sets a lifecycle hook.
 func OptJobOnBegin(handler func(context.Context)) JobBuilderOption {
 	return func(jb *JobBuilder) { jb.JobLifecycle.OnBegin = handler }
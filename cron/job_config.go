@@ -16,6 +16,13 @@ type JobConfig struct {
 	Timeout time.Duration `json:"timeout" yaml:"timeout"`
 	// ShutdownGracePeriod represents the time a job is given to clean itself up.
 	ShutdownGracePeriod time.Duration `json:"shutdownGracePeriod" yaml:"shutdownGracePeriod"`
+	// RunOnStart determines if the job should be run once immediately when the scheduler starts,
+	// rather than waiting for the first scheduled runtime.
+	RunOnStart *bool `json:"runOnStart" yaml:"runOnStart"`
+	// MissedRuns is the policy for scheduled runtimes that were missed while the scheduler was not running,
+	// either `skip` (the default) or `catchUp`, which runs the job once on start if a runtime was missed.
+	// Missed runtimes are determined from the scheduler's last run.
+	MissedRuns string `json:"missedRuns" yaml:"missedRuns"`
 	// ShouldSkipLoggerListeners skips triggering logger events if it is set to true.
 	ShouldSkipLoggerListeners *bool `json:"shouldSkipLoggerListeners" yaml:"shouldSkipLoggerListeners"`
 	// ShouldSkipLoggerOutput skips writing logger output if it is set to true.
@@ -30,6 +37,22 @@ func (jc JobConfig) DisabledOrDefault() bool {
 	return DefaultDisabled
 }
 
+// RunOnStartOrDefault returns a value or a default.
+func (jc JobConfig) RunOnStartOrDefault() bool {
+	if jc.RunOnStart != nil {
+		return *jc.RunOnStart
+	}
+	return DefaultRunOnStart
+}
+
+// MissedRunsOrDefault returns a value or a default.
+func (jc JobConfig) MissedRunsOrDefault() string {
+	if jc.MissedRuns != "" {
+		return jc.MissedRuns
+	}
+	return DefaultMissedRuns
+}
+
 // TimeoutOrDefault returns a value or a default.
 func (jc JobConfig) TimeoutOrDefault() time.Duration {
 	if jc.Timeout > 0 {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ref"
 )
 
 func TestJobConfig(t *testing.T) {
@@ -20,3 +21,20 @@ func TestJobConfig(t *testing.T) {
 	assert.Equal(jc.Timeout, jc.TimeoutOrDefault())
 	assert.Equal(jc.ShutdownGracePeriod, jc.ShutdownGracePeriodOrDefault())
 }
+
+func TestJobConfigRunOnStart(t *testing.T) {
+	assert := assert.New(t)
+
+	var jc JobConfig
+	assert.Equal(DefaultRunOnStart, jc.RunOnStartOrDefault())
+
+	jc.RunOnStart = ref.Bool(true)
+	assert.True(jc.RunOnStartOrDefault())
+}
+
+func TestJobConfigMissedRuns(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(MissedRunsSkip, JobConfig{}.MissedRunsOrDefault())
+	assert.Equal(MissedRunsCatchUp, JobConfig{MissedRuns: MissedRunsCatchUp}.MissedRunsOrDefault())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/blend/go-sdk/async"
 	"github.com/blend/go-sdk/uuid"
 )
 
@@ -51,7 +52,11 @@ type JobInvocation struct {
 	Status     JobInvocationStatus `json:"status"`
 	State      interface{}         `json:"-"`
 
+	// Cancel cancels the invocation with `ErrJobCancelled` as the cause.
 	Cancel context.CancelFunc `json:"-"`
+	// CancelCause cancels the invocation with a given cause, which is
+	// reported as the invocation error, e.g. `ErrJobShutdown`.
+	CancelCause async.CancelCauseFunc `json:"-"`
 }
 
 // Elapsed returns the elapsed time for the invocation.
@@ -76,6 +81,7 @@ func (ji *JobInvocation) Clone() *JobInvocation {
 		Status:     ji.Status,
 		State:      ji.State,
 
-		Cancel: ji.Cancel,
+		Cancel:      ji.Cancel,
+		CancelCause: ji.CancelCause,
 	}
 }
@@ -4,6 +4,7 @@ package cron
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -15,8 +16,10 @@ import (
 // New returns a new job manager.
 func New(options ...JobManagerOption) *JobManager {
 	jm := JobManager{
-		Latch: async.NewLatch(),
-		Jobs:  make(map[string]*JobScheduler),
+		Latch:      async.NewLatch(),
+		Jobs:       make(map[string]*JobScheduler),
+		Store:      NewMemoryStore(),
+		RunHistory: NewRunHistory(DefaultHistorySize),
 	}
 	for _, option := range options {
 		option(&jm)
@@ -33,6 +36,12 @@ type JobManager struct {
 	Started time.Time
 	Stopped time.Time
 	Jobs    map[string]*JobScheduler
+	// Store persists job scheduling state, like when jobs last ran, across restarts.
+	Store Store
+	// OnPanic, if set, is called with the recovered error when a job panics.
+	OnPanic PanicHandler
+	// RunHistory keeps the most recent runs of each job, see `History`.
+	RunHistory *RunHistory
 }
 
 //
@@ -120,6 +129,9 @@ func (jm *JobManager) LoadJobs(jobs ...Job) error {
 			job,
 			OptJobSchedulerLog(jm.Log),
 			OptJobSchedulerTracer(jm.Tracer),
+			OptJobSchedulerStore(jm.Store),
+			OptJobSchedulerOnPanic(jm.OnPanic),
+			OptJobSchedulerRunHistory(jm.RunHistory),
 		)
 		if err := jobScheduler.OnLoad(context.Background()); err != nil {
 			return err
@@ -141,6 +153,9 @@ func (jm *JobManager) UnloadJobs(jobNames ...string) error {
 			}
 			jobScheduler.Stop()
 			delete(jm.Jobs, jobName)
+			if jm.RunHistory != nil {
+				jm.RunHistory.Clear(jobName)
+			}
 		} else {
 			return ex.New(ErrJobNotFound, ex.OptMessagef("job: %s", jobName))
 		}
@@ -178,6 +193,18 @@ func (jm *JobManager) EnableJobs(jobNames ...string) error {
 	return nil
 }
 
+// DisableJob disables a job by name.
+// Disabled jobs remain loaded and skip their scheduled runs, but can still be
+// triggered manually with `RunJob`.
+func (jm *JobManager) DisableJob(jobName string) error {
+	return jm.DisableJobs(jobName)
+}
+
+// EnableJob enables a job by name.
+func (jm *JobManager) EnableJob(jobName string) error {
+	return jm.EnableJobs(jobName)
+}
+
 // HasJob returns if a jobName is loaded or not.
 func (jm *JobManager) HasJob(jobName string) (hasJob bool) {
 	jm.Lock()
@@ -256,6 +283,14 @@ func (jm *JobManager) CancelJob(jobName string) (err error) {
 	return
 }
 
+// History returns the most recent runs of a job, oldest first.
+func (jm *JobManager) History(jobName string) []RunRecord {
+	if jm.RunHistory == nil {
+		return nil
+	}
+	return jm.RunHistory.Get(jobName)
+}
+
 //
 // status and state
 //
@@ -269,3 +304,25 @@ func (jm *JobManager) State() JobManagerState {
 	}
 	return JobManagerStateUnknown
 }
+
+// Status returns a status snapshot of the job manager and its jobs, sorted by job name.
+func (jm *JobManager) Status() JobManagerStatus {
+	jm.Lock()
+	jobs := make([]*JobScheduler, 0, len(jm.Jobs))
+	for _, job := range jm.Jobs {
+		jobs = append(jobs, job)
+	}
+	jm.Unlock()
+	sort.Sort(JobSchedulersByJobNameAsc(jobs))
+
+	status := JobManagerStatus{
+		State:   jm.State(),
+		Started: jm.Started,
+		Stopped: jm.Stopped,
+		Jobs:    make([]JobSchedulerStatus, 0, len(jobs)),
+	}
+	for _, job := range jobs {
+		status.Jobs = append(status.Jobs, job.Status())
+	}
+	return status
+}
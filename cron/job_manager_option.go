@@ -14,3 +14,21 @@ func OptLog(log logger.Log) JobManagerOption {
 func OptTracer(tracer Tracer) JobManagerOption {
 	return func(jm *JobManager) { jm.Tracer = tracer }
 }
+
+// OptStore sets the job manager store, which persists job scheduling state.
+// It must be set before jobs are loaded.
+func OptStore(store Store) JobManagerOption {
+	return func(jm *JobManager) { jm.Store = store }
+}
+
+// OptHistorySize sets the number of runs kept in the history of each job.
+// It must be set before jobs are loaded.
+func OptHistorySize(size int) JobManagerOption {
+	return func(jm *JobManager) { jm.RunHistory = NewRunHistory(size) }
+}
+
+// OptOnPanic sets a handler that is called with the recovered error when a job panics.
+// It must be set before jobs are loaded.
+func OptOnPanic(handler PanicHandler) JobManagerOption {
+	return func(jm *JobManager) { jm.OnPanic = handler }
+}
@@ -0,0 +1,22 @@
+package cron
+
+import "time"
+
+// JobManagerStatus is a snapshot of the job manager and its loaded jobs.
+type JobManagerStatus struct {
+	State   JobManagerState      `json:"state"`
+	Started time.Time            `json:"started,omitempty"`
+	Stopped time.Time            `json:"stopped,omitempty"`
+	Jobs    []JobSchedulerStatus `json:"jobs"`
+}
+
+// JobSchedulerStatus is a snapshot of a job scheduler.
+type JobSchedulerStatus struct {
+	Name     string            `json:"name"`
+	State    JobSchedulerState `json:"state"`
+	Disabled bool              `json:"disabled"`
+	Running  bool              `json:"running"`
+	Labels   map[string]string `json:"labels"`
+	Current  *JobInvocation    `json:"current,omitempty"`
+	Last     *JobInvocation    `json:"last,omitempty"`
+}
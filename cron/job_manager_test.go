@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -348,3 +349,217 @@ func TestJobManagerEnableDisableJob(t *testing.T) {
 	assert.Nil(err)
 	assert.False(j.Disabled())
 }
+
+func TestJobManagerRunOnStart(t *testing.T) {
+	assert := assert.New(t)
+
+	didRunOnStart := make(chan struct{})
+	var normalRuns int32
+	var normalLock sync.Mutex
+
+	jm := New()
+	assert.Nil(jm.LoadJobs(
+		NewJob(
+			OptJobName("run-on-start"),
+			OptJobSchedule(EveryHour()),
+			OptJobRunOnStart(true),
+			OptJobAction(func(_ context.Context) error {
+				close(didRunOnStart)
+				return nil
+			}),
+		),
+		NewJob(
+			OptJobName("normal"),
+			OptJobSchedule(EveryHour()),
+			OptJobAction(func(_ context.Context) error {
+				normalLock.Lock()
+				normalRuns++
+				normalLock.Unlock()
+				return nil
+			}),
+		),
+	))
+
+	assert.Nil(jm.StartAsync())
+	defer jm.Stop()
+
+	select {
+	case <-didRunOnStart:
+	case <-time.After(time.Second):
+		assert.FailNow("run on start job should have run at start")
+	}
+
+	normalLock.Lock()
+	defer normalLock.Unlock()
+	assert.Zero(normalRuns)
+	assert.Nil(jm.Jobs["normal"].Last())
+	assert.Nil(jm.Jobs["normal"].Current())
+}
+
+func TestJobManagerDisableJobSkipsScheduledRuns(t *testing.T) {
+	assert := assert.New(t)
+
+	name := "disable-job-test"
+	var runLock sync.Mutex
+	var runs int
+	ran := make(chan struct{}, 1)
+
+	jm := New()
+	assert.Nil(jm.LoadJobs(NewJob(
+		OptJobName(name),
+		OptJobSchedule(Every(5*time.Millisecond)),
+		OptJobAction(func(_ context.Context) error {
+			runLock.Lock()
+			runs++
+			runLock.Unlock()
+			select {
+			case ran <- struct{}{}:
+			default:
+			}
+			return nil
+		}),
+	)))
+	assert.NotNil(jm.DisableJob("not-a-job"))
+	assert.Nil(jm.DisableJob(name))
+
+	status := jm.Status()
+	assert.Len(status.Jobs, 1)
+	assert.Equal(name, status.Jobs[0].Name)
+	assert.True(status.Jobs[0].Disabled)
+
+	assert.Nil(jm.StartAsync())
+	defer jm.Stop()
+
+	// scheduled runs should be skipped while disabled.
+	time.Sleep(50 * time.Millisecond)
+	runLock.Lock()
+	assert.Zero(runs)
+	runLock.Unlock()
+
+	// manual triggers should still run.
+	_, done, err := jm.RunJob(name)
+	assert.Nil(err)
+	<-done
+	<-ran
+	runLock.Lock()
+	assert.Equal(1, runs)
+	runLock.Unlock()
+
+	assert.Nil(jm.EnableJob(name))
+	assert.False(jm.Status().Jobs[0].Disabled)
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		assert.FailNow("job should have run on its schedule once enabled")
+	}
+}
+
+func TestJobManagerMissedRuns(t *testing.T) {
+	assert := assert.New(t)
+
+	didCatchUp := make(chan struct{})
+	var skipRuns, recentRuns int32
+	var runsLock sync.Mutex
+
+	jm := New()
+	assert.Nil(jm.LoadJobs(
+		NewJob(
+			OptJobName("catch-up"),
+			OptJobSchedule(EveryHour()),
+			OptJobMissedRuns(MissedRunsCatchUp),
+			OptJobAction(func(_ context.Context) error {
+				close(didCatchUp)
+				return nil
+			}),
+		),
+		NewJob(
+			OptJobName("skip"),
+			OptJobSchedule(EveryHour()),
+			OptJobAction(func(_ context.Context) error {
+				runsLock.Lock()
+				skipRuns++
+				runsLock.Unlock()
+				return nil
+			}),
+		),
+		NewJob(
+			OptJobName("catch-up-recent"),
+			OptJobSchedule(EveryHour()),
+			OptJobMissedRuns(MissedRunsCatchUp),
+			OptJobAction(func(_ context.Context) error {
+				runsLock.Lock()
+				recentRuns++
+				runsLock.Unlock()
+				return nil
+			}),
+		),
+	))
+
+	// simulate a gap in the run history of several scheduled runtimes.
+	jm.Jobs["catch-up"].LastRun = Now().Add(-3 * time.Hour)
+	jm.Jobs["skip"].LastRun = Now().Add(-3 * time.Hour)
+	jm.Jobs["catch-up-recent"].LastRun = Now().Add(-time.Minute)
+
+	assert.True(jm.Jobs["catch-up"].MissedRun())
+	assert.False(jm.Jobs["skip"].MissedRun())
+	assert.False(jm.Jobs["catch-up-recent"].MissedRun())
+
+	assert.Nil(jm.StartAsync())
+	defer jm.Stop()
+
+	select {
+	case <-didCatchUp:
+	case <-time.After(time.Second):
+		assert.FailNow("catch up job should have run at start")
+	}
+
+	// give the other jobs a chance to (incorrectly) run.
+	time.Sleep(50 * time.Millisecond)
+	runsLock.Lock()
+	defer runsLock.Unlock()
+	assert.Zero(skipRuns)
+	assert.Zero(recentRuns)
+}
+
+func TestManagerOnPanic(t *testing.T) {
+	assert := assert.New(t)
+
+	panics := make(chan error, 1)
+	var panicJobName string
+	traced := make(chan error, 1)
+	manager := New(
+		OptTracer(&mockTracer{
+			OnFinish: func(_ context.Context, err error) { traced <- err },
+		}),
+		OptOnPanic(func(jobName string, err error) {
+			panicJobName = jobName
+			panics <- err
+		}),
+	)
+
+	var runs int32
+	assert.Nil(manager.LoadJobs(NewJob(OptJobName("panic-test"), OptJobAction(func(_ context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		panic("this is only a test")
+	}))))
+
+	ji, done, err := manager.RunJob("panic-test")
+	assert.Nil(err)
+	<-done
+	panicErr := <-panics
+	assert.NotNil(panicErr)
+	assert.Equal("panic-test", panicJobName)
+	assert.Contains(fmt.Sprintf("%v", panicErr), "this is only a test")
+	assert.Equal(panicErr, <-traced)
+	assert.Equal(JobInvocationStatusErrored, ji.Status)
+	assert.Equal(panicErr, ji.Err)
+
+	// the scheduler should survive the panic and run the job again.
+	_, done, err = manager.RunJob("panic-test")
+	assert.Nil(err)
+	<-done
+	assert.NotNil(<-panics)
+	assert.NotNil(<-traced)
+	assert.Equal(2, atomic.LoadInt32(&runs))
+}
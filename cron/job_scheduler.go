@@ -39,13 +39,25 @@ type JobScheduler struct {
 
 	Tracer Tracer
 	Log    logger.Log
+	// Store, if set, is read for the job's last run when the scheduler starts,
+	// and written when an invocation completes.
+	Store Store
+	// OnPanic, if set, is called with the recovered error when the job panics.
+	// The error is also the invocation error, and is passed to the tracer.
+	OnPanic PanicHandler
+	// RunHistory, if set, has a record of each invocation added when it completes.
+	RunHistory *RunHistory
 
 	NextRuntime time.Time
+	// LastRun is the time the job last ran before the scheduler started, e.g. restored from persisted state.
+	// It is used to determine if scheduled runtimes were missed while the scheduler was not running.
+	LastRun time.Time
 
-	currentLock sync.Mutex
-	current     *JobInvocation
-	lastLock    sync.Mutex
-	last        *JobInvocation
+	disabledLock sync.RWMutex
+	currentLock  sync.Mutex
+	current      *JobInvocation
+	lastLock     sync.Mutex
+	last         *JobInvocation
 }
 
 // Name returns the job name.
@@ -58,6 +70,8 @@ func (js *JobScheduler) Config() JobConfig {
 	if typed, ok := js.Job.(ConfigProvider); ok {
 		return typed.Config()
 	}
+	js.disabledLock.RLock()
+	defer js.disabledLock.RUnlock()
 	return js.JobConfig
 }
 
@@ -76,8 +90,11 @@ func (js *JobScheduler) Description() string {
 
 // Disabled returns if the job is disabled or not.
 func (js *JobScheduler) Disabled() bool {
-	if js.JobConfig.Disabled != nil {
-		return *js.JobConfig.Disabled
+	js.disabledLock.RLock()
+	disabled := js.JobConfig.Disabled
+	js.disabledLock.RUnlock()
+	if disabled != nil {
+		return *disabled
 	}
 	return js.Config().DisabledOrDefault()
 }
@@ -111,6 +128,19 @@ func (js *JobScheduler) State() JobSchedulerState {
 	return JobSchedulerStateUnknown
 }
 
+// Status returns a status snapshot of the job scheduler.
+func (js *JobScheduler) Status() JobSchedulerStatus {
+	return JobSchedulerStatus{
+		Name:     js.Name(),
+		State:    js.State(),
+		Disabled: js.Disabled(),
+		Running:  !js.IsIdle(),
+		Labels:   js.Labels(),
+		Current:  js.Current(),
+		Last:     js.Last(),
+	}
+}
+
 // Start starts the scheduler.
 // This call blocks.
 func (js *JobScheduler) Start() error {
@@ -141,7 +171,7 @@ func (js *JobScheduler) Stop() error {
 		}
 	}
 	if current := js.Current(); current != nil && current.Status == JobInvocationStatusRunning {
-		current.Cancel()
+		current.CancelCause(ErrJobShutdown)
 	}
 
 	<-js.Latch.NotifyStopped()
@@ -183,7 +213,9 @@ func (js *JobScheduler) NotifyStopped() <-chan struct{} {
 // Enable sets the job as enabled.
 func (js *JobScheduler) Enable() {
 	ctx := js.withLogContext(context.Background())
+	js.disabledLock.Lock()
 	js.JobConfig.Disabled = ref.Bool(false)
+	js.disabledLock.Unlock()
 	if lifecycle := js.Lifecycle(); lifecycle.OnEnabled != nil {
 		lifecycle.OnEnabled(ctx)
 	}
@@ -195,7 +227,9 @@ func (js *JobScheduler) Enable() {
 // Disable sets the job as disabled.
 func (js *JobScheduler) Disable() {
 	ctx := js.withLogContext(context.Background())
+	js.disabledLock.Lock()
 	js.JobConfig.Disabled = ref.Bool(true)
+	js.disabledLock.Unlock()
 	if lifecycle := js.Lifecycle(); lifecycle.OnDisabled != nil {
 		lifecycle.OnDisabled(ctx)
 	}
@@ -241,11 +275,37 @@ func (js *JobScheduler) RunLoop() {
 
 	js.debugf(ctx, "RunLoop: entered running state")
 
+	if js.Store != nil {
+		if lastRun, err := js.Store.GetLastRun(js.Name()); err != nil {
+			js.error(ctx, err)
+		} else if !lastRun.IsZero() {
+			js.LastRun = lastRun
+			js.debugf(ctx, "RunLoop: restored last run `%s`", js.LastRun.Format(time.RFC3339Nano))
+		}
+	}
+
 	if js.JobSchedule != nil {
-		js.NextRuntime = js.JobSchedule.Next(js.NextRuntime)
+		if typed, ok := js.JobSchedule.(CompletionSchedule); ok && !js.LastRun.IsZero() {
+			js.NextRuntime = typed.NextAfterComplete(js.LastRun)
+		} else {
+			js.NextRuntime = js.JobSchedule.Next(js.NextRuntime)
+		}
 		js.debugf(ctx, "RunLoop: setting next runtime `%s`", js.NextRuntime.Format(time.RFC3339Nano))
 	}
 
+	// if the job is configured to run on start, or to catch up on a missed runtime,
+	// kick off an invocation immediately rather than waiting for the first runtime.
+	if js.Config().RunOnStartOrDefault() || js.MissedRun() {
+		if js.CanBeScheduled() {
+			js.debugf(ctx, "RunLoop: running on start")
+			if _, _, err := js.RunAsync(); err != nil {
+				js.error(ctx, err)
+			}
+		} else {
+			js.debugf(ctx, "RunLoop: job cannot be run on start; disabled or already running")
+		}
+	}
+
 	// if the schedule returns a zero timestamp
 	// it should be interpretted as *not* to automatically
 	// schedule the job to be run.
@@ -264,8 +324,11 @@ func (js *JobScheduler) RunLoop() {
 		runAt := time.After(js.NextRuntime.UTC().Sub(Now()))
 		select {
 		case <-runAt:
+			var ji *JobInvocation
+			var done <-chan struct{}
 			if js.CanBeScheduled() {
-				if _, _, err := js.RunAsync(); err != nil {
+				var err error
+				if ji, done, err = js.RunAsync(); err != nil {
 					js.error(ctx, err)
 				}
 			} else {
@@ -273,7 +336,18 @@ func (js *JobScheduler) RunLoop() {
 			}
 
 			// set up the next runtime.
-			if js.JobSchedule != nil {
+			if typed, ok := js.JobSchedule.(CompletionSchedule); ok && done != nil {
+				// wait for the invocation to complete so the
+				// next runtime is computed from the completion time.
+				select {
+				case <-done:
+				case <-js.Latch.NotifyStopping():
+					js.debugf(ctx, "RunLoop: stop signal received")
+					return
+				}
+				js.NextRuntime = typed.NextAfterComplete(ji.Complete)
+				js.debugf(ctx, "RunLoop: setting next runtime from completion `%s`", js.NextRuntime.Format(time.RFC3339Nano))
+			} else if js.JobSchedule != nil {
 				js.NextRuntime = js.JobSchedule.Next(js.NextRuntime)
 				js.debugf(ctx, "RunLoop: setting next runtime `%s`", js.NextRuntime.Format(time.RFC3339Nano))
 			} else {
@@ -291,6 +365,19 @@ func (js *JobScheduler) RunLoop() {
 	}
 }
 
+// MissedRun returns if the job is configured to catch up on missed runs, and a
+// scheduled runtime after the last run has passed.
+func (js *JobScheduler) MissedRun() bool {
+	if js.Config().MissedRunsOrDefault() != MissedRunsCatchUp {
+		return false
+	}
+	if js.JobSchedule == nil || js.LastRun.IsZero() {
+		return false
+	}
+	missed := js.JobSchedule.Next(js.LastRun)
+	return !missed.IsZero() && missed.Before(Now())
+}
+
 // RunAsync starts a job invocation with a context.Background() as
 // the root context.
 func (js *JobScheduler) RunAsync() (*JobInvocation, <-chan struct{}, error) {
@@ -312,7 +399,7 @@ func (js *JobScheduler) RunAsyncContext(ctx context.Context) (*JobInvocation, <-
 	go func() {
 		defer func() {
 			if err != nil && IsJobCancelled(err) {
-				js.onJobCancelled(ctx) // the job was cancelled, either manually or by a timeout
+				js.onJobCancelled(ctx, err) // the job was cancelled, either manually, by a shutdown or by a timeout
 			} else if err != nil {
 				js.onJobError(ctx, err) // the job completed with an error
 			} else {
@@ -324,6 +411,7 @@ func (js *JobScheduler) RunAsyncContext(ctx context.Context) (*JobInvocation, <-
 				tracer.Finish(ctx, err) // call the trace finisher if one was started
 			}
 			ji.Cancel() // if the job was created with a timeout, end the timeout
+			js.recordRun()
 
 			close(done)              // signal callers the job is done
 			js.assignCurrentToLast() // rotate in the current to the last result
@@ -336,7 +424,7 @@ func (js *JobScheduler) RunAsyncContext(ctx context.Context) (*JobInvocation, <-
 
 		select {
 		case <-ctx.Done(): // if the timeout or cancel is triggered
-			err = ErrJobCancelled // set the error to a known error
+			err = js.cancelledErr(ctx) // set the error to a known error with the cause
 			return
 		case err = <-js.safeBackgroundExec(ctx): // run the job in a background routine and catch pancis
 			return
@@ -427,11 +515,21 @@ func (js *JobScheduler) assignCurrentToLast() {
 	js.lastLock.Unlock()
 }
 
+func (js *JobScheduler) recordRun() {
+	if js.RunHistory == nil {
+		return
+	}
+	if current := js.Current(); current != nil {
+		js.RunHistory.Add(NewRunRecord(current))
+	}
+}
+
 func (js *JobScheduler) createInvocation(ctx context.Context) (context.Context, *JobInvocation) {
 	ji := NewJobInvocation(js.Name())
 	ji.Parameters = MergeJobParameterValues(js.Config().ParameterValues, GetJobParameterValues(ctx))
 	ctx = js.withInvocationLogContext(ctx, ji)
-	ctx, ji.Cancel = js.withTimeoutOrCancel(ctx, js.Config().TimeoutOrDefault())
+	ctx, ji.CancelCause = async.WithTimeoutCause(ctx, js.Config().TimeoutOrDefault(), ErrJobTimeout)
+	ji.Cancel = func() { ji.CancelCause(ErrJobCancelled) }
 	ctx = WithJobInvocation(ctx, ji)
 	ctx = WithJobParameterValues(ctx, ji.Parameters)
 	return ctx, ji
@@ -457,8 +555,9 @@ func (js *JobScheduler) safeBackgroundExec(ctx context.Context) chan error {
 	errors := make(chan error, 2)
 	go func() {
 		defer func() {
-			if r := recover(); r != nil {
-				errors <- ex.New(r)
+			if err := ex.Recover(recover()); err != nil {
+				js.onJobPanic(ctx, err)
+				errors <- err
 			}
 		}()
 		errors <- js.Job.Execute(ctx)
@@ -466,6 +565,18 @@ func (js *JobScheduler) safeBackgroundExec(ctx context.Context) chan error {
 	return errors
 }
 
+// cancelledErr returns the error for a cancelled invocation context.
+//
+// If the invocation was cancelled by the scheduler the cause is returned as is,
+// otherwise (e.g. the parent context was cancelled) the cause is wrapped as `ErrJobCancelled`.
+func (js *JobScheduler) cancelledErr(ctx context.Context) error {
+	cause := async.Cause(ctx)
+	if IsJobCancelled(cause) {
+		return cause
+	}
+	return ex.New(ErrJobCancelled, ex.OptInner(cause))
+}
+
 func (js *JobScheduler) withTimeoutOrCancel(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
 	if timeout > 0 {
 		return context.WithTimeout(ctx, timeout)
@@ -477,8 +588,8 @@ func (js *JobScheduler) withTimeoutOrCancel(ctx context.Context, timeout time.Du
 
 func (js *JobScheduler) onJobBegin(ctx context.Context) {
 	defer func() {
-		if r := recover(); r != nil {
-			js.error(ctx, ex.New(r, ex.OptMessagef("panic recovery in onJobBegin")))
+		if err := ex.Recover(recover(), ex.OptMessagef("panic recovery in onJobBegin")); err != nil {
+			js.error(ctx, err)
 		}
 	}()
 
@@ -498,17 +609,24 @@ func (js *JobScheduler) onJobBegin(ctx context.Context) {
 
 func (js *JobScheduler) onJobComplete(ctx context.Context) {
 	defer func() {
-		if r := recover(); r != nil {
-			js.error(ctx, ex.New(r, ex.OptMessagef("panic recovery in onJobComplete")))
+		if err := ex.Recover(recover(), ex.OptMessagef("panic recovery in onJobComplete")); err != nil {
+			js.error(ctx, err)
 		}
 	}()
 
 	js.currentLock.Lock()
 	js.current.Complete = time.Now().UTC()
 	id := js.current.ID
+	complete := js.current.Complete
 	elapsed := js.current.Elapsed()
 	js.currentLock.Unlock()
 
+	if js.Store != nil {
+		if err := js.Store.SetLastRun(js.Name(), complete); err != nil {
+			js.error(ctx, err)
+		}
+	}
+
 	if lifecycle := js.Lifecycle(); lifecycle.OnComplete != nil {
 		lifecycle.OnComplete(ctx)
 	}
@@ -517,15 +635,27 @@ func (js *JobScheduler) onJobComplete(ctx context.Context) {
 	}
 }
 
-func (js *JobScheduler) onJobCancelled(ctx context.Context) {
+func (js *JobScheduler) onJobPanic(ctx context.Context, err error) {
 	defer func() {
-		if r := recover(); r != nil {
-			js.error(ctx, ex.New(r, ex.OptMessagef("panic recovery in onJobCanceled")))
+		if err := ex.Recover(recover(), ex.OptMessagef("panic recovery in onJobPanic")); err != nil {
+			js.error(ctx, err)
+		}
+	}()
+	if js.OnPanic != nil {
+		js.OnPanic(js.Name(), err)
+	}
+}
+
+func (js *JobScheduler) onJobCancelled(ctx context.Context, err error) {
+	defer func() {
+		if err := ex.Recover(recover(), ex.OptMessagef("panic recovery in onJobCanceled")); err != nil {
+			js.error(ctx, err)
 		}
 	}()
 
 	js.currentLock.Lock()
 	js.current.Status = JobInvocationStatusCancelled
+	js.current.Err = err
 	id := js.current.ID
 	elapsed := js.current.Elapsed()
 	js.currentLock.Unlock()
@@ -540,8 +670,8 @@ func (js *JobScheduler) onJobCancelled(ctx context.Context) {
 
 func (js *JobScheduler) onJobSuccess(ctx context.Context) {
 	defer func() {
-		if r := recover(); r != nil {
-			js.error(ctx, ex.New(r, ex.OptMessagef("panic recovery in onJobSuccess")))
+		if err := ex.Recover(recover(), ex.OptMessagef("panic recovery in onJobSuccess")); err != nil {
+			js.error(ctx, err)
 		}
 	}()
 
@@ -570,8 +700,8 @@ func (js *JobScheduler) onJobSuccess(ctx context.Context) {
 
 func (js *JobScheduler) onJobError(ctx context.Context, err error) {
 	defer func() {
-		if r := recover(); r != nil {
-			js.error(ctx, ex.New(r, ex.OptMessagef("panic recovery in onJobError")))
+		if err := ex.Recover(recover(), ex.OptMessagef("panic recovery in onJobError")); err != nil {
+			js.error(ctx, err)
 		}
 	}()
 
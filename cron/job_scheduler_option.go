@@ -1,6 +1,10 @@
 package cron
 
-import "github.com/blend/go-sdk/logger"
+import (
+	"time"
+
+	"github.com/blend/go-sdk/logger"
+)
 
 // JobSchedulerOption is an option for job schedulers.
 type JobSchedulerOption func(*JobScheduler)
@@ -14,3 +18,23 @@ func OptJobSchedulerTracer(tracer Tracer) JobSchedulerOption {
 func OptJobSchedulerLog(log logger.Log) JobSchedulerOption {
 	return func(js *JobScheduler) { js.Log = log }
 }
+
+// OptJobSchedulerLastRun sets the time the job last ran before the scheduler started.
+func OptJobSchedulerLastRun(lastRun time.Time) JobSchedulerOption {
+	return func(js *JobScheduler) { js.LastRun = lastRun }
+}
+
+// OptJobSchedulerStore sets the job scheduler store.
+func OptJobSchedulerStore(store Store) JobSchedulerOption {
+	return func(js *JobScheduler) { js.Store = store }
+}
+
+// OptJobSchedulerRunHistory sets the run history job invocations are recorded to when they complete.
+func OptJobSchedulerRunHistory(runHistory *RunHistory) JobSchedulerOption {
+	return func(js *JobScheduler) { js.RunHistory = runHistory }
+}
+
+// OptJobSchedulerOnPanic sets a handler that is called with the recovered error when the job panics.
+func OptJobSchedulerOnPanic(handler PanicHandler) JobSchedulerOption {
+	return func(js *JobScheduler) { js.OnPanic = handler }
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
 	"github.com/blend/go-sdk/graceful"
 )
 
@@ -54,6 +55,32 @@ func TestJobSchedulerEnableDisable(t *testing.T) {
 	assert.True(triggerdOnEnabled)
 }
 
+func TestJobSchedulerConfigConcurrentDisable(t *testing.T) {
+	assert := assert.New(t)
+
+	// the job does not provide a config, so the scheduler config is read while it is enabled and disabled.
+	js := NewJobScheduler(&runAtJob{RunAt: time.Now().Add(time.Hour), RunDelegate: func(_ context.Context) error { return nil }})
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for x := 0; x < 100; x++ {
+			js.Disable()
+			js.Enable()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for x := 0; x < 100; x++ {
+			_ = js.Config()
+		}
+	}()
+	wg.Wait()
+	assert.False(js.Disabled())
+	assert.False(js.Config().DisabledOrDefault())
+}
+
 func TestJobSchedulerLabels(t *testing.T) {
 	assert := assert.New(t)
 
@@ -193,3 +220,49 @@ func TestSchedulerImediatelyThen(t *testing.T) {
 	assert.True(ok)
 	assert.True(typed.didRun)
 }
+
+func TestJobSchedulerCancelCause(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := [...]struct {
+		Name     string
+		Timeout  time.Duration
+		Cancel   func(context.CancelFunc, *JobInvocation)
+		Expected ex.Class
+	}{
+		{Name: "timeout", Timeout: time.Millisecond, Cancel: func(_ context.CancelFunc, _ *JobInvocation) {}, Expected: ErrJobTimeout},
+		{Name: "cancel", Cancel: func(_ context.CancelFunc, ji *JobInvocation) { ji.Cancel() }, Expected: ErrJobCancelled},
+		{Name: "shutdown", Cancel: func(_ context.CancelFunc, ji *JobInvocation) { ji.CancelCause(ErrJobShutdown) }, Expected: ErrJobShutdown},
+		{Name: "parent", Cancel: func(cancel context.CancelFunc, _ *JobInvocation) { cancel() }, Expected: ErrJobCancelled},
+	}
+
+	for _, tc := range testCases {
+		finished := make(chan error, 1)
+		js := NewJobScheduler(
+			NewJob(
+				OptJobName("cancel-cause-test"),
+				OptJobTimeout(tc.Timeout),
+				OptJobAction(func(ctx context.Context) error {
+					<-ctx.Done()
+					return nil
+				}),
+			),
+			OptJobSchedulerTracer(&mockTracer{
+				OnFinish: func(_ context.Context, err error) { finished <- err },
+			}),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		ji, done, err := js.RunAsyncContext(ctx)
+		assert.Nil(err, tc.Name)
+		tc.Cancel(cancel, ji)
+		<-done
+		cancel()
+
+		err = <-finished
+		assert.True(ex.Is(err, tc.Expected), tc.Name)
+		assert.True(IsJobCancelled(err), tc.Name)
+		assert.Equal(JobInvocationStatusCancelled, js.Last().Status, tc.Name)
+		assert.True(ex.Is(js.Last().Err, tc.Expected), tc.Name)
+	}
+}
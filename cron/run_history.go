@@ -0,0 +1,97 @@
+package cron
+
+import (
+	"sync"
+	"time"
+
+	"github.com/blend/go-sdk/collections"
+)
+
+// RunRecord is a record of a completed job invocation.
+type RunRecord struct {
+	ID       string              `json:"id"`
+	JobName  string              `json:"jobName"`
+	Started  time.Time           `json:"started"`
+	Complete time.Time           `json:"complete"`
+	Elapsed  time.Duration       `json:"elapsed"`
+	Status   JobInvocationStatus `json:"status"`
+	Err      error               `json:"err,omitempty"`
+}
+
+// NewRunRecord returns a run record for a given job invocation.
+func NewRunRecord(ji *JobInvocation) RunRecord {
+	return RunRecord{
+		ID:       ji.ID,
+		JobName:  ji.JobName,
+		Started:  ji.Started,
+		Complete: ji.Complete,
+		Elapsed:  ji.Elapsed(),
+		Status:   ji.Status,
+		Err:      ji.Err,
+	}
+}
+
+// NewRunHistory returns a new run history that keeps the last given number of runs for each job.
+func NewRunHistory(size int) *RunHistory {
+	return &RunHistory{
+		Size: size,
+		Runs: make(map[string]*collections.RingBuffer),
+	}
+}
+
+// RunHistory keeps the most recent runs of each job in memory, in a ring buffer per job.
+type RunHistory struct {
+	sync.Mutex
+	// Size is the number of runs kept for each job; it defaults to `DefaultHistorySize`.
+	Size int
+	Runs map[string]*collections.RingBuffer
+}
+
+// SizeOrDefault returns the history size or a default.
+func (rh *RunHistory) SizeOrDefault() int {
+	if rh.Size > 0 {
+		return rh.Size
+	}
+	return DefaultHistorySize
+}
+
+// Add adds a run record, dropping the oldest record for the job if its history is full.
+func (rh *RunHistory) Add(record RunRecord) {
+	rh.Lock()
+	defer rh.Unlock()
+	if rh.Runs == nil {
+		rh.Runs = make(map[string]*collections.RingBuffer)
+	}
+	size := rh.SizeOrDefault()
+	runs, ok := rh.Runs[record.JobName]
+	if !ok {
+		runs = collections.NewRingBufferWithCapacity(size)
+		rh.Runs[record.JobName] = runs
+	}
+	for runs.Len() >= size {
+		runs.Dequeue()
+	}
+	runs.Enqueue(record)
+}
+
+// Get returns the run records for a job, oldest first.
+func (rh *RunHistory) Get(jobName string) []RunRecord {
+	rh.Lock()
+	defer rh.Unlock()
+	runs, ok := rh.Runs[jobName]
+	if !ok {
+		return nil
+	}
+	output := make([]RunRecord, 0, runs.Len())
+	runs.Each(func(value interface{}) {
+		output = append(output, value.(RunRecord))
+	})
+	return output
+}
+
+// Clear removes the run records for a job.
+func (rh *RunHistory) Clear(jobName string) {
+	rh.Lock()
+	defer rh.Unlock()
+	delete(rh.Runs, jobName)
+}
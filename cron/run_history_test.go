@@ -0,0 +1,73 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestJobManagerHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	var runs int
+	job := NewJob(OptJobName("history-test"), OptJobAction(func(_ context.Context) error {
+		runs++
+		if runs%2 == 0 {
+			return fmt.Errorf("run %d failed", runs)
+		}
+		return nil
+	}))
+
+	manager := New(OptHistorySize(3))
+	assert.Nil(manager.LoadJobs(job))
+	assert.Empty(manager.History(job.Name()))
+
+	var ids []string
+	for x := 0; x < 5; x++ {
+		ji, done, err := manager.RunJob(job.Name())
+		assert.Nil(err)
+		<-done
+		ids = append(ids, ji.ID)
+	}
+
+	history := manager.History(job.Name())
+	assert.Len(history, 3)
+	for index, record := range history {
+		assert.Equal(ids[index+2], record.ID, "history should have the most recent runs, oldest first")
+		assert.Equal(job.Name(), record.JobName)
+		assert.False(record.Started.IsZero())
+		assert.False(record.Complete.Before(record.Started))
+		assert.Equal(record.Complete.Sub(record.Started), record.Elapsed)
+	}
+	assert.Equal(JobInvocationStatusSuccess, history[0].Status)
+	assert.Nil(history[0].Err)
+	assert.Equal(JobInvocationStatusErrored, history[1].Status)
+	assert.Equal("run 4 failed", ex.ErrClass(history[1].Err).Error())
+	assert.Equal(JobInvocationStatusSuccess, history[2].Status)
+
+	assert.Empty(manager.History("not-a-job"))
+	assert.Nil(manager.UnloadJobs(job.Name()))
+	assert.Empty(manager.History(job.Name()))
+}
+
+func TestRunHistoryAddConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	history := NewRunHistory(0)
+	assert.Equal(DefaultHistorySize, history.SizeOrDefault())
+
+	wg := sync.WaitGroup{}
+	for x := 0; x < 4*DefaultHistorySize; x++ {
+		wg.Add(1)
+		go func(x int) {
+			defer wg.Done()
+			history.Add(RunRecord{ID: fmt.Sprint(x), JobName: "concurrent-test"})
+		}(x)
+	}
+	wg.Wait()
+	assert.Len(history.Get("concurrent-test"), DefaultHistorySize)
+}
@@ -10,3 +10,13 @@ type Schedule interface {
 	// the job hasn't run yet. If time.Time{} is returned by the schedule it is inferred that the job should not run again.
 	Next(time.Time) time.Time
 }
+
+// CompletionSchedule is a schedule whose next runtime is computed from when
+// the previous invocation completed, rather than when it was scheduled to start.
+//
+// The job scheduler waits for the invocation it started to complete before
+// calling `NextAfterComplete` with the invocation's completion timestamp.
+type CompletionSchedule interface {
+	Schedule
+	NextAfterComplete(time.Time) time.Time
+}
@@ -0,0 +1,53 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	_ Store = (*MemoryStore)(nil)
+)
+
+// Store persists scheduling state for jobs, so it can be restored across restarts.
+//
+// The job manager gives its store to the job schedulers it loads; schedulers read a job's
+// last run when they start, which feeds the missed run policy and completion based schedules,
+// and write it when an invocation completes.
+type Store interface {
+	// GetLastRun returns the time a job last completed, or a zero time if it is unknown.
+	GetLastRun(jobName string) (time.Time, error)
+	// SetLastRun sets the time a job last completed.
+	SetLastRun(jobName string, lastRun time.Time) error
+}
+
+// NewMemoryStore returns a new in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		LastRuns: make(map[string]time.Time),
+	}
+}
+
+// MemoryStore is an in-memory store; state is kept only for the life of the process.
+type MemoryStore struct {
+	sync.Mutex
+	LastRuns map[string]time.Time
+}
+
+// GetLastRun implements Store.
+func (ms *MemoryStore) GetLastRun(jobName string) (time.Time, error) {
+	ms.Lock()
+	defer ms.Unlock()
+	return ms.LastRuns[jobName], nil
+}
+
+// SetLastRun implements Store.
+func (ms *MemoryStore) SetLastRun(jobName string, lastRun time.Time) error {
+	ms.Lock()
+	defer ms.Unlock()
+	if ms.LastRuns == nil {
+		ms.LastRuns = make(map[string]time.Time)
+	}
+	ms.LastRuns[jobName] = lastRun
+	return nil
+}
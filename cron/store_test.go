@@ -0,0 +1,138 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+var (
+	_ Store = (*fakeStore)(nil)
+)
+
+type fakeStore struct {
+	sync.Mutex
+	lastRuns map[string]time.Time
+	getErr   error
+	sets     chan string
+}
+
+func (fs *fakeStore) GetLastRun(jobName string) (time.Time, error) {
+	fs.Lock()
+	defer fs.Unlock()
+	return fs.lastRuns[jobName], fs.getErr
+}
+
+func (fs *fakeStore) SetLastRun(jobName string, lastRun time.Time) error {
+	fs.Lock()
+	fs.lastRuns[jobName] = lastRun
+	fs.Unlock()
+	if fs.sets != nil {
+		fs.sets <- jobName
+	}
+	return nil
+}
+
+func TestMemoryStore(t *testing.T) {
+	assert := assert.New(t)
+
+	store := NewMemoryStore()
+	lastRun, err := store.GetLastRun("foo")
+	assert.Nil(err)
+	assert.True(lastRun.IsZero())
+
+	now := time.Now().UTC()
+	assert.Nil(store.SetLastRun("foo", now))
+	lastRun, err = store.GetLastRun("foo")
+	assert.Nil(err)
+	assert.Equal(now, lastRun)
+
+	assert.Nil((&MemoryStore{}).SetLastRun("foo", now))
+}
+
+func TestJobManagerStore(t *testing.T) {
+	assert := assert.New(t)
+
+	store := &fakeStore{
+		lastRuns: map[string]time.Time{
+			"catch-up":       Now().Add(-3 * time.Hour),
+			"after-complete": Now().Add(-2 * time.Hour),
+		},
+		sets: make(chan string, 2),
+	}
+
+	jm := New(OptStore(store))
+	assert.Equal(store, jm.Store)
+	assert.Nil(jm.LoadJobs(
+		NewJob(
+			OptJobName("catch-up"),
+			OptJobSchedule(EveryHour()),
+			OptJobMissedRuns(MissedRunsCatchUp),
+			OptJobAction(func(_ context.Context) error { return nil }),
+		),
+		NewJob(
+			OptJobName("after-complete"),
+			OptJobSchedule(EveryAfterComplete(time.Hour)),
+			OptJobAction(func(_ context.Context) error { return nil }),
+		),
+	))
+	assert.Equal(store, jm.Jobs["catch-up"].Store)
+
+	started := Now()
+	assert.Nil(jm.StartAsync())
+	defer jm.Stop()
+
+	// both jobs should run immediately; the catch up job because it missed a runtime,
+	// the after complete job because an hour has passed since it last completed.
+	ran := map[string]bool{}
+	for x := 0; x < 2; x++ {
+		select {
+		case jobName := <-store.sets:
+			ran[jobName] = true
+		case <-time.After(time.Second):
+			assert.FailNow("jobs should have run at start")
+		}
+	}
+	assert.True(ran["catch-up"])
+	assert.True(ran["after-complete"])
+
+	lastRun, err := store.GetLastRun("catch-up")
+	assert.Nil(err)
+	assert.False(lastRun.Before(started), "the last run should have been persisted on completion")
+}
+
+func TestJobManagerStoreGetError(t *testing.T) {
+	assert := assert.New(t)
+
+	store := &fakeStore{
+		lastRuns: map[string]time.Time{"catch-up": Now().Add(-3 * time.Hour)},
+		getErr:   fmt.Errorf("store unavailable"),
+	}
+	var runs int32
+	var runsLock sync.Mutex
+
+	jm := New(OptStore(store))
+	assert.Nil(jm.LoadJobs(NewJob(
+		OptJobName("catch-up"),
+		OptJobSchedule(EveryHour()),
+		OptJobMissedRuns(MissedRunsCatchUp),
+		OptJobAction(func(_ context.Context) error {
+			runsLock.Lock()
+			runs++
+			runsLock.Unlock()
+			return nil
+		}),
+	)))
+	assert.Nil(jm.StartAsync())
+	defer jm.Stop()
+
+	time.Sleep(50 * time.Millisecond)
+	runsLock.Lock()
+	defer runsLock.Unlock()
+	assert.Zero(runs, "the last run should be ignored if the store errors")
+	assert.Equal(JobSchedulerStateRunning, jm.Jobs["catch-up"].State())
+}
@@ -1,13 +1,10 @@
 package cron
 
-import "context"
+import "github.com/blend/go-sdk/tracing"
 
 // Tracer is a trace handler.
-type Tracer interface {
-	Start(context.Context, string) (context.Context, TraceFinisher)
-}
+// It is a `tracing.Tracer`, so the same tracer can be used for web requests; see `web.WithTracing`.
+type Tracer = tracing.Tracer
 
 // TraceFinisher is a finisher for traces.
-type TraceFinisher interface {
-	Finish(context.Context, error)
-}
+type TraceFinisher = tracing.TraceFinisher
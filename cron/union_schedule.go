@@ -0,0 +1,58 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Interface assertions.
+var (
+	_ Schedule     = (*UnionSchedule)(nil)
+	_ fmt.Stringer = (*UnionSchedule)(nil)
+)
+
+// Union returns a schedule that fires whenever any of a given set of schedules fire,
+// e.g. both every weekday at 9am and at midnight on the first of each month.
+//
+// Each call to `Next` calls `Next` on every schedule, so schedules that track how many times they've
+// been called, like `Times` or `Immediately`, advance together.
+func Union(schedules ...Schedule) *UnionSchedule {
+	return &UnionSchedule{Schedules: schedules}
+}
+
+// UnionSchedule is a schedule whose next runtime is the earliest next runtime of its schedules.
+type UnionSchedule struct {
+	Schedules []Schedule
+}
+
+// Next implements cron.Schedule.
+//
+// If schedules coincide, the runtime is returned once. Runtimes that are not after a given
+// previous runtime are skipped, so a schedule that would fire again at the previous runtime doesn't fire twice.
+func (us *UnionSchedule) Next(after time.Time) (next time.Time) {
+	for _, schedule := range us.Schedules {
+		if schedule == nil {
+			continue
+		}
+		scheduleNext := schedule.Next(after)
+		if scheduleNext.IsZero() || (!after.IsZero() && !scheduleNext.After(after)) {
+			continue
+		}
+		if next.IsZero() || scheduleNext.Before(next) {
+			next = scheduleNext
+		}
+	}
+	return
+}
+
+// String returns a string representation of the schedule.
+func (us *UnionSchedule) String() string {
+	var tokens []string
+	for _, schedule := range us.Schedules {
+		if schedule != nil {
+			tokens = append(tokens, fmt.Sprintf("%v", schedule))
+		}
+	}
+	return fmt.Sprintf("union of (%s)", strings.Join(tokens, ", "))
+}
@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestUnionSchedule(t *testing.T) {
+	assert := assert.New(t)
+
+	firstOfMonth, err := ParseString("0 0 9 1 * * *")
+	assert.Nil(err)
+	schedule := Union(WeekdaysAtUTC(9, 0, 0), firstOfMonth)
+
+	// 2019-05-30 is a thursday; 2019-06-01 is a saturday, and 2019-07-01 is a monday.
+	expected := []time.Time{
+		time.Date(2019, 05, 30, 9, 0, 0, 0, time.UTC),
+		time.Date(2019, 05, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2019, 06, 01, 9, 0, 0, 0, time.UTC),
+		time.Date(2019, 06, 03, 9, 0, 0, 0, time.UTC),
+	}
+	after := time.Date(2019, 05, 30, 8, 0, 0, 0, time.UTC)
+	for _, next := range expected {
+		after = schedule.Next(after)
+		assert.Equal(next, after)
+	}
+
+	// the schedules coincide on monday 2019-07-01, which should only fire once.
+	after = time.Date(2019, 06, 28, 9, 0, 0, 0, time.UTC)
+	assert.Equal(time.Date(2019, 07, 01, 9, 0, 0, 0, time.UTC), schedule.Next(after))
+	assert.Equal(time.Date(2019, 07, 02, 9, 0, 0, 0, time.UTC), schedule.Next(time.Date(2019, 07, 01, 9, 0, 0, 0, time.UTC)))
+}
+
+func TestUnionScheduleZero(t *testing.T) {
+	assert := assert.New(t)
+
+	ts := time.Date(2020, 01, 23, 12, 11, 10, 9, time.UTC)
+	schedule := Union(Times(1, Every(time.Minute)), Times(2, Every(time.Hour)), nil)
+	assert.Equal(ts.Add(time.Minute), schedule.Next(ts))
+	assert.Equal(ts.Add(time.Hour), schedule.Next(ts))
+	assert.True(schedule.Next(ts).IsZero())
+	assert.True(Union().Next(ts).IsZero())
+}
+
+func TestUnionScheduleString(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("union of (every 1m0s, every 1h0m0s)", Union(Every(time.Minute), Every(time.Hour)).String())
+}
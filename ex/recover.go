@@ -0,0 +1,33 @@
+package ex
+
+import "fmt"
+
+// Recover converts a value returned by `recover()` into an exception.
+//
+// It is meant to be called from a deferred function:
+//
+//	defer func() {
+//		if err := ex.Recover(recover()); err != nil {
+//			// handle the err
+//		}
+//	}()
+//
+// Errors and strings are used as the exception class directly, exceptions
+// are returned as is (preserving their original stack), and any other value
+// is formatted as the class with its type recorded in the message.
+// The stack trace is captured from the deferred function, and as a result
+// will include the frames that caused the panic.
+// It returns nil if the recovered value is nil.
+func Recover(r interface{}, options ...Option) error {
+	if r == nil {
+		return nil
+	}
+	switch r.(type) {
+	case *Ex, error, string:
+		return NewWithStackDepth(r, DefaultNewStartDepth, options...)
+	default:
+		return NewWithStackDepth(Class(fmt.Sprintf("%v", r)), DefaultNewStartDepth,
+			append([]Option{OptMessagef("recovered panic of type %T", r)}, options...)...,
+		)
+	}
+}
@@ -0,0 +1,76 @@
+package ex
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func recoverPanic(value interface{}) (err error) {
+	defer func() {
+		err = Recover(recover())
+	}()
+	panic(value)
+}
+
+func TestRecoverString(t *testing.T) {
+	assert := assert.New(t)
+
+	err := recoverPanic("this is only a test")
+	assert.NotNil(err)
+	typed := As(err)
+	assert.NotNil(typed)
+	assert.Equal(Class("this is only a test"), typed.Class)
+	assert.Empty(typed.Message)
+	assert.NotEmpty(typed.StackTrace)
+	assert.Contains(fmt.Sprintf("%+v", typed), "recoverPanic")
+}
+
+func TestRecoverError(t *testing.T) {
+	assert := assert.New(t)
+
+	inner := errors.New("this is only a test")
+	err := recoverPanic(inner)
+	typed := As(err)
+	assert.NotNil(typed)
+	assert.Equal(inner, typed.Class)
+	assert.True(Is(err, inner))
+	assert.NotEmpty(typed.StackTrace)
+}
+
+func TestRecoverException(t *testing.T) {
+	assert := assert.New(t)
+
+	original := New("this is only a test")
+	err := recoverPanic(original)
+	assert.Equal(original, err, "exceptions should be returned as is")
+}
+
+func TestRecoverStruct(t *testing.T) {
+	assert := assert.New(t)
+
+	type panicValue struct {
+		Code int
+	}
+
+	err := recoverPanic(panicValue{Code: 500})
+	typed := As(err)
+	assert.NotNil(typed)
+	assert.Equal("{500}", typed.Class.Error())
+	assert.Equal("recovered panic of type ex.panicValue", typed.Message)
+	assert.NotEmpty(typed.StackTrace)
+}
+
+func TestRecoverNil(t *testing.T) {
+	assert := assert.New(t)
+	assert.Nil(Recover(nil))
+}
+
+func TestRecoverOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	err := Recover("this is only a test", OptMessage("with a message"))
+	assert.Equal("with a message", ErrMessage(err))
+}
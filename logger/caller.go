@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// loggerPackageDir is the directory of the logger package source, used to skip logger frames when capturing callers.
+var loggerPackageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// Caller is the source location an event was triggered from.
+type Caller struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Function string `json:"function,omitempty"`
+}
+
+// String returns the caller as `dir/file.go:line`.
+func (c Caller) String() string {
+	return fmt.Sprintf("%s:%d", filepath.Join(filepath.Base(filepath.Dir(c.File)), filepath.Base(c.File)), c.Line)
+}
+
+// GetCaller returns the first caller on the stack outside the logger package, skipping a given number of frames.
+func GetCaller(skip int) (caller Caller, ok bool) {
+	pcs := make([]uintptr, 32)
+	count := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:count])
+	for {
+		frame, more := frames.Next()
+		if !isLoggerFrame(frame.File) {
+			return Caller{File: frame.File, Line: frame.Line, Function: frame.Function}, frame.File != ""
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+// isLoggerFrame returns if a file is logger package source, excluding tests.
+func isLoggerFrame(file string) bool {
+	return filepath.Dir(file) == loggerPackageDir && !strings.HasSuffix(file, "_test.go")
+}
+
+type callerKey struct{}
+
+// WithCaller returns a new context with a given caller.
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// GetCallerFromContext gets the caller an event was triggered from off a context.
+func GetCallerFromContext(ctx context.Context) (caller Caller, ok bool) {
+	if raw := ctx.Value(callerKey{}); raw != nil {
+		caller, ok = raw.(Caller)
+	}
+	return
+}
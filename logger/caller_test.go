@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+// callSite returns the file and the line after the line it is called on.
+func callSite() (string, int) {
+	_, file, line, _ := runtime.Caller(1)
+	return file, line + 1
+}
+
+func TestLoggerCaptureCallerText(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := new(bytes.Buffer)
+	log := MustNew(
+		OptAll(),
+		OptOutput(buffer),
+		OptText(OptTextNoColor(), OptTextHideTimestamp()),
+		OptCaptureCaller(true),
+	)
+	defer log.Close()
+
+	file, line := callSite()
+	log.Infof("test %s", "message")
+	assert.Equal(fmt.Sprintf("[info] [logger/caller_test.go:%d] test message\n", line), buffer.String())
+	assert.Equal("caller_test.go", file[len(file)-len("caller_test.go"):])
+
+	buffer.Reset()
+	_, line = callSite()
+	log.WithPath("scope").Error(fmt.Errorf("test error"))
+	assert.Contains(buffer.String(), fmt.Sprintf("[logger/caller_test.go:%d]", line))
+
+	buffer.Reset()
+	_, line = callSite()
+	MaybeInfof(log, "maybe")
+	assert.Contains(buffer.String(), fmt.Sprintf("[logger/caller_test.go:%d]", line), "logger helpers should be skipped")
+}
+
+func TestLoggerCaptureCallerJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := new(bytes.Buffer)
+	log := MustNew(
+		OptAll(),
+		OptOutput(buffer),
+		OptJSON(),
+		OptCaptureCaller(true),
+	)
+	defer log.Close()
+
+	file, line := callSite()
+	log.Info("test message")
+
+	var output struct {
+		Caller Caller `json:"caller"`
+	}
+	assert.Nil(json.Unmarshal(buffer.Bytes(), &output))
+	assert.Equal(file, output.Caller.File)
+	assert.Equal(line, output.Caller.Line)
+	assert.Equal("github.com/blend/go-sdk/logger.TestLoggerCaptureCallerJSON", output.Caller.Function)
+}
+
+func TestLoggerCaptureCallerDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := new(bytes.Buffer)
+	log := MustNew(
+		OptAll(),
+		OptOutput(buffer),
+		OptText(OptTextNoColor(), OptTextHideTimestamp()),
+	)
+	defer log.Close()
+
+	log.Info("test message")
+	assert.Equal("[info] test message\n", buffer.String())
+}
+
+func TestTextOutputFormatterTemplateCaller(t *testing.T) {
+	assert := assert.New(t)
+
+	buffer := new(bytes.Buffer)
+	log := MustNew(
+		OptAll(),
+		OptOutput(buffer),
+		OptText(OptTextNoColor(), OptTextTemplate("{caller} {message}")),
+		OptCaptureCaller(true),
+	)
+	defer log.Close()
+
+	_, line := callSite()
+	log.Info("test message")
+	assert.Equal(fmt.Sprintf("logger/caller_test.go:%d test message\n", line), buffer.String())
+}
@@ -1,13 +1,17 @@
 package logger
 
 import (
+	"io"
+	"reflect"
+	"sync"
+
 	"github.com/blend/go-sdk/ansi"
 )
 
 var (
 	// DefaultFlagTextColors is the default color for each known flag.
 	DefaultFlagTextColors = map[string]ansi.Color{
-		Info:    ansi.ColorLightWhite,
+		Info:    ansi.ColorGreen,
 		Debug:   ansi.ColorLightYellow,
 		Warning: ansi.ColorLightYellow,
 		Error:   ansi.ColorRed,
@@ -25,3 +29,36 @@ func FlagTextColor(flag string) ansi.Color {
 	}
 	return DefaultFlagTextColor
 }
+
+// isTerminal returns if the output, unwrapping interlocked writers, is a terminal.
+func isTerminal(output io.Writer) bool {
+	if typed, ok := output.(*InterlockedWriter); ok {
+		return ansi.IsTerminal(typed.Output)
+	}
+	return ansi.IsTerminal(output)
+}
+
+// terminalCache caches if outputs are terminals, so auto color checks each output once rather than on every write.
+type terminalCache struct {
+	sync.Mutex
+	outputs map[io.Writer]bool
+}
+
+// isTerminal returns if the output is a terminal, checking it only the first time it is seen.
+// Outputs that can't be map keys are checked every time, as are all outputs if the cache is unset.
+func (tc *terminalCache) isTerminal(output io.Writer) bool {
+	if tc == nil || output == nil || !reflect.TypeOf(output).Comparable() {
+		return isTerminal(output)
+	}
+	tc.Lock()
+	defer tc.Unlock()
+	if value, ok := tc.outputs[output]; ok {
+		return value
+	}
+	if tc.outputs == nil {
+		tc.outputs = make(map[io.Writer]bool)
+	}
+	value := isTerminal(output)
+	tc.outputs[output] = value
+	return value
+}
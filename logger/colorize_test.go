@@ -14,7 +14,7 @@ func TestFlagTextColor(t *testing.T) {
 		Flag     string
 		Expected ansi.Color
 	}{
-		{Info, ansi.ColorLightWhite},
+		{Info, ansi.ColorGreen},
 		{Debug, ansi.ColorLightYellow},
 		{Warning, ansi.ColorLightYellow},
 		{Error, ansi.ColorRed},
@@ -13,6 +13,8 @@ type Config struct {
 	Format string     `json:"format,omitempty" yaml:"format,omitempty" env:"LOG_FORMAT"`
 	Text   TextConfig `json:"text,omitempty" yaml:"text,omitempty"`
 	JSON   JSONConfig `json:"json,omitempty" yaml:"json,omitempty"`
+	// CaptureCaller sets if the file and line events are triggered from should be captured.
+	CaptureCaller bool `json:"captureCaller,omitempty" yaml:"captureCaller,omitempty" env:"LOG_CAPTURE_CALLER"`
 }
 
 // Resolve resolves the config.
@@ -53,7 +55,12 @@ type TextConfig struct {
 	HideTimestamp bool   `json:"hideTimestamp,omitempty" yaml:"hideTimestamp,omitempty" env:"LOG_HIDE_TIMESTAMP"`
 	HideFields    bool   `json:"hideFields,omitempty" yaml:"hideFields,omitempty" env:"LOG_HIDE_FIELDS"`
 	NoColor       bool   `json:"noColor,omitempty" yaml:"noColor,omitempty" env:"NO_COLOR"`
+	AutoColor     bool   `json:"autoColor,omitempty" yaml:"autoColor,omitempty" env:"LOG_AUTO_COLOR"`
 	TimeFormat    string `json:"timeFormat,omitempty" yaml:"timeFormat,omitempty" env:"LOG_TIME_FORMAT"`
+	// Template is a layout for text output, e.g. `{timestamp} [{flag}] {message}`.
+	Template string `json:"template,omitempty" yaml:"template,omitempty" env:"LOG_TEXT_TEMPLATE"`
+	// TemplateStrict makes unknown template tokens error rather than render literally.
+	TemplateStrict bool `json:"templateStrict,omitempty" yaml:"templateStrict,omitempty" env:"LOG_TEXT_TEMPLATE_STRICT"`
 }
 
 // TimeFormatOrDefault returns a field value or a default.
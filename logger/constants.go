@@ -31,10 +31,13 @@ var (
 // Environment Variable Names
 const (
 	EnvVarFlags      = "LOG_FLAGS"
+	EnvVarLevel      = "LOG_LEVEL"
 	EnvVarFormat     = "LOG_FORMAT"
 	EnvVarNoColor    = "NO_COLOR"
+	EnvVarAutoColor  = "LOG_AUTO_COLOR"
 	EnvVarHideTime   = "LOG_HIDE_TIME"
 	EnvVarTimeFormat = "LOG_TIME_FORMAT"
+	EnvVarTemplate   = "LOG_TEXT_TEMPLATE"
 	EnvVarJSONPretty = "LOG_JSON_PRETTY"
 )
 
@@ -72,6 +75,7 @@ const (
 	FieldElapsed     = "elapsed"
 	FieldLabels      = "labels"
 	FieldAnnotations = "annotations"
+	FieldCaller      = "caller"
 )
 
 // JSON Formatter defaults
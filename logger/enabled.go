@@ -0,0 +1,31 @@
+package logger
+
+import "sync/atomic"
+
+var disabled int32
+
+// SetEnabled globally enables or disables logging for every logger.
+//
+// While logging is disabled, triggers are no-ops, and the builtin handlers like `Infof`
+// return before formatting their message or building an event, so benchmarks and hot paths
+// can disable logging entirely with minimal overhead. Logging is enabled by default.
+func SetEnabled(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&disabled, 0)
+		return
+	}
+	atomic.StoreInt32(&disabled, 1)
+}
+
+// Enabled returns if logging is globally enabled; see `SetEnabled`.
+func Enabled() bool {
+	return atomic.LoadInt32(&disabled) == 0
+}
+
+// ShouldTrigger returns if an event with a given flag would be triggered, that is if logging
+// is globally enabled and the flag is enabled on the logger.
+//
+// It is meant to be checked before building events that are expensive to construct.
+func (l *Logger) ShouldTrigger(flag string) bool {
+	return Enabled() && l.IsEnabled(flag)
+}
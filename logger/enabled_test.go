@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestSetEnabled(t *testing.T) {
+	assert := assert.New(t)
+	defer SetEnabled(true)
+
+	output := new(bytes.Buffer)
+	log := MustNew(OptAll(), OptOutput(output))
+	defer log.Close()
+
+	listened := make(chan Event, 16)
+	log.Listen(Info, "test", func(_ context.Context, e Event) { listened <- e })
+
+	assert.True(Enabled())
+	assert.True(log.ShouldTrigger(Info))
+
+	SetEnabled(false)
+	assert.False(Enabled())
+	assert.False(log.ShouldTrigger(Info))
+
+	log.Info("info")
+	log.Infof("infof %s", "value")
+	log.Debugf("debugf %s", "value")
+	log.Warningf("warningf %s", "value")
+	log.Errorf("errorf %s", "value")
+	assert.Equal("error", log.Error(fmt.Errorf("error")).Error())
+	log.Timed(Info, "timed")()
+	log.Trigger(context.Background(), NewMessageEvent(Info, "trigger"))
+	log.WithPath("scope").Infof("scoped %s", "value")
+
+	assert.Empty(output.String())
+
+	SetEnabled(true)
+	log.Infof("infof %s", "value")
+	assert.Contains(output.String(), "infof value")
+	e := <-listened
+	assert.Equal("infof value", e.(MessageEvent).Text, "only the event triggered while enabled should be listened to")
+}
+
+func TestSetEnabledAllocs(t *testing.T) {
+	assert := assert.New(t)
+	defer SetEnabled(true)
+
+	log := MustNew(OptAll(), OptOutput(new(bytes.Buffer)))
+	defer log.Close()
+
+	SetEnabled(false)
+	allocs := testing.AllocsPerRun(100, func() {
+		log.Infof("hello %s", "world")
+		log.Debug("hello")
+		log.Errorf("hello %s", "world")
+	})
+	assert.Zero(allocs)
+
+	SetEnabled(true)
+	log.Disable(Debug)
+	allocs = testing.AllocsPerRun(100, func() {
+		log.Debugf("hello %s", "world")
+	})
+	assert.Zero(allocs, "disabled flags should not build events")
+}
+
+func BenchmarkLoggerInfofDisabled(b *testing.B) {
+	defer SetEnabled(true)
+	log := MustNew(OptAll(), OptOutput(new(bytes.Buffer)))
+	defer log.Close()
+
+	SetEnabled(false)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for x := 0; x < b.N; x++ {
+		log.Infof("hello %s", "world")
+	}
+}
+
+func BenchmarkLoggerInfofEnabled(b *testing.B) {
+	log := MustNew(OptAll(), OptOutput(new(bytes.Buffer)))
+	defer log.Close()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for x := 0; x < b.N; x++ {
+		log.Infof("hello %s", "world")
+	}
+}
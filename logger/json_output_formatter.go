@@ -130,5 +130,8 @@ func (jw JSONOutputFormatter) GetScopeFields(ctx context.Context, e Event) map[s
 	if annotations := GetAnnotations(ctx); len(annotations) > 0 {
 		output[FieldAnnotations] = annotations
 	}
+	if caller, ok := GetCallerFromContext(ctx); ok {
+		output[FieldCaller] = caller
+	}
 	return output
 }
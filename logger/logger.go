@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"sync"
+	"sync/atomic"
 )
 
 // New returns a new logger with a given set of enabled flags.
@@ -70,11 +71,61 @@ type Logger struct {
 	Scope
 
 	RecoverPanics bool
+	// CaptureCaller, if set, captures the file and line events are triggered from; see `OptCaptureCaller`.
+	CaptureCaller bool
 
 	Output    io.Writer
 	Formatter WriteFormatter
 	Errors    chan error
 	Listeners map[string]map[string]*Worker
+
+	// WriteWorker, if set, queues writes to the output to be processed in the background.
+	WriteWorker *Worker
+	// WriteDropWhenFull drops writes if the write worker queue is full, rather than blocking.
+	WriteDropWhenFull bool
+
+	writesDropped int64
+	// writeWorkerLock guards enqueueing writes against the write worker being stopped on close.
+	writeWorkerLock    sync.RWMutex
+	writeWorkerStopped bool
+
+	outputsLock sync.RWMutex
+	outputs     map[string]io.Writer
+}
+
+// WithOutputFor routes events for a given flag to a given writer rather than the default output.
+// Events for flags without a routed writer are written to the default output.
+// Passing a nil writer removes the routing for the flag.
+func (l *Logger) WithOutputFor(flag string, w io.Writer) {
+	l.outputsLock.Lock()
+	defer l.outputsLock.Unlock()
+
+	if w == nil {
+		delete(l.outputs, flag)
+		return
+	}
+	if l.outputs == nil {
+		l.outputs = make(map[string]io.Writer)
+	}
+	l.outputs[flag] = NewInterlockedWriter(w)
+}
+
+// OutputFor returns the writer events for a given flag are written to.
+func (l *Logger) OutputFor(flag string) io.Writer {
+	l.outputsLock.RLock()
+	defer l.outputsLock.RUnlock()
+
+	if output, ok := l.outputs[flag]; ok {
+		return output
+	}
+	return l.Output
+}
+
+// hasOutputs returns if there are any routed outputs.
+func (l *Logger) hasOutputs() bool {
+	l.outputsLock.RLock()
+	defer l.outputsLock.RUnlock()
+	return len(l.outputs) > 0
 }
 
 // HasListeners returns if there are registered listener for an event.
@@ -108,6 +159,17 @@ func (l *Logger) HasListener(flag, listenerName string) bool {
 	return ok
 }
 
+// ListenerCount returns the number of listeners registered for a flag.
+func (l *Logger) ListenerCount(flag string) int {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.Listeners == nil {
+		return 0
+	}
+	return len(l.Listeners[flag])
+}
+
 // Listen adds a listener for a given flag.
 func (l *Logger) Listen(flag, listenerName string, listener Listener) {
 	l.Lock()
@@ -182,7 +244,7 @@ func (l *Logger) RemoveListener(flag, listenerName string) error {
 // There are no order guarantees on when these events will be processed across listeners.
 // This call will not block on the event listeners, but will block on the write.
 func (l *Logger) Trigger(ctx context.Context, e Event) {
-	if e == nil {
+	if e == nil || !Enabled() {
 		return
 	}
 
@@ -191,6 +253,14 @@ func (l *Logger) Trigger(ctx context.Context, e Event) {
 		return
 	}
 
+	if l.CaptureCaller {
+		if _, ok := GetCallerFromContext(ctx); !ok {
+			if caller, ok := GetCaller(0); ok {
+				ctx = WithCaller(ctx, caller)
+			}
+		}
+	}
+
 	if !IsSkipTrigger(ctx) {
 		var listeners map[string]*Worker
 		l.Lock()
@@ -207,10 +277,12 @@ func (l *Logger) Trigger(ctx context.Context, e Event) {
 	l.Write(ctx, e)
 }
 
-// Write writes an event synchronously to the writer either as a normal even or as an error.
+// Write writes an event to the writer either as a normal even or as an error.
+// The write is synchronous unless writes are queued with `OptWriteAsync`,
+// and writes after the logger is closed fall back to being synchronous.
 func (l *Logger) Write(ctx context.Context, e Event) {
 	// if a formater or the output are unset, bail.
-	if l.Formatter == nil || l.Output == nil {
+	if l.Formatter == nil || (l.Output == nil && !l.hasOutputs()) {
 		return
 	}
 
@@ -218,7 +290,45 @@ func (l *Logger) Write(ctx context.Context, e Event) {
 		return
 	}
 
-	err := l.Formatter.WriteFormat(ctx, l.Output, e)
+	if l.WriteWorker != nil && l.enqueueWrite(ctx, e) {
+		return
+	}
+	l.write(ctx, e)
+}
+
+// WritesDropped returns the number of writes dropped because the write worker queue was full.
+func (l *Logger) WritesDropped() int64 {
+	return atomic.LoadInt64(&l.writesDropped)
+}
+
+// enqueueWrite queues an event for the write worker, dropping
+// it if the queue is full and the logger is set to drop writes.
+// It returns false if the write worker was stopped by closing the logger.
+func (l *Logger) enqueueWrite(ctx context.Context, e Event) bool {
+	l.writeWorkerLock.RLock()
+	defer l.writeWorkerLock.RUnlock()
+	if l.writeWorkerStopped {
+		return false
+	}
+	if !l.WriteDropWhenFull {
+		l.WriteWorker.Work <- EventWithContext{ctx, e}
+		return true
+	}
+	select {
+	case l.WriteWorker.Work <- EventWithContext{ctx, e}:
+	default:
+		atomic.AddInt64(&l.writesDropped, 1)
+	}
+	return true
+}
+
+// write writes an event synchronously to the output for its flag.
+func (l *Logger) write(ctx context.Context, e Event) {
+	output := l.OutputFor(e.GetFlag())
+	if output == nil {
+		return
+	}
+	err := l.Formatter.WriteFormat(ctx, output, e)
 	if err != nil && l.Errors != nil {
 		l.Errors <- err
 	}
@@ -251,11 +361,23 @@ func (l *Logger) Close() error {
 		delete(l.Listeners, key)
 	}
 	l.Listeners = nil
+
+	// flush any queued writes; later writes are written synchronously.
+	if l.WriteWorker != nil {
+		l.writeWorkerLock.Lock()
+		l.writeWorkerStopped = true
+		l.writeWorkerLock.Unlock()
+	}
+	if l.WriteWorker != nil && l.WriteWorker.IsStarted() {
+		if err = l.WriteWorker.Stop(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// Drain stops the event listeners, letting them complete their work
-// and then restarts the listeners.
+// Drain stops the event listeners and the write worker, letting them complete their work
+// and then restarts them.
 func (l *Logger) Drain() error {
 	return l.DrainContext(context.Background())
 }
@@ -271,5 +393,11 @@ func (l *Logger) DrainContext(ctx context.Context) error {
 			go worker.Start()
 		}
 	}
+	if l.WriteWorker != nil {
+		if err = l.WriteWorker.StopContext(ctx); err != nil {
+			return err
+		}
+		go l.WriteWorker.Start()
+	}
 	return nil
 }
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -179,6 +181,36 @@ func TestLoggerListeners(t *testing.T) {
 	assert.False(log.HasListener(Error, "bar"))
 }
 
+func TestLoggerListenerCount(t *testing.T) {
+	assert := assert.New(t)
+
+	log := MustNew(OptAll(), OptOutput(new(bytes.Buffer)))
+	defer log.Close()
+
+	assert.Zero(log.ListenerCount(Info))
+
+	foo := make(chan MessageEvent, 2)
+	bar := make(chan MessageEvent, 2)
+	log.Listen(Info, "foo", NewMessageEventListener(func(_ context.Context, me MessageEvent) { foo <- me }))
+	log.Listen(Info, "bar", NewMessageEventListener(func(_ context.Context, me MessageEvent) { bar <- me }))
+	assert.Equal(2, log.ListenerCount(Info))
+	assert.Zero(log.ListenerCount(Error))
+
+	log.Info("before")
+	assert.Equal("before", (<-foo).Text)
+	assert.Equal("before", (<-bar).Text)
+
+	assert.Nil(log.RemoveListener(Info, "foo"))
+	assert.Equal(1, log.ListenerCount(Info))
+
+	log.Info("after")
+	assert.Equal("after", (<-bar).Text)
+	assert.Empty(foo)
+
+	assert.Nil(log.RemoveListener(Info, "bar"))
+	assert.Zero(log.ListenerCount(Info))
+}
+
 func TestLoggerProd(t *testing.T) {
 	assert := assert.New(t)
 
@@ -188,3 +220,178 @@ func TestLoggerProd(t *testing.T) {
 	assert.True(p.Flags.IsEnabled("bailey"))
 	assert.True(p.Formatter.(*TextOutputFormatter).NoColor)
 }
+
+func TestLoggerWriteAsync(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	release := make(chan struct{})
+	output := mockWriter{
+		WriteHandler: func(data []byte) (int, error) {
+			<-release
+			return buf.Write(data)
+		},
+	}
+
+	log := MustNew(
+		OptAll(),
+		OptOutput(output),
+		OptText(OptTextHideTimestamp(), OptTextNoColor()),
+		OptWriteAsync(16),
+	)
+
+	// triggering should not block on the (blocked) output.
+	for x := 0; x < 8; x++ {
+		log.Infof("event %d", x)
+	}
+	assert.Empty(buf.String())
+
+	close(release)
+	assert.Nil(log.Close())
+	assert.Equal(8, strings.Count(buf.String(), "[info]"))
+	assert.True(strings.HasPrefix(buf.String(), "[info] event 0\n"))
+	assert.True(strings.HasSuffix(buf.String(), "[info] event 7\n"))
+}
+
+func TestLoggerWriteAsyncDrain(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	log := MustNew(
+		OptAll(),
+		OptOutput(buf),
+		OptText(OptTextHideTimestamp(), OptTextNoColor()),
+		OptWriteAsync(0),
+	)
+	assert.Equal(DefaultWorkerQueueDepth, cap(log.WriteWorker.Work))
+
+	log.Info("before drain")
+	assert.Nil(log.Drain())
+	assert.Equal("[info] before drain\n", buf.String())
+}
+
+func TestLoggerWriteAsyncDropWhenFull(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	output := mockWriter{
+		WriteHandler: func(data []byte) (int, error) {
+			select {
+			case entered <- struct{}{}:
+			default:
+			}
+			<-release
+			return buf.Write(data)
+		},
+	}
+
+	log := MustNew(
+		OptAll(),
+		OptOutput(output),
+		OptText(OptTextHideTimestamp(), OptTextNoColor()),
+		OptWriteAsync(1),
+		OptWriteDropWhenFull(true),
+	)
+
+	log.Info("first") // picked up by the worker, blocked on the output
+	<-entered
+	log.Info("second") // fills the queue
+	log.Info("third")  // dropped
+	assert.Equal(1, log.WritesDropped())
+
+	close(release)
+	assert.Nil(log.Close())
+	assert.Equal("[info] first\n[info] second\n", buf.String())
+}
+
+func TestLoggerWriteAsyncAfterClose(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	log := MustNew(
+		OptAll(),
+		OptOutput(buf),
+		OptText(OptTextHideTimestamp(), OptTextNoColor()),
+		OptWriteAsync(1),
+	)
+	log.Info("before close")
+	assert.Nil(log.Close())
+
+	// more writes than the queue depth would block if they were still queued.
+	for x := 0; x < 4; x++ {
+		log.Write(context.Background(), NewMessageEvent(Info, fmt.Sprintf("after close %d", x)))
+	}
+	assert.Equal("[info] before close\n[info] after close 0\n[info] after close 1\n[info] after close 2\n[info] after close 3\n", buf.String())
+}
+
+func TestLoggerWithOutputFor(t *testing.T) {
+	assert := assert.New(t)
+
+	output, errors, audit := new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)
+	log := MustNew(
+		OptAll(),
+		OptOutput(output),
+		OptText(OptTextHideTimestamp(), OptTextNoColor()),
+		OptOutputFor(Error, errors),
+	)
+	log.WithOutputFor(Fatal, errors)
+	log.WithOutputFor("audit", audit)
+
+	log.Infof("info event")
+	log.Errorf("error event")
+	log.Fatalf("fatal event")
+	log.Trigger(context.Background(), NewMessageEvent("audit", "audit event"))
+
+	assert.Equal("[info] info event\n", output.String())
+	assert.Equal("[error] error event\n[fatal] fatal event\n", errors.String())
+	assert.Equal("[audit] audit event\n", audit.String())
+
+	log.WithOutputFor(Error, nil)
+	log.Errorf("another error event")
+	assert.Equal("[info] info event\n[error] another error event\n", output.String())
+}
+
+func TestLoggerWithOutputForWithoutDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	errors := new(bytes.Buffer)
+	log := MustNew(
+		OptAll(),
+		OptOutput(nil),
+		OptText(OptTextHideTimestamp(), OptTextNoColor()),
+		OptOutputFor(Error, errors),
+	)
+	log.Infof("info event")
+	log.Errorf("error event")
+	assert.Equal("[error] error event\n", errors.String())
+}
+
+func TestLoggerWithOutputForConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	output, errors := new(bytes.Buffer), new(bytes.Buffer)
+	log := MustNew(
+		OptAll(),
+		OptOutput(output),
+		OptText(OptTextHideTimestamp(), OptTextNoColor()),
+	)
+
+	var wg sync.WaitGroup
+	for x := 0; x < 8; x++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			log.WithOutputFor(Error, errors)
+		}()
+		go func(index int) {
+			defer wg.Done()
+			log.Infof("event %d", index)
+		}(x)
+	}
+	wg.Wait()
+	log.Errorf("error event")
+	assert.Equal(8, strings.Count(output.String(), "[info]"))
+	assert.Equal("[error] error event\n", errors.String())
+}
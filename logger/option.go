@@ -14,6 +14,7 @@ func OptConfig(cfg Config) Option {
 	return func(l *Logger) error {
 		l.Formatter = cfg.Formatter()
 		l.Flags = NewFlags(cfg.FlagsOrDefault()...)
+		l.CaptureCaller = cfg.CaptureCaller
 		return nil
 	}
 }
@@ -28,6 +29,21 @@ func OptConfigFromEnv() Option {
 		}
 		l.Formatter = cfg.Formatter()
 		l.Flags = NewFlags(cfg.FlagsOrDefault()...)
+		l.CaptureCaller = cfg.CaptureCaller
+		return nil
+	}
+}
+
+// OptFlagsFromEnv sets the logger flags from the `LOG_FLAGS` or `LOG_LEVEL` environment variables.
+// Flags other than the built in flags must be given as known flags, e.g. `webutil.FlagHTTPRequest`,
+// and the option returns an error if the expression has an unknown flag.
+func OptFlagsFromEnv(knownFlags ...string) Option {
+	return func(l *Logger) error {
+		flags, err := FlagsFromEnv(knownFlags...)
+		if err != nil {
+			return err
+		}
+		l.Flags = flags
 		return nil
 	}
 }
@@ -55,6 +71,14 @@ func OptOutput(output io.Writer) Option {
 	}
 }
 
+// OptOutputFor routes events for a given flag to a given writer rather than the default output.
+func OptOutputFor(flag string, output io.Writer) Option {
+	return func(l *Logger) error {
+		l.WithOutputFor(flag, output)
+		return nil
+	}
+}
+
 // OptPath sets an initial logger context path.
 // This is useful if you want to label a logger to differentiate multiple loggers.
 func OptPath(path ...string) Option {
@@ -106,3 +130,34 @@ func OptEnabled(flags ...string) Option {
 func OptDisabled(flags ...string) Option {
 	return func(l *Logger) error { l.Flags.Disable(flags...); return nil }
 }
+
+// OptWriteAsync queues writes to the output with a given queue depth, to be
+// processed by a background worker so that triggering events does not block on the output.
+// If the queue depth is zero or less, `DefaultWorkerQueueDepth` is used.
+//
+// Pending writes are flushed when the logger is drained or closed.
+func OptWriteAsync(queueDepth int) Option {
+	return func(l *Logger) error {
+		if queueDepth <= 0 {
+			queueDepth = DefaultWorkerQueueDepth
+		}
+		l.WriteWorker = NewWorker(l.write)
+		l.WriteWorker.Work = make(chan EventWithContext, queueDepth)
+		go l.WriteWorker.Start()
+		<-l.WriteWorker.NotifyStarted()
+		return nil
+	}
+}
+
+// OptCaptureCaller sets if the file and line events are triggered from should be captured.
+// The caller is the first frame outside the logger package, and is written by the text and json formatters.
+//
+// Capturing the caller walks the stack for each enabled event, so it is disabled by default.
+func OptCaptureCaller(captureCaller bool) Option {
+	return func(l *Logger) error { l.CaptureCaller = captureCaller; return nil }
+}
+
+// OptWriteDropWhenFull sets if async writes should be dropped if the queue is full, rather than blocking.
+func OptWriteDropWhenFull(dropWhenFull bool) Option {
+	return func(l *Logger) error { l.WriteDropWhenFull = dropWhenFull; return nil }
+}
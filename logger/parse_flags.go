@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"strings"
+
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+)
+
+// ErrFlagUnknown is returned when parsing a flags expression with a flag that is not known.
+const ErrFlagUnknown ex.Class = "logger; flags expression has an unknown flag"
+
+// BuiltinFlags are the flags defined by the logger package,
+// they are always known when parsing a flags expression.
+var BuiltinFlags = []string{Fatal, Error, Warning, Info, Debug, Audit}
+
+// ParseFlags parses a csv flags expression, e.g. `all,-debug` or `error,fatal`, into a flag set.
+//
+// The expression follows the rules of `NewFlags`, but each flag must be one of the
+// built in flags, one of the given known flags, or the special `all` and `none` values,
+// otherwise an `ErrFlagUnknown` exception is returned naming the flag.
+func ParseFlags(expression string, knownFlags ...string) (*Flags, error) {
+	known := make(map[string]bool)
+	for _, flag := range append(BuiltinFlags, knownFlags...) {
+		known[strings.ToLower(strings.TrimSpace(flag))] = true
+	}
+
+	var flags []string
+	for _, rawFlag := range strings.Split(expression, ",") {
+		flag := strings.ToLower(strings.TrimSpace(rawFlag))
+		if flag == "" {
+			continue
+		}
+		if name := strings.TrimPrefix(flag, "-"); name != FlagAll && name != FlagNone && !known[name] {
+			return nil, ex.New(ErrFlagUnknown, ex.OptMessagef("flag: %s, expression: %s", name, expression))
+		}
+		flags = append(flags, flag)
+	}
+	return NewFlags(flags...), nil
+}
+
+// FlagsFromEnv parses the flags expression set by the `LOG_FLAGS` environment variable,
+// falling back to the `LOG_LEVEL` environment variable, with a given set of known flags.
+//
+// If neither is set, the default flags are returned.
+func FlagsFromEnv(knownFlags ...string) (*Flags, error) {
+	expression := env.Env().String(EnvVarFlags)
+	if expression == "" {
+		expression = env.Env().String(EnvVarLevel)
+	}
+	if expression == "" {
+		return NewFlags(DefaultFlags...), nil
+	}
+	return ParseFlags(expression, knownFlags...)
+}
@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestParseFlags(t *testing.T) {
+	assert := assert.New(t)
+
+	flags, err := ParseFlags("all")
+	assert.Nil(err)
+	assert.True(flags.All())
+	assert.True(flags.IsEnabled(Debug))
+
+	flags, err = ParseFlags(" Error, fatal ,")
+	assert.Nil(err)
+	assert.True(flags.IsEnabled(Error))
+	assert.True(flags.IsEnabled(Fatal))
+	assert.False(flags.IsEnabled(Info))
+
+	flags, err = ParseFlags("all,-http.request,-debug", "http.request")
+	assert.Nil(err)
+	assert.True(flags.IsEnabled(Info))
+	assert.True(flags.IsEnabled("cron.complete"))
+	assert.False(flags.IsEnabled("http.request"))
+	assert.False(flags.IsEnabled(Debug))
+
+	flags, err = ParseFlags("none")
+	assert.Nil(err)
+	assert.True(flags.None())
+	assert.False(flags.IsEnabled(Error))
+
+	_, err = ParseFlags("error,-http.request")
+	assert.True(ex.Is(err, ErrFlagUnknown))
+	assert.Contains(ex.ErrMessage(err), "flag: http.request")
+
+	_, err = ParseFlags("error,infoo", "http.request")
+	assert.True(ex.Is(err, ErrFlagUnknown))
+	assert.Contains(ex.ErrMessage(err), "flag: infoo")
+}
+
+func TestFlagsFromEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	defer env.Restore()
+	env.SetEnv(env.New())
+
+	flags, err := FlagsFromEnv()
+	assert.Nil(err)
+	assert.True(flags.IsEnabled(Info))
+	assert.False(flags.IsEnabled(Debug))
+
+	env.Env().Set(EnvVarLevel, "error,fatal")
+	flags, err = FlagsFromEnv()
+	assert.Nil(err)
+	assert.True(flags.IsEnabled(Error))
+	assert.False(flags.IsEnabled(Info))
+
+	env.Env().Set(EnvVarFlags, "all,-http.request")
+	flags, err = FlagsFromEnv("http.request")
+	assert.Nil(err)
+	assert.True(flags.IsEnabled(Info))
+	assert.False(flags.IsEnabled("http.request"))
+
+	_, err = FlagsFromEnv()
+	assert.True(ex.Is(err, ErrFlagUnknown))
+
+	_, err = New(OptFlagsFromEnv())
+	assert.True(ex.Is(err, ErrFlagUnknown))
+	log, err := New(OptFlagsFromEnv("http.request"), OptOutput(nil))
+	assert.Nil(err)
+	assert.False(log.IsEnabled("http.request"))
+}
@@ -0,0 +1,291 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+var (
+	_ io.WriteCloser = (*RotatingFileWriter)(nil)
+)
+
+// RotatingFileWriter defaults.
+const (
+	DefaultRotatingFileWriterMaxFiles   = 10
+	DefaultRotatingFileWriterTimeFormat = "20060102T150405.000000000Z"
+	DefaultRotatingFileWriterFileMode   = 0644
+)
+
+// NewRotatingFileWriter returns a new rotating file writer for a given path.
+//
+// The file at the path is opened for appending (and created if it does not exist),
+// and is rolled over according to the options.
+func NewRotatingFileWriter(path string, options ...RotatingFileWriterOption) (*RotatingFileWriter, error) {
+	rfw := &RotatingFileWriter{
+		Path:     path,
+		MaxFiles: DefaultRotatingFileWriterMaxFiles,
+		Now:      func() time.Time { return time.Now().UTC() },
+	}
+	for _, option := range options {
+		option(rfw)
+	}
+	if err := rfw.open(); err != nil {
+		return nil, err
+	}
+	return rfw, nil
+}
+
+// RotatingFileWriterOption mutates a rotating file writer.
+type RotatingFileWriterOption func(*RotatingFileWriter)
+
+// OptRotatingFileWriterMaxBytes sets the size threshold in bytes past which the current file is rolled over.
+func OptRotatingFileWriterMaxBytes(maxBytes int64) RotatingFileWriterOption {
+	return func(rfw *RotatingFileWriter) { rfw.MaxBytes = maxBytes }
+}
+
+// OptRotatingFileWriterInterval sets the time boundary at which the current file is rolled over.
+func OptRotatingFileWriterInterval(interval time.Duration) RotatingFileWriterOption {
+	return func(rfw *RotatingFileWriter) { rfw.Interval = interval }
+}
+
+// OptRotatingFileWriterMaxFiles sets the maximum number of historical files to keep.
+func OptRotatingFileWriterMaxFiles(maxFiles int) RotatingFileWriterOption {
+	return func(rfw *RotatingFileWriter) { rfw.MaxFiles = maxFiles }
+}
+
+// OptRotatingFileWriterCompress sets if historical files should be gzipped.
+func OptRotatingFileWriterCompress(compress bool) RotatingFileWriterOption {
+	return func(rfw *RotatingFileWriter) { rfw.Compress = compress }
+}
+
+// RotatingFileWriter is a writer that writes to a file, rolling it over
+// when it exceeds a size or crosses a time boundary.
+//
+// Historical files are named `<path>.<timestamp>` (with a `.gz` suffix if compressed),
+// and only the most recent `MaxFiles` are kept.
+type RotatingFileWriter struct {
+	sync.Mutex
+
+	// Path is the path of the current file.
+	Path string
+	// MaxBytes is the size threshold for rolling over, zero disables size based rotation.
+	MaxBytes int64
+	// Interval is the time boundary for rolling over, zero disables time based rotation.
+	Interval time.Duration
+	// MaxFiles is the number of historical files to keep, zero or less keeps all files.
+	MaxFiles int
+	// Compress determines if historical files are gzipped.
+	Compress bool
+	// Now returns the current time, it is used to determine time boundaries.
+	Now func() time.Time
+
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// Write implements io.Writer.
+//
+// The current file is rolled over before the write if the write
+// would exceed the size threshold or a time boundary has been crossed.
+func (rfw *RotatingFileWriter) Write(contents []byte) (count int, err error) {
+	rfw.Lock()
+	defer rfw.Unlock()
+
+	if rfw.file == nil {
+		err = ex.New(os.ErrClosed, ex.OptMessagef("path: %s", rfw.Path))
+		return
+	}
+	if rfw.shouldRotate(int64(len(contents))) {
+		if err = rfw.rotate(); err != nil {
+			return
+		}
+	}
+	count, err = rfw.file.Write(contents)
+	rfw.size += int64(count)
+	if err != nil {
+		err = ex.New(err)
+	}
+	return
+}
+
+// Rotate forces the current file to be rolled over.
+func (rfw *RotatingFileWriter) Rotate() error {
+	rfw.Lock()
+	defer rfw.Unlock()
+	return rfw.rotate()
+}
+
+// Close closes the current file.
+func (rfw *RotatingFileWriter) Close() error {
+	rfw.Lock()
+	defer rfw.Unlock()
+
+	if rfw.file == nil {
+		return nil
+	}
+	err := rfw.file.Close()
+	rfw.file = nil
+	if err != nil {
+		return ex.New(err)
+	}
+	return nil
+}
+
+// HistoricalFiles returns the paths of the historical files, oldest first.
+//
+// Only files named `<path>.<timestamp>` or `<path>.<timestamp>.gz` are returned,
+// so unrelated files next to the current file are left alone.
+func (rfw *RotatingFileWriter) HistoricalFiles() ([]string, error) {
+	dir, base := filepath.Dir(rfw.Path), filepath.Base(rfw.Path)
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, ex.New(err)
+	}
+	var matches []string
+	for _, entry := range entries {
+		if entry.IsDir() || !isHistoricalFile(base, entry.Name()) {
+			continue
+		}
+		matches = append(matches, filepath.Join(dir, entry.Name()))
+	}
+	// the timestamp format sorts lexically in time order;
+	// strip the compression suffix so compressed and uncompressed files interleave correctly.
+	sort.Slice(matches, func(i, j int) bool {
+		return strings.TrimSuffix(matches[i], ".gz") < strings.TrimSuffix(matches[j], ".gz")
+	})
+	return matches, nil
+}
+
+// isHistoricalFile returns if a file name is a historical file for a given current file name,
+// i.e. the name followed by a rotation timestamp and an optional `.gz` suffix.
+func isHistoricalFile(base, name string) bool {
+	if !strings.HasPrefix(name, base+".") {
+		return false
+	}
+	timestamp := strings.TrimSuffix(strings.TrimPrefix(name, base+"."), ".gz")
+	_, err := time.Parse(DefaultRotatingFileWriterTimeFormat, timestamp)
+	return err == nil
+}
+
+//
+// internal helpers; these assume the lock is held.
+//
+
+func (rfw *RotatingFileWriter) now() time.Time {
+	if rfw.Now != nil {
+		return rfw.Now()
+	}
+	return time.Now().UTC()
+}
+
+func (rfw *RotatingFileWriter) shouldRotate(incoming int64) bool {
+	if rfw.MaxBytes > 0 && rfw.size > 0 && rfw.size+incoming > rfw.MaxBytes {
+		return true
+	}
+	if rfw.Interval > 0 && !rfw.now().Truncate(rfw.Interval).Equal(rfw.opened.Truncate(rfw.Interval)) {
+		return true
+	}
+	return false
+}
+
+func (rfw *RotatingFileWriter) open() error {
+	file, err := os.OpenFile(rfw.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, DefaultRotatingFileWriterFileMode)
+	if err != nil {
+		return ex.New(err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return ex.New(err)
+	}
+	rfw.file = file
+	rfw.size = info.Size()
+	rfw.opened = rfw.now()
+	return nil
+}
+
+func (rfw *RotatingFileWriter) rotate() error {
+	if rfw.file != nil {
+		if err := rfw.file.Close(); err != nil {
+			return ex.New(err)
+		}
+		rfw.file = nil
+	}
+
+	historical := rfw.Path + "." + rfw.now().UTC().Format(DefaultRotatingFileWriterTimeFormat)
+	if err := os.Rename(rfw.Path, historical); err != nil && !os.IsNotExist(err) {
+		return ex.New(err)
+	}
+	if rfw.Compress {
+		if err := compressFile(historical); err != nil {
+			return err
+		}
+	}
+	if err := rfw.open(); err != nil {
+		return err
+	}
+	return rfw.prune()
+}
+
+func (rfw *RotatingFileWriter) prune() error {
+	if rfw.MaxFiles <= 0 {
+		return nil
+	}
+	files, err := rfw.HistoricalFiles()
+	if err != nil {
+		return err
+	}
+	if len(files) <= rfw.MaxFiles {
+		return nil
+	}
+	for _, file := range files[:len(files)-rfw.MaxFiles] {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return ex.New(err)
+		}
+	}
+	return nil
+}
+
+// compressFile gzips a file to `<path>.gz` and removes the original.
+func compressFile(path string) error {
+	source, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return ex.New(err)
+	}
+	defer source.Close()
+
+	destination, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, DefaultRotatingFileWriterFileMode)
+	if err != nil {
+		return ex.New(err)
+	}
+	defer destination.Close()
+
+	gz := gzip.NewWriter(destination)
+	if _, err = io.Copy(gz, source); err != nil {
+		return ex.New(err)
+	}
+	if err = gz.Close(); err != nil {
+		return ex.New(err)
+	}
+	if err = destination.Close(); err != nil {
+		return ex.New(err)
+	}
+	// close the source before removing it, which is required on windows.
+	if err = source.Close(); err != nil {
+		return ex.New(err)
+	}
+	return ex.New(os.Remove(path))
+}
@@ -0,0 +1,208 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "go-sdk-logger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func TestRotatingFileWriterMaxBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	path := filepath.Join(dir, "test.log")
+	rfw, err := NewRotatingFileWriter(path,
+		OptRotatingFileWriterMaxBytes(16),
+		OptRotatingFileWriterMaxFiles(2),
+	)
+	assert.Nil(err)
+	defer rfw.Close()
+
+	line := []byte("0123456789\n") // 11 bytes
+	_, err = rfw.Write(line)
+	assert.Nil(err)
+	files, err := rfw.HistoricalFiles()
+	assert.Nil(err)
+	assert.Empty(files)
+
+	// the second write would exceed the threshold, so it should roll over.
+	_, err = rfw.Write(line)
+	assert.Nil(err)
+	files, err = rfw.HistoricalFiles()
+	assert.Nil(err)
+	assert.Len(files, 1)
+
+	contents, err := ioutil.ReadFile(path)
+	assert.Nil(err)
+	assert.Equal(string(line), string(contents))
+	contents, err = ioutil.ReadFile(files[0])
+	assert.Nil(err)
+	assert.Equal(string(line), string(contents))
+
+	// write past the threshold a few more times, only the most recent files should be kept.
+	for x := 0; x < 4; x++ {
+		_, err = rfw.Write(line)
+		assert.Nil(err)
+	}
+	files, err = rfw.HistoricalFiles()
+	assert.Nil(err)
+	assert.Len(files, 2)
+}
+
+func TestRotatingFileWriterHistoricalFilesSiblings(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	// the path includes glob metacharacters, and the directory has unrelated files that share its prefix.
+	path := filepath.Join(dir, "test[1].log")
+	for _, name := range []string{"test[1].log.bak", "test[1].log.20200101.gz", "test1.log.20200101T000000.000000000Z", "test[1].log.lock"} {
+		assert.Nil(ioutil.WriteFile(filepath.Join(dir, name), []byte("keep\n"), 0644))
+	}
+	rfw, err := NewRotatingFileWriter(path, OptRotatingFileWriterMaxFiles(1))
+	assert.Nil(err)
+	defer rfw.Close()
+
+	for x := 0; x < 3; x++ {
+		_, err = rfw.Write([]byte("line\n"))
+		assert.Nil(err)
+		assert.Nil(rfw.Rotate())
+	}
+	files, err := rfw.HistoricalFiles()
+	assert.Nil(err)
+	assert.Len(files, 1)
+	assert.True(strings.HasPrefix(files[0], path+"."))
+
+	for _, name := range []string{"test[1].log.bak", "test[1].log.20200101.gz", "test1.log.20200101T000000.000000000Z", "test[1].log.lock"} {
+		_, err = os.Stat(filepath.Join(dir, name))
+		assert.Nil(err, name)
+	}
+}
+
+func TestRotatingFileWriterInterval(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	now := time.Date(2019, 10, 14, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(dir, "test.log")
+	rfw, err := NewRotatingFileWriter(path, OptRotatingFileWriterInterval(time.Hour))
+	assert.Nil(err)
+	defer rfw.Close()
+	rfw.Now = func() time.Time { return now }
+	rfw.opened = now
+
+	_, err = rfw.Write([]byte("first\n"))
+	assert.Nil(err)
+	now = now.Add(30 * time.Minute)
+	_, err = rfw.Write([]byte("second\n"))
+	assert.Nil(err)
+	files, err := rfw.HistoricalFiles()
+	assert.Nil(err)
+	assert.Empty(files)
+
+	now = now.Add(30 * time.Minute)
+	_, err = rfw.Write([]byte("third\n"))
+	assert.Nil(err)
+	files, err = rfw.HistoricalFiles()
+	assert.Nil(err)
+	assert.Len(files, 1)
+	assert.Equal(path+".20191014T130000.000000000Z", files[0])
+
+	contents, err := ioutil.ReadFile(files[0])
+	assert.Nil(err)
+	assert.Equal("first\nsecond\n", string(contents))
+}
+
+func TestRotatingFileWriterCompress(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	path := filepath.Join(dir, "test.log")
+	rfw, err := NewRotatingFileWriter(path, OptRotatingFileWriterCompress(true))
+	assert.Nil(err)
+	defer rfw.Close()
+
+	_, err = rfw.Write([]byte("compressed\n"))
+	assert.Nil(err)
+	assert.Nil(rfw.Rotate())
+
+	files, err := rfw.HistoricalFiles()
+	assert.Nil(err)
+	assert.Len(files, 1)
+	assert.True(strings.HasSuffix(files[0], ".gz"))
+
+	f, err := os.Open(files[0])
+	assert.Nil(err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	assert.Nil(err)
+	contents, err := ioutil.ReadAll(gz)
+	assert.Nil(err)
+	assert.Equal("compressed\n", string(contents))
+}
+
+func TestRotatingFileWriterConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	path := filepath.Join(dir, "test.log")
+	rfw, err := NewRotatingFileWriter(path,
+		OptRotatingFileWriterMaxBytes(64),
+		OptRotatingFileWriterMaxFiles(-1),
+	)
+	assert.Nil(err)
+
+	log := MustNew(OptAll(), OptOutput(rfw), OptText(OptTextNoColor(), OptTextHideTimestamp()))
+	wg := sync.WaitGroup{}
+	for x := 0; x < 8; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := 0; y < 32; y++ {
+				log.Info("test")
+			}
+		}()
+	}
+	wg.Wait()
+	log.Close()
+	assert.Nil(rfw.Close())
+
+	files, err := rfw.HistoricalFiles()
+	assert.Nil(err)
+	files = append(files, path)
+
+	var lines int
+	for _, file := range files {
+		contents, err := ioutil.ReadFile(file)
+		assert.Nil(err)
+		lines += strings.Count(string(contents), "\n")
+	}
+	assert.Equal(8*32, lines)
+
+	_, err = rfw.Write([]byte("closed"))
+	assert.NotNil(err)
+}
@@ -125,6 +125,9 @@ func (sc Scope) WithAnnotations(annotations Annotations) Scope {
 // The provided context is ammended with fields from the scope.
 // The provided context is also ammended with a TriggerTimestamp, which can be retrieved with `GetTriggerTimestamp(ctx)` in listeners.
 func (sc Scope) Trigger(ctx context.Context, event Event) {
+	if event == nil || !sc.Logger.ShouldTrigger(event.GetFlag()) {
+		return
+	}
 	ctx = WithTriggerTimestamp(ctx, time.Now().UTC())
 	sc.Logger.Trigger(sc.Apply(ctx), event)
 }
@@ -135,57 +138,103 @@ func (sc Scope) Trigger(ctx context.Context, event Event) {
 
 // Info logs an informational message to the output stream.
 func (sc Scope) Info(args ...interface{}) {
+	if !sc.Logger.ShouldTrigger(Info) {
+		return
+	}
 	sc.Trigger(sc.Context, NewMessageEvent(Info, fmt.Sprint(args...)))
 }
 
 // Infof logs an informational message to the output stream.
 func (sc Scope) Infof(format string, args ...interface{}) {
+	if !sc.Logger.ShouldTrigger(Info) {
+		return
+	}
 	sc.Trigger(sc.Context, NewMessageEvent(Info, fmt.Sprintf(format, args...)))
 }
 
 // Debug logs a debug message to the output stream.
 func (sc Scope) Debug(args ...interface{}) {
+	if !sc.Logger.ShouldTrigger(Debug) {
+		return
+	}
 	sc.Trigger(sc.Context, NewMessageEvent(Debug, fmt.Sprint(args...)))
 }
 
 // Debugf logs a debug message to the output stream.
 func (sc Scope) Debugf(format string, args ...interface{}) {
+	if !sc.Logger.ShouldTrigger(Debug) {
+		return
+	}
 	sc.Trigger(sc.Context, NewMessageEvent(Debug, fmt.Sprintf(format, args...)))
 }
 
 // Warningf logs a warning message to the output stream.
 func (sc Scope) Warningf(format string, args ...interface{}) {
+	if !sc.Logger.ShouldTrigger(Warning) {
+		return
+	}
 	sc.Trigger(sc.Context, NewErrorEvent(Warning, fmt.Errorf(format, args...)))
 }
 
 // Errorf writes an event to the log and triggers event listeners.
 func (sc Scope) Errorf(format string, args ...interface{}) {
+	if !sc.Logger.ShouldTrigger(Error) {
+		return
+	}
 	sc.Trigger(sc.Context, NewErrorEvent(Error, fmt.Errorf(format, args...)))
 }
 
 // Fatalf writes an event to the log and triggers event listeners.
 func (sc Scope) Fatalf(format string, args ...interface{}) {
+	if !sc.Logger.ShouldTrigger(Fatal) {
+		return
+	}
 	sc.Trigger(sc.Context, NewErrorEvent(Fatal, fmt.Errorf(format, args...)))
 }
 
 // Warning logs a warning error to std err.
 func (sc Scope) Warning(err error, opts ...ErrorEventOption) error {
+	if !sc.Logger.ShouldTrigger(Warning) {
+		return err
+	}
 	sc.Trigger(sc.Context, NewErrorEvent(Warning, err, opts...))
 	return err
 }
 
 // Error logs an error to std err.
 func (sc Scope) Error(err error, opts ...ErrorEventOption) error {
+	if !sc.Logger.ShouldTrigger(Error) {
+		return err
+	}
 	sc.Trigger(sc.Context, NewErrorEvent(Error, err, opts...))
 	return err
 }
 
 // Fatal logs an error as fatal.
 func (sc Scope) Fatal(err error, opts ...ErrorEventOption) error {
+	if !sc.Logger.ShouldTrigger(Fatal) {
+		return err
+	}
 	sc.Trigger(sc.Context, NewErrorEvent(Fatal, err, opts...))
 	return err
 }
 
+// Timed returns a function that, when called, logs a message for a named block with a given flag
+// and the time elapsed since `Timed` was called. It is meant to be deferred, e.g.
+//
+//	defer log.Timed(logger.Debug, "load users")()
+//
+// The elapsed time is set on the message event, and is written as the `elapsed` field in json output.
+func (sc Scope) Timed(flag, name string) func() {
+	if !sc.Logger.ShouldTrigger(flag) {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		sc.Trigger(sc.Context, NewMessageEvent(flag, name, OptMessageElapsed(time.Since(start))))
+	}
+}
+
 //
 // Context utilities
 //
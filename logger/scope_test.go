@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/blend/go-sdk/assert"
 )
@@ -110,3 +111,26 @@ func TestScopeApply(t *testing.T) {
 	assert.Equal("bar", GetLabels(final)["foo"])
 	assert.Equal("loo", GetLabels(final)["moo"])
 }
+
+func TestScopeTimed(t *testing.T) {
+	assert := assert.New(t)
+
+	log := MustNew(OptAll(), OptOutput(nil), OptFormatter(nil))
+	defer log.Close()
+
+	events := make(chan MessageEvent, 1)
+	log.Listen(Debug, "test", NewMessageEventListener(func(_ context.Context, me MessageEvent) {
+		events <- me
+	}))
+
+	done := log.Timed(Debug, "test block")
+	time.Sleep(50 * time.Millisecond)
+	done()
+
+	me := <-events
+	assert.Equal(Debug, me.Flag)
+	assert.Equal("test block", me.Text)
+	assert.True(me.Elapsed >= 50*time.Millisecond, me.Elapsed.String())
+	assert.True(me.Elapsed < time.Second, me.Elapsed.String())
+	assert.Equal(me.Elapsed, me.Decompose()[FieldElapsed])
+}
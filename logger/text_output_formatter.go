@@ -20,6 +20,7 @@ func NewTextOutputFormatter(options ...TextOutputFormatterOption) *TextOutputFor
 	tf := &TextOutputFormatter{
 		BufferPool: bufferutil.NewPool(DefaultBufferPoolSize),
 		TimeFormat: DefaultTextTimeFormat,
+		terminals:  new(terminalCache),
 	}
 
 	for _, option := range options {
@@ -38,7 +39,10 @@ func OptTextConfig(cfg TextConfig) TextOutputFormatterOption {
 		tf.HideTimestamp = cfg.HideTimestamp
 		tf.HideFields = cfg.HideFields
 		tf.NoColor = cfg.NoColor
+		tf.AutoColor = cfg.AutoColor
 		tf.TimeFormat = cfg.TimeFormatOrDefault()
+		tf.Template = cfg.Template
+		tf.TemplateStrict = cfg.TemplateStrict
 	}
 }
 
@@ -62,14 +66,38 @@ func OptTextNoColor() TextOutputFormatterOption {
 	return func(tf *TextOutputFormatter) { tf.NoColor = true }
 }
 
+// OptTextAutoColor only colorizes text output if the output is a terminal.
+func OptTextAutoColor() TextOutputFormatterOption {
+	return func(tf *TextOutputFormatter) { tf.AutoColor = true }
+}
+
+// OptTextTemplate sets a template for the layout of text output, e.g. `{timestamp} [{flag}] {message}`.
+// The known tokens are `timestamp`, `path`, `flag`, `caller`, `message` and `labels`.
+func OptTextTemplate(template string) TextOutputFormatterOption {
+	return func(tf *TextOutputFormatter) { tf.Template = template }
+}
+
+// OptTextTemplateStrict makes unknown text template tokens error rather than render literally.
+func OptTextTemplateStrict() TextOutputFormatterOption {
+	return func(tf *TextOutputFormatter) { tf.TemplateStrict = true }
+}
+
 // TextOutputFormatter handles formatting messages as text.
 type TextOutputFormatter struct {
 	HideTimestamp bool
 	HideFields    bool
 	NoColor       bool
+	AutoColor     bool
 	TimeFormat    string
+	// Template, if set, is the layout of the text output; it takes precedence over `HideTimestamp`.
+	Template string
+	// TemplateStrict makes unknown template tokens error rather than render literally.
+	TemplateStrict bool
 
 	BufferPool *bufferutil.Pool
+
+	// terminals caches if outputs are terminals for `AutoColor`.
+	terminals *terminalCache
 }
 
 // TimeFormatOrDefault returns the time format or a default
@@ -115,6 +143,11 @@ func (tf TextOutputFormatter) FormatPath(path ...string) string {
 	return fmt.Sprintf("[%s]", strings.Join(path, " > "))
 }
 
+// FormatCaller returns the caller section of the message as a string.
+func (tf TextOutputFormatter) FormatCaller(caller Caller) string {
+	return fmt.Sprintf("[%s]", tf.Colorize(caller.String(), ansi.ColorLightBlack))
+}
+
 // FormatLabels returns the scope labels section of the message as a string.
 func (tf TextOutputFormatter) FormatLabels(labels Labels) string {
 	return FormatLabels(tf, ansi.ColorBlue, labels)
@@ -122,9 +155,22 @@ func (tf TextOutputFormatter) FormatLabels(labels Labels) string {
 
 // WriteFormat implements write formatter.
 func (tf TextOutputFormatter) WriteFormat(ctx context.Context, output io.Writer, e Event) error {
+	if tf.AutoColor && !tf.terminals.isTerminal(output) {
+		tf.NoColor = true
+	}
+
 	buffer := tf.BufferPool.Get()
 	defer tf.BufferPool.Put(buffer)
 
+	if tf.Template != "" {
+		if err := tf.writeTemplate(ctx, buffer, e); err != nil {
+			return err
+		}
+		buffer.WriteString(Newline)
+		_, err := io.Copy(output, buffer)
+		return err
+	}
+
 	if !tf.HideTimestamp {
 		buffer.WriteString(tf.FormatTimestamp(GetEventTimestamp(ctx, e)))
 		buffer.WriteString(Space)
@@ -139,6 +185,11 @@ func (tf TextOutputFormatter) WriteFormat(ctx context.Context, output io.Writer,
 	buffer.WriteString(tf.FormatFlag(e.GetFlag(), FlagTextColor(e.GetFlag())))
 	buffer.WriteString(Space)
 
+	if caller, ok := GetCallerFromContext(ctx); ok {
+		buffer.WriteString(tf.FormatCaller(caller))
+		buffer.WriteString(Space)
+	}
+
 	if typed, ok := e.(TextWritable); ok {
 		typed.WriteText(tf, buffer)
 	} else if stringer, ok := e.(fmt.Stringer); ok {
@@ -1,12 +1,18 @@
 package logger
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/blend/go-sdk/ansi"
 	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/bufferutil"
+	"github.com/blend/go-sdk/ex"
 )
 
 func TestTextOutputFormatter(t *testing.T) {
@@ -101,3 +107,93 @@ func TestTextOutputFormatterFormatFields(t *testing.T) {
 	expected := fmt.Sprintf("%s=%v %s=%v", ansi.ColorBlue.Apply("buzz"), "one", ansi.ColorBlue.Apply("foo"), "bar")
 	assert.Equal(expected, actual)
 }
+
+func TestTextOutputFormatterWriteFormatColor(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	testCases := [...]struct {
+		Formatter  *TextOutputFormatter
+		Output     io.Writer
+		ShouldHave bool
+	}{
+		{Formatter: NewTextOutputFormatter(), Output: new(bytes.Buffer), ShouldHave: true},
+		{Formatter: NewTextOutputFormatter(OptTextNoColor()), Output: new(bytes.Buffer), ShouldHave: false},
+		{Formatter: NewTextOutputFormatter(OptTextAutoColor()), Output: new(bytes.Buffer), ShouldHave: false},
+		{Formatter: NewTextOutputFormatter(OptTextAutoColor()), Output: NewInterlockedWriter(new(bytes.Buffer)), ShouldHave: false},
+	}
+
+	for _, tc := range testCases {
+		assert.Nil(tc.Formatter.WriteFormat(ctx, tc.Output, NewMessageEvent(Error, "test")))
+		var output string
+		if typed, ok := tc.Output.(*InterlockedWriter); ok {
+			output = typed.Output.(*bytes.Buffer).String()
+		} else {
+			output = tc.Output.(*bytes.Buffer).String()
+		}
+		assert.Contains(output, "test")
+		assert.Equal(tc.ShouldHave, strings.Contains(output, "\033["), output)
+		if tc.ShouldHave {
+			assert.Contains(output, ansi.ColorRed.Apply(Error))
+		}
+	}
+}
+
+func TestTextOutputFormatterWriteFormatTemplate(t *testing.T) {
+	assert := assert.New(t)
+
+	ts := time.Date(2019, 06, 01, 12, 30, 15, 0, time.UTC)
+	ctx := WithTimestamp(context.Background(), ts)
+	ctx = WithPath(ctx, "api")
+	ctx = WithLabels(ctx, Labels{"foo": "bar"})
+
+	tf := NewTextOutputFormatter(
+		OptTextNoColor(),
+		OptTextTimeFormat(time.RFC3339),
+		OptTextTemplate("{timestamp} {path} [{flag}] {message} {labels}"),
+	)
+	buffer := new(bytes.Buffer)
+	assert.Nil(tf.WriteFormat(ctx, buffer, NewMessageEvent(Info, "this is a test")))
+	assert.Equal("2019-06-01T12:30:15Z [api] [info] this is a test foo=bar\n", buffer.String())
+
+	buffer.Reset()
+	tf = NewTextOutputFormatter(OptTextNoColor(), OptTextTemplate("{flag}|{message}|{unknown}|{path}|{labels}|{"))
+	assert.Nil(tf.WriteFormat(context.Background(), buffer, NewMessageEvent(Error, "uh oh")))
+	assert.Equal("error|uh oh|{unknown}|||{\n", buffer.String())
+
+	buffer.Reset()
+	tf = NewTextOutputFormatter(OptTextConfig(TextConfig{NoColor: true, Template: "{flag} {unknown}", TemplateStrict: true}))
+	err := tf.WriteFormat(context.Background(), buffer, NewMessageEvent(Error, "uh oh"))
+	assert.True(ex.Is(err, ErrTextTemplateUnknownToken))
+	assert.Empty(buffer.String())
+
+	buffer.Reset()
+	tf = NewTextOutputFormatter(OptTextTemplate("[{flag}] {message}"))
+	assert.Nil(tf.WriteFormat(context.Background(), buffer, NewMessageEvent(Info, "colored")))
+	assert.Equal("["+ansi.ColorGreen.Apply(Info)+"] colored\n", buffer.String())
+}
+
+func TestTextOutputFormatterAutoColorCached(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	tf := NewTextOutputFormatter(OptTextAutoColor())
+	output := new(bytes.Buffer)
+	assert.Nil(tf.WriteFormat(ctx, output, NewMessageEvent(Error, "test")))
+	assert.NotContains(output.String(), "\033[")
+	isTerminal, ok := tf.terminals.outputs[output]
+	assert.True(ok, "the output should be checked once and cached")
+	assert.False(isTerminal)
+
+	// the cached value is used rather than checking the output again.
+	tf.terminals.outputs[output] = true
+	output.Reset()
+	assert.Nil(tf.WriteFormat(ctx, output, NewMessageEvent(Error, "test")))
+	assert.Contains(output.String(), ansi.ColorRed.Apply(Error))
+
+	// formatters that are not created with `NewTextOutputFormatter` check the output every time.
+	literal := TextOutputFormatter{AutoColor: true, BufferPool: bufferutil.NewPool(DefaultBufferPoolSize)}
+	output.Reset()
+	assert.Nil(literal.WriteFormat(ctx, output, NewMessageEvent(Error, "test")))
+	assert.NotContains(output.String(), "\033[")
+}
@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/blend/go-sdk/ansi"
+	"github.com/blend/go-sdk/ex"
+)
+
+// Text template tokens.
+const (
+	TextTemplateTimestamp = "timestamp"
+	TextTemplatePath      = "path"
+	TextTemplateFlag      = "flag"
+	TextTemplateCaller    = "caller"
+	TextTemplateMessage   = "message"
+	TextTemplateLabels    = "labels"
+)
+
+const (
+	// ErrTextTemplateUnknownToken is returned by strict text templates with a token that is not known.
+	ErrTextTemplateUnknownToken ex.Class = "logger; text template has an unknown token"
+)
+
+// writeTemplate writes an event to a buffer with the formatter's template.
+//
+// Tokens are names wrapped in braces, e.g. `{timestamp} [{flag}] {message}`. Unknown tokens
+// are written literally, unless the template is strict, in which case an error is returned.
+func (tf TextOutputFormatter) writeTemplate(ctx context.Context, buffer *bytes.Buffer, e Event) error {
+	template := tf.Template
+	for len(template) > 0 {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			buffer.WriteString(template)
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			buffer.WriteString(template)
+			break
+		}
+		end += start
+
+		buffer.WriteString(template[:start])
+		token := template[start+1 : end]
+		if !tf.writeTemplateToken(ctx, buffer, e, token) {
+			if tf.TemplateStrict {
+				return ex.New(ErrTextTemplateUnknownToken, ex.OptMessagef("token: %s", token))
+			}
+			buffer.WriteString(template[start : end+1])
+		}
+		template = template[end+1:]
+	}
+	return nil
+}
+
+// writeTemplateToken writes a template token, returning false if the token is not known.
+func (tf TextOutputFormatter) writeTemplateToken(ctx context.Context, buffer *bytes.Buffer, e Event, token string) bool {
+	switch token {
+	case TextTemplateTimestamp:
+		buffer.WriteString(tf.Colorize(GetEventTimestamp(ctx, e).Format(tf.TimeFormatOrDefault()), ansi.ColorLightBlack))
+	case TextTemplatePath:
+		if scopePath := GetPath(ctx); scopePath != nil {
+			buffer.WriteString(tf.FormatPath(scopePath...))
+		}
+	case TextTemplateFlag:
+		buffer.WriteString(tf.Colorize(e.GetFlag(), FlagTextColor(e.GetFlag())))
+	case TextTemplateCaller:
+		if caller, ok := GetCallerFromContext(ctx); ok {
+			buffer.WriteString(tf.Colorize(caller.String(), ansi.ColorLightBlack))
+		}
+	case TextTemplateMessage:
+		if typed, ok := e.(TextWritable); ok {
+			typed.WriteText(tf, buffer)
+		} else if stringer, ok := e.(fmt.Stringer); ok {
+			buffer.WriteString(stringer.String())
+		}
+	case TextTemplateLabels:
+		if labels := GetLabels(ctx); len(labels) > 0 {
+			buffer.WriteString(tf.FormatLabels(labels))
+		}
+	default:
+		return false
+	}
+	return true
+}
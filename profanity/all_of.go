@@ -0,0 +1,34 @@
+package profanity
+
+import "fmt"
+
+func init() {
+	RegisterRuleEvaluator("allOf", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return len(r.AllOf) > 0 },
+		RuleFuncFunc: func(r Rule) RuleFunc { return AllOf(r.AllOf...) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[all of: %s]", joinRules(r.AllOf)) },
+	})
+}
+
+// AllOf creates a composite rule from a list of child rules.
+// It acts as an AND; it passes only if every child rule passes, and fails with the result
+// of the first child rule that fails. Child rules whose file filters exclude the file are skipped.
+func AllOf(rules ...Rule) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		for index, rule := range rules {
+			if !rule.ShouldInclude(filename) || rule.ShouldExclude(filename) {
+				continue
+			}
+			result := rule.Apply(filename, contents)
+			if result.Err != nil {
+				return result
+			}
+			if !result.OK {
+				result.File = filename
+				result.Message = fmt.Sprintf("all of: %s: %s", rule.Name(fmt.Sprintf("#%d", index)), result.Message)
+				return result
+			}
+		}
+		return RuleResult{OK: true}
+	}
+}
@@ -0,0 +1,75 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/ansi"
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestAllOf(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := AllOf(
+		Rule{ID: "NO_FOO", Contains: []string{"foo"}},
+		Rule{Pattern: []string{"^bar"}},
+	)
+
+	assert.Nil(ok(ruleFunc("file.txt", []byte("aaa\nbbb\n"))))
+
+	res := ruleFunc("file.txt", []byte("aaa\nfoo\n"))
+	assert.False(res.OK)
+	assert.Equal("file.txt", res.File)
+	assert.Equal(2, res.Line)
+	assert.Equal(`all of: NO_FOO: contains: "foo"`, res.Message)
+
+	res = ruleFunc("file.txt", []byte("bar\n"))
+	assert.False(res.OK)
+	assert.Equal(`all of: #1: regexp match: "^bar"`, res.Message)
+}
+
+func TestAllOfNested(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := AllOf(
+		Rule{ID: "NO_FOO", Contains: []string{"foo"}},
+		Rule{ID: "NESTED", AllOf: []Rule{
+			{ID: "NO_BAR", Contains: []string{"bar"}},
+			{ID: "NO_GO_BAZ", Contains: []string{"baz"}, IncludeFiles: []string{"*.go"}},
+		}},
+	)
+
+	assert.Nil(ok(ruleFunc("file.txt", []byte("aaa"))))
+	assert.Nil(ok(ruleFunc("file.txt", []byte("baz"))), "the nested rule should skip non-go files")
+
+	res := ruleFunc("file.go", []byte("baz"))
+	assert.False(res.OK)
+	assert.Equal(`all of: NESTED: all of: NO_GO_BAZ: contains: "baz"`, res.Message)
+}
+
+func TestProcessAllOf(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_DEBUG:
+  description: "no debug leftovers"
+  includeFiles: [ "*.txt" ]
+  allOf:
+  - id: NO_TODO
+    contains: [ "TODO" ]
+  - id: NO_FORBIDDEN
+    pattern: [ "forbidden\\(" ]
+`,
+		"ok.txt":  "ok\n",
+		"bad.txt": "ok\nforbidden(foo)\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, ansi.Bold(ansi.ColorWhite, "bad.txt")+":2")
+	assert.Contains(stderr, "all of: NO_FORBIDDEN")
+	assert.NotContains(stderr, "ok.txt")
+}
@@ -0,0 +1,45 @@
+package profanity
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+func init() {
+	RegisterRuleEvaluator("requireAnnotatedTodos", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.RequireAnnotatedTodos },
+		RuleFuncFunc: func(r Rule) RuleFunc { return RequireAnnotatedTodos() },
+		StringFunc:   func(r Rule) string { return "[require annotated todos]" },
+	})
+}
+
+// todoMarker matches `TODO` and `FIXME` markers, and an owner annotation if present, e.g. `TODO(user):`.
+var todoMarker = regexp.MustCompile(`\b(TODO|FIXME)\b(\([^()\s]+\):)?`)
+
+// RequireAnnotatedTodos creates a rule that fails if a file has a `TODO` or `FIXME` marker
+// without an owner in the form `TODO(user):`.
+// It fails on the first bare marker, reporting the marker text through the end of the line.
+func RequireAnnotatedTodos() RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		scanner := bufio.NewScanner(bytes.NewBuffer(contents))
+		var line int
+		for scanner.Scan() {
+			line++
+			text := scanner.Text()
+			for _, match := range todoMarker.FindAllStringSubmatchIndex(text, -1) {
+				if match[4] >= 0 { // has an owner annotation
+					continue
+				}
+				return RuleResult{
+					File:    filename,
+					Line:    line,
+					Message: fmt.Sprintf("%s without an owner: \"%s\"", text[match[2]:match[3]], strings.TrimSpace(text[match[0]:])),
+				}
+			}
+		}
+		return RuleResult{OK: true}
+	}
+}
@@ -0,0 +1,36 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestRequireAnnotatedTodos(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := RequireAnnotatedTodos()
+	assert.Nil(ok(ruleFunc("file.go", []byte("package foo\n\n// TODO(will): fix this\n// FIXME(jane): and this\nvar TODOList []string\n"))))
+	assert.Nil(ok(ruleFunc("file.go", nil)))
+
+	res := ruleFunc("file.go", []byte("package foo\n\n// TODO(will): fix this\n\t// TODO fix this too\n"))
+	assert.False(res.OK)
+	assert.Equal("file.go", res.File)
+	assert.Equal(4, res.Line)
+	assert.Equal(`TODO without an owner: "TODO fix this too"`, res.Message)
+
+	res = ruleFunc("file.py", []byte("x = 1 # FIXME: broken\n"))
+	assert.False(res.OK)
+	assert.Equal(1, res.Line)
+	assert.Equal(`FIXME without an owner: "FIXME: broken"`, res.Message)
+
+	res = ruleFunc("file.go", []byte("// TODO(): empty owner\n"))
+	assert.False(res.OK)
+
+	res = ruleFunc("file.go", []byte("// TODO(will) missing colon\n"))
+	assert.False(res.OK)
+
+	rule := Rule{RequireAnnotatedTodos: true}
+	assert.False(rule.Apply("file.go", []byte("// TODO\n")).OK)
+	assert.Contains(rule.String(), "[require annotated todos]")
+}
@@ -0,0 +1,48 @@
+package profanity
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterRuleEvaluator("anyOf", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return len(r.AnyOf) > 0 },
+		RuleFuncFunc: func(r Rule) RuleFunc { return AnyOf(r.AnyOf...) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[any of: %s]", joinRules(r.AnyOf)) },
+	})
+}
+
+// AnyOf creates a composite rule from a list of child rules.
+// It acts as an OR; it passes if any child rule passes, and fails only if every child rule fails,
+// with the messages of each child rule combined. Child rules whose file filters exclude the file are skipped.
+func AnyOf(rules ...Rule) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		var failures []string
+		var first *RuleResult
+		for index, rule := range rules {
+			if !rule.ShouldInclude(filename) || rule.ShouldExclude(filename) {
+				continue
+			}
+			result := rule.Apply(filename, contents)
+			if result.Err != nil {
+				return result
+			}
+			if result.OK {
+				return RuleResult{OK: true}
+			}
+			if first == nil {
+				first = &result
+			}
+			failures = append(failures, fmt.Sprintf("%s: %s", rule.Name(fmt.Sprintf("#%d", index)), result.Message))
+		}
+		if first == nil {
+			return RuleResult{OK: true}
+		}
+		return RuleResult{
+			File:    filename,
+			Line:    first.Line,
+			Message: fmt.Sprintf("any of: %s", strings.Join(failures, "; ")),
+		}
+	}
+}
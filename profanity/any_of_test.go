@@ -0,0 +1,72 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/ansi"
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestAnyOf(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := AnyOf(
+		Rule{ID: "NO_FOO", Contains: []string{"foo"}},
+		Rule{ID: "NO_BAR", Contains: []string{"bar"}},
+	)
+
+	assert.Nil(ok(ruleFunc("file.txt", []byte("aaa"))))
+	assert.Nil(ok(ruleFunc("file.txt", []byte("foo"))), "one child passing should pass the rule")
+	assert.Nil(ok(ruleFunc("file.txt", []byte("bar"))), "one child passing should pass the rule")
+
+	res := ruleFunc("file.txt", []byte("aaa\nfoo bar\n"))
+	assert.False(res.OK)
+	assert.Equal("file.txt", res.File)
+	assert.Equal(2, res.Line)
+	assert.Equal(`any of: NO_FOO: contains: "foo"; NO_BAR: contains: "bar"`, res.Message)
+}
+
+func TestAnyOfNested(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := AnyOf(
+		Rule{Contains: []string{"foo"}},
+		Rule{AllOf: []Rule{
+			{ID: "NO_BAR", Contains: []string{"bar"}},
+		}},
+	)
+
+	assert.Nil(ok(ruleFunc("file.txt", []byte("foo"))))
+	res := ruleFunc("file.txt", []byte("foo bar"))
+	assert.False(res.OK)
+	assert.Equal(`any of: #0: contains: "foo"; #1: all of: NO_BAR: contains: "bar"`, res.Message)
+}
+
+func TestProcessAnyOf(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+MIXED_LOGGING:
+  description: "please use a single logging package per file"
+  includeFiles: [ "*.txt" ]
+  anyOf:
+  - id: NO_FMT
+    contains: [ "fmt.Print" ]
+  - id: NO_LOG
+    pattern: [ "log\\.Print" ]
+`,
+		"fmt.txt":   "fmt.Println(foo)\n",
+		"log.txt":   "log.Println(foo)\n",
+		"mixed.txt": "fmt.Println(foo)\nlog.Println(foo)\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure), stderr)
+	assert.Contains(stderr, ansi.Bold(ansi.ColorWhite, "mixed.txt")+":1")
+	assert.Contains(stderr, `any of: NO_FMT: contains: "fmt.Print"; NO_LOG: regexp match: "log\.Print"`)
+	assert.NotContains(stderr, "fmt.txt")
+	assert.NotContains(stderr, "log.txt")
+}
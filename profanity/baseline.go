@@ -0,0 +1,106 @@
+package profanity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/yaml"
+)
+
+// NewBaselineViolation returns a baseline violation for a rule result.
+//
+// The hash is of the (whitespace trimmed) line the violation was found on, or of the
+// full file contents if the result did not have a line, so the violation stays
+// in the baseline as the line moves within the file, but not if it changes or moves to another file.
+func NewBaselineViolation(file string, rule Rule, res RuleResult, contents []byte) BaselineViolation {
+	hashed := contents
+	if res.Line > 0 {
+		lines := bytes.Split(contents, []byte("\n"))
+		if res.Line <= len(lines) {
+			hashed = bytes.TrimSpace(lines[res.Line-1])
+		}
+	}
+	hash := sha256.Sum256(hashed)
+	return BaselineViolation{
+		File: filepath.ToSlash(file),
+		Rule: rule.ID,
+		Hash: hex.EncodeToString(hash[:]),
+	}
+}
+
+// BaselineViolation is a violation recorded in a baseline.
+type BaselineViolation struct {
+	File string `yaml:"file"`
+	Rule string `yaml:"rule"`
+	Hash string `yaml:"hash"`
+}
+
+// Baseline is a set of pre-existing violations that are suppressed during a run.
+type Baseline struct {
+	Violations []BaselineViolation `yaml:"violations"`
+}
+
+// Has returns if the baseline includes a given violation.
+func (b Baseline) Has(violation BaselineViolation) bool {
+	for _, existing := range b.Violations {
+		if existing == violation {
+			return true
+		}
+	}
+	return false
+}
+
+// Add adds a violation to the baseline if it is not already present.
+func (b *Baseline) Add(violation BaselineViolation) {
+	if b.Has(violation) {
+		return
+	}
+	b.Violations = append(b.Violations, violation)
+}
+
+// ReadBaseline reads a baseline from a given path.
+//
+// If the file does not exist, the error will satisfy `os.IsNotExist`.
+func ReadBaseline(path string) (*Baseline, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, ex.New(err, ex.OptMessagef("file: %s", path))
+	}
+	var baseline Baseline
+	if err := yaml.Unmarshal(contents, &baseline); err != nil {
+		return nil, ex.New("cannot unmarshal baseline file", ex.OptMessagef("file: %s", path), ex.OptInnerClass(err))
+	}
+	return &baseline, nil
+}
+
+// WriteBaseline writes a baseline to a given path.
+// Violations are sorted so the file is stable between runs.
+func WriteBaseline(path string, baseline *Baseline) error {
+	sort.Slice(baseline.Violations, func(i, j int) bool {
+		vi, vj := baseline.Violations[i], baseline.Violations[j]
+		if vi.File != vj.File {
+			return vi.File < vj.File
+		}
+		if vi.Rule != vj.Rule {
+			return vi.Rule < vj.Rule
+		}
+		return vi.Hash < vj.Hash
+	})
+	contents, err := yaml.Marshal(baseline)
+	if err != nil {
+		return ex.New(err)
+	}
+	if err := ioutil.WriteFile(path, contents, 0644); err != nil {
+		return ex.New(err, ex.OptMessagef("file: %s", path))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package profanity
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestNewBaselineViolation(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := Rule{ID: "NO_FOO"}
+	contents := []byte("bar\n\tfoo\n")
+	violation := NewBaselineViolation(filepath.Join("nested", "bad.txt"), rule, RuleResult{Line: 2}, contents)
+	assert.Equal("nested/bad.txt", violation.File)
+	assert.Equal("NO_FOO", violation.Rule)
+	assert.NotEmpty(violation.Hash)
+
+	// the hash is of the trimmed line, so it is stable as the line moves.
+	moved := NewBaselineViolation(filepath.Join("nested", "bad.txt"), rule, RuleResult{Line: 1}, []byte("foo\nbar\n"))
+	assert.Equal(violation, moved)
+
+	// file level violations hash the full contents.
+	whole := NewBaselineViolation("bad.txt", rule, RuleResult{}, contents)
+	changed := NewBaselineViolation("bad.txt", rule, RuleResult{}, []byte("bar\n"))
+	assert.NotEqual(whole.Hash, changed.Hash)
+}
+
+func TestBaselineReadWrite(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "profanity-baseline")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "baseline.yml")
+
+	_, err = ReadBaseline(path)
+	assert.True(os.IsNotExist(err))
+
+	var baseline Baseline
+	baseline.Add(BaselineViolation{File: "b.txt", Rule: "NO_FOO", Hash: "abc"})
+	baseline.Add(BaselineViolation{File: "a.txt", Rule: "NO_FOO", Hash: "abc"})
+	baseline.Add(BaselineViolation{File: "a.txt", Rule: "NO_FOO", Hash: "abc"})
+	assert.Len(baseline.Violations, 2)
+	assert.Nil(WriteBaseline(path, &baseline))
+
+	read, err := ReadBaseline(path)
+	assert.Nil(err)
+	assert.Len(read.Violations, 2)
+	assert.Equal("a.txt", read.Violations[0].File)
+	assert.True(read.Has(BaselineViolation{File: "b.txt", Rule: "NO_FOO", Hash: "abc"}))
+	assert.False(read.Has(BaselineViolation{File: "b.txt", Rule: "NO_BAR", Hash: "abc"}))
+}
@@ -9,6 +9,10 @@ type Config struct {
 	RulesFile string   `yaml:"rulesFile"`
 	Include   []string `yaml:"include,omitempty"`
 	Exclude   []string `yaml:"exclude,omitempty"`
+	SkipDirs  []string `yaml:"skipDirs,omitempty"`
+	Baseline  string   `yaml:"baseline,omitempty"`
+	Format    string   `yaml:"format,omitempty"`
+	Docs      *bool    `yaml:"docs,omitempty"`
 }
 
 // VerboseOrDefault returns an option or a default.
@@ -35,6 +39,22 @@ func (c Config) FailFastOrDefault() bool {
 	return false
 }
 
+// DocsOrDefault returns an option or a default.
+func (c Config) DocsOrDefault() bool {
+	if c.Docs != nil {
+		return *c.Docs
+	}
+	return false
+}
+
+// RootOrDefault returns the root directory to walk or a default.
+func (c Config) RootOrDefault() string {
+	if c.Root != "" {
+		return c.Root
+	}
+	return Root
+}
+
 // RulesFileOrDefault returns the rules file or a default.
 func (c Config) RulesFileOrDefault() string {
 	if c.RulesFile != "" {
@@ -42,3 +62,27 @@ func (c Config) RulesFileOrDefault() string {
 	}
 	return DefaultRulesFile
 }
+
+// SkipDirsOrDefault returns the directory names to skip or a default.
+//
+// The `AlwaysSkipDirs`, e.g. `.git`, are skipped even if the directory names to skip are set.
+func (c Config) SkipDirsOrDefault() []string {
+	if len(c.SkipDirs) == 0 {
+		return DefaultSkipDirs
+	}
+	output := append([]string(nil), AlwaysSkipDirs...)
+	for _, skipDir := range c.SkipDirs {
+		if !containsString(output, skipDir) {
+			output = append(output, skipDir)
+		}
+	}
+	return output
+}
+
+// FormatOrDefault returns the output format or a default.
+func (c Config) FormatOrDefault() string {
+	if c.Format != "" {
+		return c.Format
+	}
+	return FormatText
+}
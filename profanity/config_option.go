@@ -54,6 +54,34 @@ func OptExclude(excludes ...string) ConfigOption {
 	}
 }
 
+// OptSkipDirs sets the directory names to skip.
+func OptSkipDirs(skipDirs ...string) ConfigOption {
+	return func(c *Config) {
+		c.SkipDirs = skipDirs
+	}
+}
+
+// OptBaseline sets the baseline file path.
+func OptBaseline(baseline string) ConfigOption {
+	return func(c *Config) {
+		c.Baseline = baseline
+	}
+}
+
+// OptFormat sets the output format.
+func OptFormat(format string) ConfigOption {
+	return func(c *Config) {
+		c.Format = format
+	}
+}
+
+// OptDocs sets if we should write a markdown table of the rules instead of checking files.
+func OptDocs(docs bool) ConfigOption {
+	return func(c *Config) {
+		c.Docs = ref.Bool(docs)
+	}
+}
+
 // OptConfig sets the config in its entirety.
 func OptConfig(cfg Config) ConfigOption {
 	return func(c *Config) {
@@ -23,6 +23,10 @@ func TestConfigOptions(t *testing.T) {
 	OptFailFast(true)(cfg)
 	assert.True(cfg.FailFastOrDefault())
 
+	assert.False(cfg.DocsOrDefault())
+	OptDocs(true)(cfg)
+	assert.True(cfg.DocsOrDefault())
+
 	assert.Empty(cfg.Root)
 	OptRoot("../foo")(cfg)
 	assert.Equal("../foo", cfg.Root)
@@ -23,7 +23,15 @@ func TestConfig(t *testing.T) {
 	cfg.FailFast = ref.Bool(true)
 	assert.True(cfg.FailFastOrDefault())
 
+	assert.Equal(Root, cfg.RootOrDefault())
+	cfg.Root = "foo"
+	assert.Equal("foo", cfg.RootOrDefault())
+
 	assert.Equal(DefaultRulesFile, cfg.RulesFileOrDefault())
 	cfg.RulesFile = "foo"
 	assert.Equal("foo", cfg.RulesFileOrDefault())
+
+	assert.Equal(DefaultSkipDirs, cfg.SkipDirsOrDefault())
+	cfg.SkipDirs = []string{"walked", ".git"}
+	assert.Equal([]string{".git", "walked"}, cfg.SkipDirsOrDefault())
 }
@@ -3,6 +3,57 @@ package profanity
 // Defaults
 const (
 	DefaultRulesFile = "PROFANITY_RULES.yml"
+	// DefaultRequireHeaderWithinLines is the default number of lines at the top of a file that make up its header.
+	DefaultRequireHeaderWithinLines = 10
+)
+
+var (
+	// DefaultSkipDirs are the directory names that are skipped by default.
+	DefaultSkipDirs = []string{".git", "_bin", "vendor", "node_modules"}
+	// AlwaysSkipDirs are the directory names that are skipped even if the directory names to skip are set.
+	AlwaysSkipDirs = []string{".git"}
+	// DefaultRequireTestFileExempt are the file base names that do not require test files by default.
+	DefaultRequireTestFileExempt = []string{"main.go"}
+	// DefaultDebugStatements are the debug statements forbidden by default, keyed by file extension.
+	DefaultDebugStatements = map[string][]string{
+		".go":  {"fmt.Println(", "spew.Dump("},
+		".js":  {"console.log(", "debugger;"},
+		".jsx": {"console.log(", "debugger;"},
+		".ts":  {"console.log(", "debugger;"},
+		".tsx": {"console.log(", "debugger;"},
+		".py":  {"pdb.set_trace()", "breakpoint()"},
+		".rb":  {"binding.pry"},
+	}
+)
+
+// Directives
+const (
+	// DirectiveInherit is the rules file key that determines if parent rules are inherited.
+	DirectiveInherit = "inherit"
+	// DirectiveAllow is the inline comment that exempts a line from rules that support it, e.g. `// profanity:allow`.
+	DirectiveAllow = "profanity:allow"
+	// DirectiveDisablePrefix is the prefix shared by the inline suppression directives.
+	DirectiveDisablePrefix = "profanity:disable"
+	// DirectiveDisable is the inline comment that suppresses a rule for a whole file, e.g. `// profanity:disable=RULE_ID`.
+	DirectiveDisable = "profanity:disable="
+	// DirectiveDisableNextLine is the inline comment that suppresses a rule for the next line, e.g. `// profanity:disable-next-line=RULE_ID`.
+	DirectiveDisableNextLine = "profanity:disable-next-line="
+)
+
+// Severities
+const (
+	// SeverityError is the default rule severity; failures fail the run.
+	SeverityError = "error"
+	// SeverityWarn is a rule severity that reports failures without failing the run.
+	SeverityWarn = "warn"
+)
+
+// Formats
+const (
+	// FormatText is the default output format; violations are written to stderr as text.
+	FormatText = "text"
+	// FormatJSONL is an output format that streams violations to stdout as one json object per line.
+	FormatJSONL = "jsonl"
 )
 
 // Glob constants
@@ -12,4 +63,7 @@ const (
 
 	GoFiles     = "*.go"
 	GoTestFiles = "*_test.go"
+
+	// GoTestFileSuffix is the suffix of go test file names.
+	GoTestFileSuffix = "_test.go"
 )
@@ -7,6 +7,14 @@ import (
 	"strings"
 )
 
+func init() {
+	RegisterRuleEvaluator("contains", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return len(r.Contains) > 0 },
+		RuleFuncFunc: func(r Rule) RuleFunc { return ContainsAny(r.Contains...) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[contains: %s]", strings.Join(r.Contains, ",")) },
+	})
+}
+
 // ContainsAny creates a simple contains rule.
 // It acts as an OR; it fails if a corpus contains any given value.
 func ContainsAny(values ...string) RuleFunc {
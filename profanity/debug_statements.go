@@ -0,0 +1,54 @@
+package profanity
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func init() {
+	RegisterRuleEvaluator("forbidDebugStatements", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.ForbidDebugStatements },
+		RuleFuncFunc: func(r Rule) RuleFunc { return ForbidDebugStatements(r.DebugStatementsOrDefault()) },
+		StringFunc: func(r Rule) string {
+			statements := r.DebugStatementsOrDefault()
+			extensions := make([]string, 0, len(statements))
+			for extension := range statements {
+				extensions = append(extensions, extension)
+			}
+			sort.Strings(extensions)
+			return fmt.Sprintf("[forbid debug statements: %s]", strings.Join(extensions, ","))
+		},
+	})
+}
+
+// ForbidDebugStatements creates a rule that fails if a file has a debug statement, e.g. `console.log(`,
+// where the statements are keyed by file extension, e.g. `.js`; files with other extensions are skipped.
+// Lines that contain `profanity:allow`, e.g. in a trailing comment, are exempt.
+// It fails on the first debug statement.
+func ForbidDebugStatements(statements map[string][]string) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		forbidden, ok := statements[strings.ToLower(filepath.Ext(filename))]
+		if !ok || len(forbidden) == 0 {
+			return RuleResult{OK: true}
+		}
+		scanner := bufio.NewScanner(bytes.NewBuffer(contents))
+		var line int
+		for scanner.Scan() {
+			line++
+			text := scanner.Text()
+			if strings.Contains(text, DirectiveAllow) {
+				continue
+			}
+			for _, statement := range forbidden {
+				if strings.Contains(text, statement) {
+					return RuleResult{File: filename, Line: line, Message: fmt.Sprintf("debug statement: \"%s\"", statement)}
+				}
+			}
+		}
+		return RuleResult{OK: true}
+	}
+}
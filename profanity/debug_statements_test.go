@@ -0,0 +1,85 @@
+package profanity
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestForbidDebugStatements(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := ForbidDebugStatements(DefaultDebugStatements)
+	assert.Nil(ok(ruleFunc("file.go", []byte("package foo\n\nfunc foo() { fmt.Printf(\"%d\", 1) }\n"))))
+	assert.Nil(ok(ruleFunc("file.md", []byte("console.log(\"hello\")\n"))))
+	assert.Nil(ok(ruleFunc("file.js", nil)))
+
+	res := ruleFunc("file.go", []byte("package foo\n\nfunc foo() {\n\tfmt.Println(\"here\")\n}\n"))
+	assert.False(res.OK)
+	assert.Equal("file.go", res.File)
+	assert.Equal(4, res.Line)
+	assert.Equal(`debug statement: "fmt.Println("`, res.Message)
+
+	res = ruleFunc("FILE.TSX", []byte("const x = 1;\nconsole.log(x);\n"))
+	assert.False(res.OK)
+	assert.Equal(2, res.Line)
+	assert.Equal(`debug statement: "console.log("`, res.Message)
+
+	res = ruleFunc("file.js", []byte("function foo() {\n  debugger;\n}\n"))
+	assert.False(res.OK)
+	assert.Equal(`debug statement: "debugger;"`, res.Message)
+
+	res = ruleFunc("file.py", []byte("import pdb\n\npdb.set_trace()\n"))
+	assert.False(res.OK)
+	assert.Equal(3, res.Line)
+	assert.Equal(`debug statement: "pdb.set_trace()"`, res.Message)
+
+	assert.Nil(ok(ruleFunc("file.js", []byte("console.log(banner); // profanity:allow\n"))))
+	assert.Nil(ok(ruleFunc("file.py", []byte("breakpoint()  # profanity:allow\n"))))
+}
+
+func TestForbidDebugStatementsRule(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := Rule{ForbidDebugStatements: true}
+	assert.False(rule.Apply("file.py", []byte("breakpoint()\n")).OK)
+	assert.Contains(rule.String(), "[forbid debug statements: .go,.js,.jsx,.py,.rb,.ts,.tsx]")
+
+	rule = Rule{ForbidDebugStatements: true, DebugStatements: map[string][]string{"JS": {"alert("}}}
+	assert.Equal([]string{"alert("}, rule.DebugStatementsOrDefault()[".js"])
+	assert.True(rule.Apply("file.js", []byte("console.log(foo)\n")).OK)
+}
+
+func TestProcessForbidDebugStatements(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+DEBUG_STATEMENTS:
+  description: "please remove debugging statements"
+  forbidDebugStatements: true
+  debugStatements:
+    go: [ "log.Printf(\"DEBUG" ]
+`,
+		"main.go":    "package main\n\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n",
+		"debug.go":   "package main\n\nfunc debug() {\n\tlog.Printf(\"DEBUG %v\", 1)\n}\n",
+		"allowed.js": "console.log(banner); // profanity:allow\n",
+		"app.js":     "\nconsole.log(state);\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "debug.go")
+	assert.Contains(stderr, `debug statement: "log.Printf("DEBUG"`)
+	assert.Contains(stderr, "app.js")
+	assert.NotContains(stderr, "main.go")
+	assert.NotContains(stderr, "allowed.js")
+	assert.Contains(stderr, "scanned 4 file(s), 2 violation(s) across 1 rule(s)")
+
+	rules, err := New(OptRoot(root)).RulesFromPath(filepath.Join(root, DefaultRulesFile))
+	assert.Nil(err)
+	assert.Contains(rules["DEBUG_STATEMENTS"].String(), "[forbid debug statements:")
+}
@@ -0,0 +1,102 @@
+package profanity
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// Docs walks the root for rules files and writes a markdown table of every rule they define to the output stream,
+// sorted by rules file and then by rule id, so the policy a repository enforces can be published.
+func (p *Profanity) Docs() error {
+	root := p.Config.RootOrDefault()
+	var rules []Rule
+	if err := filepath.Walk(root, func(fullPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if fullPath != root && p.ShouldSkipDir(info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Name() != p.Config.RulesFileOrDefault() {
+			return nil
+		}
+		rulesFile, err := p.RulesFileFromPath(fullPath)
+		if err != nil {
+			return err
+		}
+		for _, rule := range rulesFile.Rules {
+			rules = append(rules, rule)
+		}
+		return nil
+	}); err != nil {
+		return ex.New(err)
+	}
+	if p.Stdout != nil {
+		WriteDocs(p.Stdout, root, rules)
+	}
+	return nil
+}
+
+// WriteDocs writes a markdown table of a given set of rules, sorted by rules file and then by rule id.
+// Rules files are listed relative to a given root.
+func WriteDocs(w io.Writer, root string, rules []Rule) {
+	sorted := make([]docsRule, 0, len(rules))
+	for _, rule := range rules {
+		file := rule.File
+		if rel, err := filepath.Rel(root, rule.File); err == nil {
+			file = rel
+		}
+		sorted = append(sorted, docsRule{File: filepath.ToSlash(file), Rule: rule})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].File != sorted[j].File {
+			return sorted[i].File < sorted[j].File
+		}
+		return sorted[i].Rule.ID < sorted[j].Rule.ID
+	})
+
+	fmt.Fprint(w, "| File | Rule | Kind | Severity | Files | Description |\n")
+	fmt.Fprint(w, "| --- | --- | --- | --- | --- | --- |\n")
+	for _, rule := range sorted {
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s |\n",
+			docsCell("`"+rule.File+"`"),
+			docsCell("`"+rule.Rule.ID+"`"),
+			docsCell(strings.Join(rule.Rule.Kinds(), ", ")),
+			docsCell(rule.Rule.SeverityOrDefault()),
+			docsCell(docsFiles(rule.Rule)),
+			docsCell(rule.Rule.Description),
+		)
+	}
+}
+
+type docsRule struct {
+	File string
+	Rule Rule
+}
+
+// docsFiles returns a summary of the file globs a rule includes and excludes.
+func docsFiles(rule Rule) string {
+	var tokens []string
+	if len(rule.IncludeFiles) > 0 {
+		tokens = append(tokens, "include: "+strings.Join(rule.IncludeFiles, ", "))
+	}
+	if len(rule.ExcludeFiles) > 0 {
+		tokens = append(tokens, "exclude: "+strings.Join(rule.ExcludeFiles, ", "))
+	}
+	return strings.Join(tokens, "; ")
+}
+
+// docsCell escapes a value for a markdown table cell.
+func docsCell(value string) string {
+	value = strings.Replace(value, "|", `\|`, -1)
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,50 @@
+package profanity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestProcessDocs(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FMT:
+  description: "please use the logger | not fmt"
+  includeFiles: [ "*.go" ]
+  excludeFiles: [ "*_test.go" ]
+  contains: [ "fmt.Println" ]
+GO_FMT:
+  description: "please run gofmt"
+  severity: warn
+  goFmt: true
+`,
+		"vendor/foo/" + DefaultRulesFile: `
+SKIPPED:
+  contains: [ "foo" ]
+`,
+		"web/" + DefaultRulesFile: `
+MAX_LINES:
+  description: "please break up long files"
+  maxLines: 100
+  maxBytes: 4096
+`,
+		"web/bad.go": "fmt.Println()\n",
+	})
+	defer cleanup()
+
+	stdout, stderr, err := process(root, OptDocs(true))
+	assert.Nil(err)
+	assert.Empty(stderr, "the files should not be checked")
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Len(lines, 5)
+	assert.Equal("| File | Rule | Kind | Severity | Files | Description |", lines[0])
+	assert.Equal("| `PROFANITY_RULES.yml` | `GO_FMT` | goFmt | warn |  | please run gofmt |", lines[2])
+	assert.Equal("| `PROFANITY_RULES.yml` | `NO_FMT` | contains | error | include: *.go; exclude: *_test.go | please use the logger \\| not fmt |", lines[3])
+	assert.Equal("| `web/PROFANITY_RULES.yml` | `MAX_LINES` | maxBytes, maxLines | error |  | please break up long files |", lines[4])
+	assert.NotContains(stdout, "SKIPPED")
+}
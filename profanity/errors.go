@@ -4,5 +4,16 @@ import "github.com/blend/go-sdk/ex"
 
 // Errors
 const (
-	ErrFailure ex.Class = "profanity failure"
+	ErrFailure                   ex.Class = "profanity failure"
+	ErrInvalidSeverity           ex.Class = "profanity invalid rule severity"
+	ErrInvalidFormat             ex.Class = "profanity invalid output format"
+	ErrInvalidLineEndings        ex.Class = "profanity invalid rule line endings"
+	ErrInvalidIndentation        ex.Class = "profanity invalid rule indentation"
+	ErrInvalidMessage            ex.Class = "profanity invalid rule message"
+	ErrInvalidPackageName        ex.Class = "profanity invalid rule package name pattern"
+	ErrInvalidDetectSecretsAllow ex.Class = "profanity invalid rule detect secrets allow pattern"
+	ErrInvalidDirective          ex.Class = "profanity invalid rules file directive"
+	ErrUnknownRuleKind           ex.Class = "profanity unknown rule kind"
+	ErrInvalidRuleKind           ex.Class = "profanity invalid rule kind settings"
+	ErrRuleKindUnset             ex.Class = "profanity rule kind unset"
 )
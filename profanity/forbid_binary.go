@@ -0,0 +1,70 @@
+package profanity
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+func init() {
+	RegisterRuleEvaluator("forbidBinary", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.ForbidBinary },
+		RuleFuncFunc: func(r Rule) RuleFunc { return ForbidBinary(r.MaxBinaryBytes, r.ForbidBinaryAllowExtensions...) },
+		StringFunc: func(r Rule) string {
+			token := fmt.Sprintf("[forbid binary, max bytes: %d]", r.MaxBinaryBytes)
+			if len(r.ForbidBinaryAllowExtensions) > 0 {
+				token += fmt.Sprintf(" [forbid binary allow extensions: %s]", strings.Join(r.ForbidBinaryAllowExtensions, ", "))
+			}
+			return token
+		},
+	})
+}
+
+// Binary detection settings.
+const (
+	// BinarySampleBytes is the number of bytes at the start of a file that are checked to detect if it is binary.
+	BinarySampleBytes = 8000
+	// BinaryInvalidUTF8Ratio is the ratio of invalid utf-8 bytes in the sample at which a file is binary.
+	BinaryInvalidUTF8Ratio = 0.1
+)
+
+// ForbidBinary creates a rule that fails if a binary file is larger than a given size in bytes; if the size
+// is zero or less, any binary file fails. Files with any of a given set of extensions, e.g. `.png`, are skipped.
+//
+// Files are binary if the start of the file, see `BinarySampleBytes`, has a NUL byte or is not mostly valid utf-8.
+func ForbidBinary(maxBytes int, allowExtensions ...string) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		if len(contents) <= maxBytes || hasExtension(filename, allowExtensions...) {
+			return RuleResult{OK: true}
+		}
+		if !IsBinary(contents) {
+			return RuleResult{OK: true}
+		}
+		if maxBytes > 0 {
+			return RuleResult{File: filename, Message: fmt.Sprintf("binary file: max bytes: %d, actual: %d", maxBytes, len(contents))}
+		}
+		return RuleResult{File: filename, Message: fmt.Sprintf("binary file: actual: %d", len(contents))}
+	}
+}
+
+// IsBinary returns if a corpus appears to be binary rather than text, sampling the start of the corpus.
+func IsBinary(contents []byte) bool {
+	sample := contents
+	if len(sample) > BinarySampleBytes {
+		sample = sample[:BinarySampleBytes]
+	}
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return true
+	}
+	var invalid int
+	for index := 0; index < len(sample); {
+		r, size := utf8.DecodeRune(sample[index:])
+		// a rune split by the end of the sample is not invalid.
+		if r == utf8.RuneError && size == 1 && (len(sample) == len(contents) || len(sample)-index >= utf8.UTFMax) {
+			invalid++
+		}
+		index += size
+	}
+	return invalid > 0 && float64(invalid) >= BinaryInvalidUTF8Ratio*float64(len(sample))
+}
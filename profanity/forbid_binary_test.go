@@ -0,0 +1,79 @@
+package profanity
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestIsBinary(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(IsBinary(nil))
+	assert.False(IsBinary([]byte("package foo\n")))
+	assert.False(IsBinary([]byte("héllo wörld, こんにちは\n")))
+	assert.True(IsBinary([]byte("foo\x00bar")))
+	assert.True(IsBinary([]byte{0xff, 0xfe, 0xfd, 'a', 0xfc}))
+
+	// a multi-byte rune split by the end of the sample is not invalid.
+	text := strings.Repeat("a", BinarySampleBytes-1) + "é"
+	assert.False(IsBinary([]byte(text)))
+
+	// only the sample is checked.
+	assert.False(IsBinary(append([]byte(strings.Repeat("a", BinarySampleBytes)), 0)))
+}
+
+func TestForbidBinary(t *testing.T) {
+	assert := assert.New(t)
+
+	small := []byte{0x7f, 'E', 'L', 'F', 0x00, 0x01}
+	large := append(bytes.Repeat([]byte{0x00, 0xff}, 512), small...)
+
+	ruleFunc := ForbidBinary(64, ".png")
+	assert.Nil(ok(ruleFunc("main.go", []byte(strings.Repeat("package foo\n", 100)))), "text files should pass")
+	assert.Nil(ok(ruleFunc("small.bin", small)), "binary files under the limit should pass")
+	assert.Nil(ok(ruleFunc("large.PNG", large)), "allowed extensions should pass")
+
+	res := ruleFunc("large.bin", large)
+	assert.False(res.OK)
+	assert.Equal("large.bin", res.File)
+	assert.Equal("binary file: max bytes: 64, actual: 1030", res.Message)
+
+	res = ForbidBinary(0)("small.bin", small)
+	assert.False(res.OK)
+	assert.Equal("binary file: actual: 6", res.Message)
+}
+
+func TestProcessForbidBinary(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+FORBID_BINARY:
+  description: "please dont check in binaries"
+  forbidBinary: true
+  maxBinaryBytes: 16
+  forbidBinaryAllowExtensions: [ ".png" ]
+`,
+		"README.md":  strings.Repeat("text ", 100),
+		"small.bin":  "\x00\x01\x02",
+		"large.bin":  strings.Repeat("\x00\xff", 100),
+		"image.png":  strings.Repeat("\x00\xff", 100),
+		"sub/foo.go": "package sub\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "large.bin")
+	assert.Contains(stderr, "binary file: max bytes: 16, actual: 200")
+	assert.Contains(stderr, "scanned 5 file(s), 1 violation(s) across 1 rule(s)")
+
+	rules, err := New(OptRoot(root)).RulesFromPath(filepath.Join(root, DefaultRulesFile))
+	assert.Nil(err)
+	assert.Contains(rules["FORBID_BINARY"].String(), "[forbid binary, max bytes: 16] [forbid binary allow extensions: .png]")
+}
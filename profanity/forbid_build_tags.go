@@ -0,0 +1,85 @@
+package profanity
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/build/constraint"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	RegisterRuleEvaluator("forbidBuildTags", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return len(r.ForbidBuildTags) > 0 },
+		RuleFuncFunc: func(r Rule) RuleFunc { return ForbidBuildTags(r.ForbidBuildTags...) },
+		StringFunc: func(r Rule) string {
+			return fmt.Sprintf("[forbid build tags: %s]", strings.Join(r.ForbidBuildTags, ","))
+		},
+	})
+}
+
+// ForbidBuildTags creates a rule that fails if a go file has a build constraint, either `//go:build` or `// +build`,
+// that requires any of a given set of tags, e.g. a temporary `wip` tag.
+//
+// Only the constraint region at the top of the file, i.e. the comments and blank lines before the first other line, is checked.
+// Negated tags, e.g. `!wip`, do not gate the file behind the tag and are allowed. Files that are not go files are skipped.
+func ForbidBuildTags(tags ...string) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		if filepath.Ext(filename) != ".go" {
+			return RuleResult{OK: true}
+		}
+		scanner := bufio.NewScanner(bytes.NewBuffer(contents))
+		var line int
+		for scanner.Scan() {
+			line++
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+			if !strings.HasPrefix(text, "//") {
+				break
+			}
+			if !constraint.IsGoBuild(text) && !constraint.IsPlusBuild(text) {
+				continue
+			}
+			expr, err := constraint.Parse(text)
+			if err != nil {
+				continue
+			}
+			for _, tag := range requiredBuildTags(expr) {
+				if containsString(tags, tag) {
+					return RuleResult{
+						File:    filename,
+						Line:    line,
+						Message: fmt.Sprintf("forbidden build tag: %s", tag),
+					}
+				}
+			}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+// requiredBuildTags returns the tags a build constraint expression references that are not negated.
+func requiredBuildTags(expr constraint.Expr) []string {
+	switch typed := expr.(type) {
+	case *constraint.TagExpr:
+		return []string{typed.Tag}
+	case *constraint.AndExpr:
+		return append(requiredBuildTags(typed.X), requiredBuildTags(typed.Y)...)
+	case *constraint.OrExpr:
+		return append(requiredBuildTags(typed.X), requiredBuildTags(typed.Y)...)
+	default: // *constraint.NotExpr
+		return nil
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package profanity
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestForbidBuildTags(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := ForbidBuildTags("wip", "scratch")
+
+	assert.Nil(ok(ruleFunc("allowed.go", []byte("//go:build integration && !wip\n// +build integration,!wip\n\npackage foo\n"))))
+	assert.Nil(ok(ruleFunc("none.go", []byte("// Package foo is a test.\npackage foo\n"))))
+	assert.Nil(ok(ruleFunc("body.go", []byte("package foo\n\n//go:build wip\n"))), "constraints after the package clause should be ignored")
+	assert.Nil(ok(ruleFunc("README.md", []byte("//go:build wip\n"))), "non-go files should be skipped")
+
+	res := ruleFunc("banned.go", []byte("// Copyright notice.\n\n//go:build linux && (wip || scratch)\n\npackage foo\n"))
+	assert.False(res.OK)
+	assert.Nil(res.Err)
+	assert.Equal("banned.go", res.File)
+	assert.Equal(3, res.Line)
+	assert.Equal("forbidden build tag: wip", res.Message)
+
+	res = ruleFunc("legacy.go", []byte("// +build scratch\n\npackage foo\n"))
+	assert.False(res.OK)
+	assert.Equal(1, res.Line)
+	assert.Equal("forbidden build tag: scratch", res.Message)
+}
+
+func TestProcessForbidBuildTags(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_WIP:
+  description: "please remove the wip build tag before merging"
+  forbidBuildTags: [ "wip" ]
+`,
+		"allowed.go": "//go:build integration\n\npackage foo\n",
+		"banned.go":  "//go:build wip\n\npackage foo\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "banned.go")
+	assert.Contains(stderr, "forbidden build tag: wip")
+	assert.Contains(stderr, "scanned 2 file(s), 1 violation(s) across 1 rule(s)")
+
+	rules, err := New(OptRoot(root)).RulesFromPath(filepath.Join(root, DefaultRulesFile))
+	assert.Nil(err)
+	assert.Contains(rules["NO_WIP"].String(), "[forbid build tags: wip]")
+}
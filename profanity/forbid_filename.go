@@ -0,0 +1,44 @@
+package profanity
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	RegisterRuleEvaluator("forbidFilename", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.ForbidFilename != "" },
+		RuleFuncFunc: func(r Rule) RuleFunc { return ForbidFilename(r.ForbidFilename) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[forbid filename: %s]", r.ForbidFilename) },
+	})
+	RegisterRuleEvaluator("forbidExtension", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.ForbidExtension != "" },
+		RuleFuncFunc: func(r Rule) RuleFunc { return ForbidExtension(r.ForbidExtension) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[forbid extension: %s]", r.ForbidExtension) },
+	})
+}
+
+// ForbidFilename creates a rule that fails if a file has a given base name, e.g. `Thumbs.db`.
+func ForbidFilename(name string) RuleFunc {
+	return func(filename string, _ []byte) RuleResult {
+		if filepath.Base(filename) == name {
+			return RuleResult{File: filename, Message: fmt.Sprintf("forbidden filename: %s", name)}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+// ForbidExtension creates a rule that fails if a file has a given extension, e.g. `.exe`.
+// The leading dot is optional.
+func ForbidExtension(ext string) RuleFunc {
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return func(filename string, _ []byte) RuleResult {
+		if filepath.Ext(filename) == ext {
+			return RuleResult{File: filename, Message: fmt.Sprintf("forbidden extension: %s", ext)}
+		}
+		return RuleResult{OK: true}
+	}
+}
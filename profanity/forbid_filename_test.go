@@ -0,0 +1,35 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestForbidFilename(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := ForbidFilename("Thumbs.db")
+	assert.Nil(ok(ruleFunc("foo/bar.db", nil)))
+	assert.Nil(ok(ruleFunc("foo/Thumbs.db.txt", nil)))
+
+	res := ruleFunc("foo/Thumbs.db", nil)
+	assert.False(res.OK)
+	assert.Equal("foo/Thumbs.db", res.File)
+	assert.Equal("forbidden filename: Thumbs.db", res.Message)
+}
+
+func TestForbidExtension(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, ext := range []string{".exe", "exe"} {
+		ruleFunc := ForbidExtension(ext)
+		assert.Nil(ok(ruleFunc("foo/bar.go", nil)))
+		assert.Nil(ok(ruleFunc("foo/exe", nil)))
+
+		res := ruleFunc("foo/bar.exe", nil)
+		assert.False(res.OK)
+		assert.Equal("foo/bar.exe", res.File)
+		assert.Equal("forbidden extension: .exe", res.Message)
+	}
+}
@@ -0,0 +1,52 @@
+package profanity
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+func init() {
+	RegisterRuleEvaluator("requireGeneratedMarker", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.RequireGeneratedMarker },
+		RuleFuncFunc: func(r Rule) RuleFunc { return RequireGeneratedMarker() },
+		StringFunc:   func(r Rule) string { return "[require generated marker]" },
+	})
+}
+
+// GeneratedMarkerExpr matches the standard generated file comment, see `go help generate`.
+var GeneratedMarkerExpr = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// RequireGeneratedMarker creates a rule that fails if a file does not have a
+// `// Code generated ... DO NOT EDIT.` comment before its first non-comment, non-blank text.
+// If the marker is in the file but after that text, the failure reports the line it was found on.
+func RequireGeneratedMarker() RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		scanner := bufio.NewScanner(bytes.NewBuffer(contents))
+		var line, textLine int
+		for scanner.Scan() {
+			line++
+			text := strings.TrimRight(scanner.Text(), "\r")
+			if GeneratedMarkerExpr.MatchString(text) {
+				if textLine == 0 {
+					return RuleResult{OK: true}
+				}
+				return RuleResult{
+					File:    filename,
+					Line:    line,
+					Message: "generated file marker must precede the first non-comment text",
+				}
+			}
+			if textLine == 0 {
+				if trimmed := strings.TrimSpace(text); trimmed != "" && !strings.HasPrefix(trimmed, "//") {
+					textLine = line
+				}
+			}
+		}
+		return RuleResult{
+			File:    filename,
+			Message: "missing generated file marker: \"// Code generated ... DO NOT EDIT.\"",
+		}
+	}
+}
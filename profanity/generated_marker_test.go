@@ -0,0 +1,58 @@
+package profanity
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestRequireGeneratedMarker(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := RequireGeneratedMarker()
+	assert.Nil(ok(ruleFunc("marked.gen.go", []byte("// Code generated by stringer; DO NOT EDIT.\n\npackage foo\n"))))
+	assert.Nil(ok(ruleFunc("build.gen.go", []byte("//go:build linux\n\n// Code generated by protoc-gen-go. DO NOT EDIT.\npackage foo\n"))), "the marker can follow other comments")
+	assert.Nil(ok(ruleFunc("crlf.gen.go", []byte("// Code generated by hand. DO NOT EDIT.\r\npackage foo\r\n"))))
+
+	res := ruleFunc("late.gen.go", []byte("package foo\n\n// Code generated by stringer; DO NOT EDIT.\n"))
+	assert.False(res.OK)
+	assert.Equal("late.gen.go", res.File)
+	assert.Equal(3, res.Line)
+	assert.Equal("generated file marker must precede the first non-comment text", res.Message)
+
+	res = ruleFunc("unmarked.gen.go", []byte("// Code generated by stringer.\npackage foo\n"))
+	assert.False(res.OK)
+	assert.Zero(res.Line)
+	assert.Contains(res.Message, "missing generated file marker")
+
+	assert.False(ruleFunc("empty.gen.go", nil).OK)
+}
+
+func TestProcessRequireGeneratedMarker(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+GENERATED:
+  description: "please regenerate the file with go generate"
+  includeFiles: [ "*.gen.go" ]
+  requireGeneratedMarker: true
+`,
+		"types.gen.go":    "// Code generated by stringer; DO NOT EDIT.\n\npackage foo\n",
+		"unmarked.gen.go": "package foo\n",
+		"main.go":         "package foo\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "unmarked.gen.go")
+	assert.NotContains(stderr, "types.gen.go")
+	assert.Contains(stderr, "scanned 3 file(s), 1 violation(s) across 1 rule(s)")
+
+	rules, err := New(OptRoot(root)).RulesFromPath(filepath.Join(root, DefaultRulesFile))
+	assert.Nil(err)
+	assert.Contains(rules["GENERATED"].String(), "[require generated marker]")
+}
@@ -0,0 +1,48 @@
+package profanity
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+)
+
+func init() {
+	RegisterRuleEvaluator("goFmt", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.GoFmt },
+		RuleFuncFunc: func(r Rule) RuleFunc { return GoFmt() },
+		StringFunc:   func(r Rule) string { return "[go fmt]" },
+	})
+}
+
+// GoFmt creates a rule that fails if a go file is not formatted as `gofmt` would format it.
+// Files that are not go files are skipped.
+func GoFmt() RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		if filepath.Ext(filename) != ".go" {
+			return RuleResult{OK: true}
+		}
+		formatted, err := format.Source(contents)
+		if err != nil {
+			return RuleResult{File: filename, Message: fmt.Sprintf("go fmt: %v", err)}
+		}
+		if !bytes.Equal(formatted, contents) {
+			return RuleResult{File: filename, Line: firstDifferentLine(contents, formatted), Message: "go fmt: file is not formatted"}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+// firstDifferentLine returns the 1-indexed line number of the first line that differs between two corpuses.
+func firstDifferentLine(a, b []byte) int {
+	line := 1
+	for index := 0; index < len(a) && index < len(b); index++ {
+		if a[index] != b[index] {
+			return line
+		}
+		if a[index] == '\n' {
+			line++
+		}
+	}
+	return line
+}
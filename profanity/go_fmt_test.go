@@ -0,0 +1,38 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestGoFmt(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := GoFmt()
+
+	formatted := "package foo\n\nfunc Foo() string {\n\treturn \"foo\"\n}\n"
+	assert.Nil(ok(ruleFunc("foo.go", []byte(formatted))))
+
+	res := ruleFunc("foo.go", []byte("package foo\n\nfunc Foo() string {\n    return \"foo\"\n}\n"))
+	assert.False(res.OK)
+	assert.Equal("foo.go", res.File)
+	assert.Equal(4, res.Line)
+	assert.Equal("go fmt: file is not formatted", res.Message)
+
+	res = ruleFunc("foo.go", []byte("package foo\n\nfunc Foo( {\n"))
+	assert.False(res.OK)
+	assert.Contains(res.Message, "go fmt: ")
+
+	assert.Nil(ok(ruleFunc("foo.txt", []byte("package foo\n\nfunc Foo( {\n"))))
+}
+
+func TestRuleApplyGoFmt(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := Rule{ID: "GO_FMT", GoFmt: true}
+	assert.Equal("[GO_FMT] [go fmt]", rule.String())
+	assert.Nil(ok(rule.Apply("foo.go", []byte("package foo\n"))))
+	assert.False(rule.Apply("foo.go", []byte("package  foo\n")).OK)
+	assert.Nil(ok(rule.Apply("foo.md", []byte("package  foo\n"))))
+}
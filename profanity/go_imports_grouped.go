@@ -0,0 +1,121 @@
+package profanity
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterRuleEvaluator("goImportsGrouped", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.GoImportsGrouped },
+		RuleFuncFunc: func(r Rule) RuleFunc { return GoImportsGrouped(r.GoImportsLocalPrefixes()...) },
+		StringFunc: func(r Rule) string {
+			if r.GoImportsLocalPrefix != "" {
+				return fmt.Sprintf("[go imports grouped, local prefix: %s]", r.GoImportsLocalPrefix)
+			}
+			return "[go imports grouped]"
+		},
+	})
+}
+
+// Go import groups, in the order they should appear.
+const (
+	GoImportGroupStandard = iota
+	GoImportGroupThirdParty
+	GoImportGroupLocal
+)
+
+// GoImportGroupName returns the name of an import group.
+func GoImportGroupName(group int) string {
+	switch group {
+	case GoImportGroupStandard:
+		return "standard library"
+	case GoImportGroupLocal:
+		return "local"
+	default:
+		return "third party"
+	}
+}
+
+// GoImportGroup returns the group of an import path, where local imports have any of a given set of prefixes.
+// Standard library imports are those whose first path element does not contain a dot, as with `goimports`.
+func GoImportGroup(importPath string, localPrefixes ...string) int {
+	for _, prefix := range localPrefixes {
+		if prefix != "" && strings.HasPrefix(importPath, prefix) {
+			return GoImportGroupLocal
+		}
+	}
+	firstElem := importPath
+	if index := strings.IndexByte(importPath, '/'); index >= 0 {
+		firstElem = importPath[:index]
+	}
+	if !strings.Contains(firstElem, ".") {
+		return GoImportGroupStandard
+	}
+	return GoImportGroupThirdParty
+}
+
+// GoImportsGrouped creates a rule that fails if the imports of a go file are not grouped and sorted as `goimports` would,
+// i.e. standard library imports, then third party imports, then local imports, with blank lines between the groups
+// and imports sorted within each group. Local imports are those with any of a given set of prefixes.
+// The first import out of place is reported. Files that are not go files are skipped.
+func GoImportsGrouped(localPrefixes ...string) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		if filepath.Ext(filename) != ".go" {
+			return RuleResult{OK: true}
+		}
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, filename, contents, parser.ImportsOnly|parser.ParseComments)
+		if err != nil {
+			return RuleResult{Err: err}
+		}
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.IMPORT {
+				continue
+			}
+			if message, pos := checkGoImportsGrouped(fset, genDecl, localPrefixes); message != "" {
+				return RuleResult{File: filename, Line: fset.Position(pos).Line, Message: message}
+			}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+// checkGoImportsGrouped returns a message and position for the first import in a declaration that is out of place.
+func checkGoImportsGrouped(fset *token.FileSet, decl *ast.GenDecl, localPrefixes []string) (string, token.Pos) {
+	var previousPath string
+	var previousGroup, previousEnd int
+	for index, spec := range decl.Specs {
+		importSpec := spec.(*ast.ImportSpec)
+		importPath, err := strconv.Unquote(importSpec.Path.Value)
+		if err != nil {
+			continue
+		}
+		group := GoImportGroup(importPath, localPrefixes...)
+
+		start := importSpec.Pos()
+		if importSpec.Doc != nil {
+			start = importSpec.Doc.Pos()
+		}
+		// a blank line between imports starts a new group.
+		newGroup := index == 0 || fset.Position(start).Line > previousEnd+1
+
+		switch {
+		case index == 0:
+		case !newGroup && group != previousGroup:
+			return fmt.Sprintf("go imports: %q (%s) is grouped with %s imports", importPath, GoImportGroupName(group), GoImportGroupName(previousGroup)), importSpec.Pos()
+		case newGroup && group < previousGroup:
+			return fmt.Sprintf("go imports: %q (%s) is after %s imports", importPath, GoImportGroupName(group), GoImportGroupName(previousGroup)), importSpec.Pos()
+		case !newGroup && importPath < previousPath:
+			return fmt.Sprintf("go imports: %q is not sorted; it should be before %q", importPath, previousPath), importSpec.Pos()
+		}
+		previousPath, previousGroup, previousEnd = importPath, group, fset.Position(importSpec.End()).Line
+	}
+	return "", token.NoPos
+}
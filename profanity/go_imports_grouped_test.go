@@ -0,0 +1,161 @@
+package profanity
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestGoImportGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(GoImportGroupStandard, GoImportGroup("fmt"))
+	assert.Equal(GoImportGroupStandard, GoImportGroup("net/http"))
+	assert.Equal(GoImportGroupThirdParty, GoImportGroup("github.com/blend/go-sdk/ex"))
+	assert.Equal(GoImportGroupLocal, GoImportGroup("github.com/blend/go-sdk/ex", "github.com/foo", "github.com/blend/go-sdk"))
+	assert.Equal(GoImportGroupThirdParty, GoImportGroup("golang.org/x/mod/modfile", "github.com/blend/go-sdk"))
+}
+
+func TestGoImportsGrouped(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := GoImportsGrouped("github.com/blend/go-sdk")
+
+	assert.Nil(ok(ruleFunc("grouped.go", []byte(`package foo
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+
+	// the logger is local
+	"github.com/blend/go-sdk/logger"
+)
+
+import "context"
+`))))
+	assert.Nil(ok(ruleFunc("single.go", []byte("package foo\n\nimport \"fmt\"\n"))))
+	assert.Nil(ok(ruleFunc("none.go", []byte("package foo\n"))))
+	assert.Nil(ok(ruleFunc("README.md", []byte("import (\n\t\"os\"\n\t\"fmt\"\n)\n"))), "other files should be skipped")
+	assert.Nil(ok(GoImportsGrouped()("nolocal.go", []byte(`package foo
+
+import (
+	"fmt"
+
+	"github.com/blend/go-sdk/logger"
+	"github.com/spf13/cobra"
+)
+`))), "without a local prefix all non standard library imports are third party")
+
+	testCases := [...]struct {
+		Name     string
+		Contents string
+		Line     int
+		Message  string
+	}{
+		{
+			Name: "unsorted",
+			Contents: `package foo
+
+import (
+	"os"
+	"fmt"
+)
+`,
+			Line:    5,
+			Message: `go imports: "fmt" is not sorted; it should be before "os"`,
+		},
+		{
+			Name: "ungrouped",
+			Contents: `package foo
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+)
+`,
+			Line:    5,
+			Message: `go imports: "github.com/spf13/cobra" (third party) is grouped with standard library imports`,
+		},
+		{
+			Name: "local grouped with third party",
+			Contents: `package foo
+
+import (
+	"fmt"
+
+	"github.com/blend/go-sdk/logger"
+	"github.com/spf13/cobra"
+)
+`,
+			Line:    7,
+			Message: `go imports: "github.com/spf13/cobra" (third party) is grouped with local imports`,
+		},
+		{
+			Name: "groups out of order",
+			Contents: `package foo
+
+import (
+	"github.com/spf13/cobra"
+
+	"fmt"
+)
+`,
+			Line:    6,
+			Message: `go imports: "fmt" (standard library) is after third party imports`,
+		},
+		{
+			Name: "local before third party",
+			Contents: `package foo
+
+import (
+	"github.com/blend/go-sdk/logger"
+
+	"github.com/spf13/cobra"
+)
+`,
+			Line:    6,
+			Message: `go imports: "github.com/spf13/cobra" (third party) is after local imports`,
+		},
+	}
+
+	for _, tc := range testCases {
+		res := ruleFunc(tc.Name+".go", []byte(tc.Contents))
+		assert.False(res.OK, tc.Name)
+		assert.Equal(tc.Line, res.Line, tc.Name)
+		assert.Equal(tc.Message, res.Message, tc.Name)
+	}
+
+	assert.NotNil(ruleFunc("invalid.go", []byte("not go")).Err)
+}
+
+func TestProcessGoImportsGrouped(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+GO_IMPORTS:
+  description: "please run goimports"
+  goImportsGrouped: true
+  goImportsLocalPrefix: "github.com/blend/go-sdk, github.com/blend/other"
+`,
+		"grouped.go":   "package foo\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/blend/other/bar\"\n)\n",
+		"ungrouped.go": "package foo\n\nimport (\n\t\"fmt\"\n\t\"github.com/blend/go-sdk/ex\"\n)\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "ungrouped.go")
+	assert.Contains(stderr, `"github.com/blend/go-sdk/ex" (local) is grouped with standard library imports`)
+	assert.Contains(stderr, "scanned 2 file(s), 1 violation(s) across 1 rule(s)")
+
+	rules, err := New(OptRoot(root)).RulesFromPath(filepath.Join(root, DefaultRulesFile))
+	assert.Nil(err)
+	assert.Equal([]string{"github.com/blend/go-sdk", "github.com/blend/other"}, rules["GO_IMPORTS"].GoImportsLocalPrefixes())
+	assert.Contains(rules["GO_IMPORTS"].String(), "[go imports grouped, local prefix: github.com/blend/go-sdk, github.com/blend/other]")
+}
@@ -0,0 +1,166 @@
+package profanity
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	RegisterRuleEvaluator("forbidGoModReplace", RuleEvaluatorFuncs{
+		IsSetFunc: func(r Rule) bool { return r.ForbidGoModLocalReplace || len(r.ForbidGoModReplace) > 0 },
+		RuleFuncFunc: func(r Rule) RuleFunc {
+			return ForbidGoModReplace(r.ForbidGoModLocalReplace, r.ForbidGoModReplace...)
+		},
+		StringFunc: func(r Rule) string {
+			var tokens []string
+			if r.ForbidGoModLocalReplace {
+				tokens = append(tokens, "[forbid go.mod local replace]")
+			}
+			if len(r.ForbidGoModReplace) > 0 {
+				tokens = append(tokens, fmt.Sprintf("[forbid go.mod replace: %s]", strings.Join(r.ForbidGoModReplace, ", ")))
+			}
+			return strings.Join(tokens, " ")
+		},
+	})
+}
+
+// GoModFile is the name of go module files.
+const GoModFile = "go.mod"
+
+// ForbidGoModReplace creates a rule that fails if a `go.mod` file has a `replace` directive
+// that points at a local path, if `local` is set, or that replaces a module matching any of a given set of globs.
+// Files that are not `go.mod` files are skipped.
+func ForbidGoModReplace(local bool, modules ...string) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		if filepath.Base(filename) != GoModFile {
+			return RuleResult{OK: true}
+		}
+		replaces, err := parseGoModReplaces(contents)
+		if err != nil {
+			return RuleResult{File: filename, Message: fmt.Sprintf("go.mod: %v", err)}
+		}
+		for _, replace := range replaces {
+			var message string
+			// replacements without a version are directories; see `go help mod edit`.
+			if local && replace.NewVersion == "" {
+				message = fmt.Sprintf("go.mod replace with local path: %s => %s", replace.OldPath, replace.NewPath)
+			} else if GlobAnyMatch(modules, replace.OldPath) {
+				message = fmt.Sprintf("go.mod replace of forbidden module: %s => %s", replace.OldPath, replace.NewPath)
+			}
+			if message != "" {
+				return RuleResult{File: filename, Line: replace.Line, Message: message}
+			}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+// goModReplace is a `replace` directive of a `go.mod` file.
+type goModReplace struct {
+	Line       int
+	OldPath    string
+	OldVersion string
+	NewPath    string
+	NewVersion string
+}
+
+// parseGoModReplaces parses the `replace` directives of a `go.mod` file,
+// both single line directives and those in `replace ( ... )` blocks.
+func parseGoModReplaces(contents []byte) (replaces []goModReplace, err error) {
+	var block string
+	var blockLine int
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	var line int
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if index := strings.Index(text, "//"); index >= 0 {
+			text = text[:index]
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if block != "" {
+			if len(fields) == 1 && fields[0] == ")" {
+				block = ""
+				continue
+			}
+			if block == "replace" {
+				replace, parseErr := parseGoModReplace(line, fields)
+				if parseErr != nil {
+					return nil, parseErr
+				}
+				replaces = append(replaces, replace)
+			}
+			continue
+		}
+		if len(fields) == 2 && fields[1] == "(" {
+			block, blockLine = fields[0], line
+			continue
+		}
+		if fields[0] == "replace" {
+			replace, parseErr := parseGoModReplace(line, fields[1:])
+			if parseErr != nil {
+				return nil, parseErr
+			}
+			replaces = append(replaces, replace)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if block != "" {
+		return nil, fmt.Errorf("line %d: unterminated %s block", blockLine, block)
+	}
+	return replaces, nil
+}
+
+// parseGoModReplace parses the fields of a replace directive, i.e. `old [version] => new [version]`.
+func parseGoModReplace(line int, fields []string) (replace goModReplace, err error) {
+	replace.Line = line
+	arrow := -1
+	for index, field := range fields {
+		if field == "=>" {
+			arrow = index
+			break
+		}
+	}
+	old, replacement := fields, []string(nil)
+	if arrow >= 0 {
+		old, replacement = fields[:arrow], fields[arrow+1:]
+	}
+	if arrow < 0 || len(old) < 1 || len(old) > 2 || len(replacement) < 1 || len(replacement) > 2 {
+		err = fmt.Errorf("line %d: malformed replace directive, expected: old [version] => new [version]", line)
+		return
+	}
+	if replace.OldPath, err = unquoteGoModField(line, old[0]); err != nil {
+		return
+	}
+	if len(old) == 2 {
+		replace.OldVersion = old[1]
+	}
+	if replace.NewPath, err = unquoteGoModField(line, replacement[0]); err != nil {
+		return
+	}
+	if len(replacement) == 2 {
+		replace.NewVersion = replacement[1]
+	}
+	return
+}
+
+// unquoteGoModField unquotes a `go.mod` field if it is quoted.
+func unquoteGoModField(line int, field string) (string, error) {
+	if !strings.HasPrefix(field, `"`) && !strings.HasPrefix(field, "`") {
+		return field, nil
+	}
+	value, err := strconv.Unquote(field)
+	if err != nil {
+		return "", fmt.Errorf("line %d: invalid quoted string: %s", line, field)
+	}
+	return value, nil
+}
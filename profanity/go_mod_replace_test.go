@@ -0,0 +1,103 @@
+package profanity
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+const goModReplaceTestHeader = `module github.com/blend/example
+
+go 1.13
+
+require github.com/blend/go-sdk v1.20200101.1
+`
+
+func TestForbidGoModReplace(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := ForbidGoModReplace(true, "github.com/blend/forbidden*")
+
+	assert.Nil(ok(ruleFunc("go.mod", []byte(goModReplaceTestHeader))), "no replaces should pass")
+	assert.Nil(ok(ruleFunc("go.mod", []byte(goModReplaceTestHeader+"\nreplace github.com/blend/go-sdk => github.com/fork/go-sdk v1.20200101.2\n"))), "module replaces should pass")
+	assert.Nil(ok(ruleFunc("README.md", []byte("replace github.com/blend/go-sdk => ../go-sdk\n"))), "other files should be skipped")
+
+	res := ruleFunc("go.mod", []byte(goModReplaceTestHeader+"\nreplace github.com/blend/go-sdk => ../go-sdk\n"))
+	assert.False(res.OK)
+	assert.Equal("go.mod", res.File)
+	assert.Equal(7, res.Line)
+	assert.Equal("go.mod replace with local path: github.com/blend/go-sdk => ../go-sdk", res.Message)
+
+	res = ruleFunc("go.mod", []byte(goModReplaceTestHeader+`
+replace (
+	github.com/blend/go-sdk => github.com/fork/go-sdk v1.20200101.2
+	github.com/blend/forbidden-lib v1.0.0 => github.com/fork/forbidden-lib v1.0.1
+)
+`))
+	assert.False(res.OK)
+	assert.Equal(9, res.Line)
+	assert.Equal("go.mod replace of forbidden module: github.com/blend/forbidden-lib => github.com/fork/forbidden-lib", res.Message)
+
+	assert.Nil(ok(ForbidGoModReplace(false)("go.mod", []byte(goModReplaceTestHeader+"\nreplace github.com/blend/go-sdk => ../go-sdk\n"))), "local replaces should pass if not forbidden")
+
+	res = ruleFunc("go.mod", []byte("module github.com/blend/example\nrequire (\n"))
+	assert.False(res.OK)
+	assert.True(strings.HasPrefix(res.Message, "go.mod: "), res.Message)
+}
+
+func TestProcessForbidGoModReplace(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+GO_MOD_REPLACE:
+  description: "please dont commit local replace directives"
+  forbidGoModLocalReplace: true
+`,
+		"go.mod":         goModReplaceTestHeader + "\nreplace github.com/blend/go-sdk => ../go-sdk\n",
+		"allowed/go.mod": goModReplaceTestHeader + "\nreplace github.com/blend/go-sdk => github.com/fork/go-sdk v1.20200101.2\n",
+		"main.go":        "package main\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "go.mod replace with local path: github.com/blend/go-sdk => ../go-sdk")
+	assert.Contains(stderr, "scanned 3 file(s), 1 violation(s) across 1 rule(s)")
+
+	rules, err := New(OptRoot(root)).RulesFromPath(filepath.Join(root, DefaultRulesFile))
+	assert.Nil(err)
+	assert.Contains(rules["GO_MOD_REPLACE"].String(), "[forbid go.mod local replace]")
+}
+
+func TestParseGoModReplaces(t *testing.T) {
+	assert := assert.New(t)
+
+	replaces, err := parseGoModReplaces([]byte(`module github.com/blend/example // the module
+
+require (
+	github.com/blend/go-sdk v1.20200101.1 // replace => is not a directive here
+)
+
+replace github.com/blend/go-sdk v1.20200101.1 => "../go-sdk" // local
+replace (
+	// a comment line
+	github.com/blend/other => github.com/fork/other v1.0.0
+)
+`))
+	assert.Nil(err)
+	assert.Len(replaces, 2)
+	assert.Equal(goModReplace{Line: 7, OldPath: "github.com/blend/go-sdk", OldVersion: "v1.20200101.1", NewPath: "../go-sdk"}, replaces[0])
+	assert.Equal(goModReplace{Line: 10, OldPath: "github.com/blend/other", NewPath: "github.com/fork/other", NewVersion: "v1.0.0"}, replaces[1])
+
+	_, err = parseGoModReplaces([]byte("replace github.com/blend/go-sdk ../go-sdk\n"))
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "line 1: malformed replace directive")
+
+	_, err = parseGoModReplaces([]byte("module foo\n\nreplace (\n\tfoo => ../foo\n"))
+	assert.NotNil(err)
+	assert.Equal("line 3: unterminated replace block", err.Error())
+}
@@ -7,6 +7,16 @@ import (
 	"strings"
 )
 
+func init() {
+	RegisterRuleEvaluator("importsContain", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return len(r.ImportsContain) > 0 },
+		RuleFuncFunc: func(r Rule) RuleFunc { return ImportsContainAny(r.ImportsContain...) },
+		StringFunc: func(r Rule) string {
+			return fmt.Sprintf("[go imports contain any: %s]", strings.Join(r.ImportsContain, ","))
+		},
+	})
+}
+
 // ImportsContainAny returns a profanity error if a given file contains any of a list of imports.
 func ImportsContainAny(imports ...string) RuleFunc {
 	return func(filename string, contents []byte) RuleResult {
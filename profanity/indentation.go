@@ -0,0 +1,116 @@
+package profanity
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"strings"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+func init() {
+	RegisterRuleEvaluator("indentation", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.Indentation != "" },
+		RuleFuncFunc: func(r Rule) RuleFunc { return Indentation(r.Indentation, r.IndentationWidth) },
+		StringFunc: func(r Rule) string {
+			if r.IndentationWidth > 0 {
+				return fmt.Sprintf("[indentation: %s, width: %d]", r.Indentation, r.IndentationWidth)
+			}
+			return fmt.Sprintf("[indentation: %s]", r.Indentation)
+		},
+		ValidateFunc: func(r Rule) error {
+			if !isIndentation(r.Indentation) {
+				return ex.New(ErrInvalidIndentation, ex.OptMessagef("rule: %s, file: %s, indentation: %s", r.ID, r.File, r.Indentation))
+			}
+			if r.IndentationWidth < 0 {
+				return ex.New(ErrInvalidIndentation, ex.OptMessagef("rule: %s, file: %s, indentation width: %d", r.ID, r.File, r.IndentationWidth))
+			}
+			return nil
+		},
+	})
+}
+
+// Indentation styles.
+const (
+	IndentationTabs   = "tabs"
+	IndentationSpaces = "spaces"
+)
+
+// Indentation creates a rule that fails if a line is indented with other than a given style, either `tabs` or `spaces`.
+//
+// For `tabs`, a line fails if its indentation starts with a space; spaces after the leading tabs are allowed for alignment.
+// For `spaces`, a line fails if its indentation has a tab, or if a width is given, if it is not a multiple of the width.
+// Blank lines, and for go files the lines inside multi-line raw string literals, are ignored;
+// other files with indentation sensitive strings, e.g. heredocs, should be scoped out with the rule's file globs.
+// It fails on the first offending line.
+func Indentation(style string, width int) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		var ignored map[int]bool
+		if hasExtension(filename, ".go") {
+			ignored = goRawStringLines(contents)
+		}
+		scanner := bufio.NewScanner(bytes.NewBuffer(contents))
+		var line int
+		for scanner.Scan() {
+			line++
+			text := scanner.Text()
+			if ignored[line] || strings.TrimSpace(text) == "" {
+				continue
+			}
+			indent := text[:len(text)-len(strings.TrimLeft(text, " \t"))]
+			if message := indentationMessage(style, width, indent); message != "" {
+				return RuleResult{File: filename, Line: line, Message: message}
+			}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+// indentationMessage returns the failure message for the indentation of a line, or empty if it is indented with a given style.
+func indentationMessage(style string, width int, indent string) string {
+	switch style {
+	case IndentationTabs:
+		if strings.HasPrefix(indent, " ") {
+			return "indentation: line is indented with spaces, expected tabs"
+		}
+	case IndentationSpaces:
+		if strings.Contains(indent, "\t") {
+			return "indentation: line is indented with tabs, expected spaces"
+		}
+		if width > 0 && len(indent)%width != 0 {
+			return fmt.Sprintf("indentation: line is indented with %d space(s), expected a multiple of %d", len(indent), width)
+		}
+	}
+	return ""
+}
+
+// goRawStringLines returns the lines of go source that are inside multi-line raw string literals, i.e.
+// every line of the literal after the line it starts on, as their indentation is part of the string.
+func goRawStringLines(contents []byte) map[int]bool {
+	lines := make(map[int]bool)
+	fileSet := token.NewFileSet()
+	file := fileSet.AddFile("", fileSet.Base(), len(contents))
+	var s scanner.Scanner
+	s.Init(file, contents, nil, scanner.ScanComments)
+	for {
+		pos, tok, literal := s.Scan()
+		if tok == token.EOF {
+			return lines
+		}
+		if tok != token.STRING || !strings.HasPrefix(literal, "`") {
+			continue
+		}
+		start := fileSet.Position(pos).Line
+		for line := start + 1; line <= start+strings.Count(literal, "\n"); line++ {
+			lines[line] = true
+		}
+	}
+}
+
+// isIndentation returns if a style is a known indentation style.
+func isIndentation(style string) bool {
+	return style == IndentationTabs || style == IndentationSpaces
+}
@@ -0,0 +1,104 @@
+package profanity
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestIndentationTabs(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := Indentation(IndentationTabs, 0)
+	assert.Nil(ok(ruleFunc("file.go", []byte("package foo\n\nfunc foo() {\n\tif true {\n\t\tbar()\n\t}\n    \n}\n"))))
+	assert.Nil(ok(ruleFunc("file.go", []byte("package foo\n\nvar x = map[string]int{\n\t\"a\":   1,\n\t\t  // aligned\n}\n"))))
+	assert.Nil(ok(ruleFunc("file.go", nil)))
+
+	res := ruleFunc("file.go", []byte("package foo\n\nfunc foo() {\n\tbar()\n    baz()\n  buzz()\n}\n"))
+	assert.False(res.OK)
+	assert.Equal("file.go", res.File)
+	assert.Equal(5, res.Line)
+	assert.Equal("indentation: line is indented with spaces, expected tabs", res.Message)
+
+	// the lines of multi-line raw strings in go files are ignored.
+	assert.Nil(ok(ruleFunc("file.go", []byte("package foo\n\nvar x = `\n  indented: yaml\n    nested: true\n`\n"))))
+	res = ruleFunc("file.txt", []byte("var x = `\n  indented: yaml\n`\n"))
+	assert.False(res.OK)
+	assert.Equal(2, res.Line)
+}
+
+func TestIndentationSpaces(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := Indentation(IndentationSpaces, 2)
+	assert.Nil(ok(ruleFunc("file.yml", []byte("foo:\n  bar:\n    - baz\n\n\t\nbuzz: true\n"))))
+
+	res := ruleFunc("file.yml", []byte("foo:\n  bar: true\n\tbaz: true\n"))
+	assert.False(res.OK)
+	assert.Equal(3, res.Line)
+	assert.Equal("indentation: line is indented with tabs, expected spaces", res.Message)
+
+	res = ruleFunc("file.yml", []byte("foo:\n  bar:\n     baz: true\n"))
+	assert.False(res.OK)
+	assert.Equal(3, res.Line)
+	assert.Equal("indentation: line is indented with 5 space(s), expected a multiple of 2", res.Message)
+
+	assert.Nil(ok(Indentation(IndentationSpaces, 0)("file.py", []byte("def foo():\n   return 1\n"))))
+}
+
+func TestRuleIndentation(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := Rule{ID: "SPACES", Indentation: IndentationSpaces, IndentationWidth: 4}
+	assert.Nil(rule.Validate())
+	assert.False(rule.Apply("file.py", []byte("def foo():\n\treturn 1\n")).OK)
+	assert.True(rule.Apply("file.py", []byte("def foo():\n    return 1\n")).OK)
+	assert.Contains(rule.String(), "[indentation: spaces, width: 4]")
+
+	rule = Rule{ID: "TABS", Indentation: IndentationTabs}
+	assert.Nil(rule.Validate())
+	assert.Contains(rule.String(), "[indentation: tabs]")
+
+	rule = Rule{ID: "MIXED", Indentation: "mixed"}
+	assert.True(ex.Is(rule.Validate(), ErrInvalidIndentation))
+
+	rule = Rule{ID: "NEGATIVE", Indentation: IndentationSpaces, IndentationWidth: -1}
+	assert.True(ex.Is(rule.Validate(), ErrInvalidIndentation))
+}
+
+func TestProcessIndentation(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+GO_TABS:
+  description: "please use tabs"
+  includeFiles: [ "*.go" ]
+  indentation: tabs
+YAML_SPACES:
+  description: "please use two spaces"
+  includeFiles: [ "*.yml" ]
+  indentation: spaces
+  indentationWidth: 2
+`,
+		"tabs.go":    "package foo\n\nfunc foo() {\n\tbar()\n}\n",
+		"spaces.go":  "package foo\n\nfunc foo() {\n    bar()\n}\n",
+		"config.yml": "foo:\n  bar: true\n",
+		"tabs.yml":   "foo:\n\tbar: true\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "spaces.go")
+	assert.Contains(stderr, "tabs.yml")
+	assert.NotContains(stderr, "tabs.go")
+	assert.NotContains(stderr, "config.yml")
+	assert.Contains(stderr, "scanned 4 file(s), 2 violation(s) across 2 rule(s)")
+
+	rules, err := New(OptRoot(root)).RulesFromPath(filepath.Join(root, DefaultRulesFile))
+	assert.Nil(err)
+	assert.Contains(rules["YAML_SPACES"].String(), "[indentation: spaces, width: 2]")
+}
@@ -0,0 +1,62 @@
+package profanity
+
+import (
+	"fmt"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+func init() {
+	RegisterRuleEvaluator("lineEndings", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.LineEndings != "" },
+		RuleFuncFunc: func(r Rule) RuleFunc { return LineEndings(r.LineEndings) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[line endings: %s]", r.LineEndings) },
+		ValidateFunc: func(r Rule) error {
+			if !isLineEndings(r.LineEndings) {
+				return ex.New(ErrInvalidLineEndings, ex.OptMessagef("rule: %s, file: %s, line endings: %s", r.ID, r.File, r.LineEndings))
+			}
+			return nil
+		},
+	})
+}
+
+// Line ending styles.
+const (
+	LineEndingsLF   = "lf"
+	LineEndingsCRLF = "crlf"
+)
+
+// LineEndings creates a rule that fails if a file has line endings other than a given style, either `lf` or `crlf`.
+// Files without any line endings pass.
+func LineEndings(style string) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		var found, firstLine int
+		line := 1
+		for index, b := range contents {
+			if b != '\n' {
+				continue
+			}
+			isCRLF := index > 0 && contents[index-1] == '\r'
+			if (style == LineEndingsLF && isCRLF) || (style == LineEndingsCRLF && !isCRLF) {
+				if found == 0 {
+					firstLine = line
+				}
+				found++
+			}
+			line++
+		}
+		if found > 0 {
+			return RuleResult{
+				File:    filename,
+				Line:    firstLine,
+				Message: fmt.Sprintf("line endings: found %d line ending(s) that are not %s", found, style),
+			}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+// isLineEndings returns if a style is a known line ending style.
+func isLineEndings(style string) bool {
+	return style == LineEndingsLF || style == LineEndingsCRLF
+}
@@ -0,0 +1,50 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestLineEndings(t *testing.T) {
+	assert := assert.New(t)
+
+	lf := LineEndings(LineEndingsLF)
+	assert.Nil(ok(lf("lf.txt", []byte("foo\nbar\n"))))
+	assert.Nil(ok(lf("empty.txt", nil)))
+	assert.Nil(ok(lf("none.txt", []byte("foo\rbar"))))
+
+	res := lf("crlf.txt", []byte("foo\r\nbar\r\n"))
+	assert.False(res.OK)
+	assert.Equal("crlf.txt", res.File)
+	assert.Equal(1, res.Line)
+	assert.Equal("line endings: found 2 line ending(s) that are not lf", res.Message)
+
+	res = lf("mixed.txt", []byte("foo\nbar\r\nbaz\n"))
+	assert.False(res.OK)
+	assert.Equal(2, res.Line)
+	assert.Equal("line endings: found 1 line ending(s) that are not lf", res.Message)
+
+	crlf := LineEndings(LineEndingsCRLF)
+	assert.Nil(ok(crlf("crlf.txt", []byte("foo\r\nbar\r\n"))))
+	assert.Nil(ok(crlf("none.txt", []byte("foo"))))
+
+	res = crlf("mixed.txt", []byte("\nfoo\r\nbar\n"))
+	assert.False(res.OK)
+	assert.Equal(1, res.Line)
+	assert.Equal("line endings: found 2 line ending(s) that are not crlf", res.Message)
+}
+
+func TestRuleLineEndings(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := Rule{ID: "LF", LineEndings: LineEndingsLF}
+	assert.Nil(rule.Validate())
+	assert.False(rule.Apply("crlf.txt", []byte("foo\r\n")).OK)
+	assert.True(rule.Apply("lf.txt", []byte("foo\n")).OK)
+	assert.Contains(rule.String(), "[line endings: lf]")
+
+	rule.LineEndings = "cr"
+	assert.True(ex.Is(rule.Validate(), ErrInvalidLineEndings))
+}
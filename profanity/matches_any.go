@@ -5,27 +5,88 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/blend/go-sdk/ex"
 )
 
+func init() {
+	RegisterRuleEvaluator("pattern", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return len(r.Pattern) > 0 },
+		RuleFuncFunc: func(r Rule) RuleFunc { return MatchesAnyMessage(r.Message, r.Pattern...) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[matches patterns: %s]", strings.Join(r.Pattern, ",")) },
+	})
+}
+
 // MatchesAny creates a new regex filter rule.
 // It failes if any of the expressions match.
 func MatchesAny(exprs ...string) RuleFunc {
+	return MatchesAnyMessage("", exprs...)
+}
+
+// MatchesAnyMessage creates a new regex filter rule that reports failures with a given message template.
+// It fails if any of the expressions match.
+//
+// The message is a `text/template` that is passed the named capture groups of the match,
+// along with `match`, the full matched text, and `expr`, the expression that matched,
+// e.g. `found deprecated api {{ .name }}`. If the message is empty a default message is used.
+func MatchesAnyMessage(message string, exprs ...string) RuleFunc {
 	return func(filename string, contents []byte) RuleResult {
+		var tmpl *template.Template
+		if message != "" {
+			var err error
+			if tmpl, err = template.New("message").Option("missingkey=zero").Parse(message); err != nil {
+				return RuleResult{Err: ex.New(ErrInvalidMessage, ex.OptMessagef("message: %s", message), ex.OptInner(err))}
+			}
+		}
+		regexes := make([]*regexp.Regexp, len(exprs))
+		for index, expr := range exprs {
+			regexes[index] = regexp.MustCompile(expr)
+		}
+
 		scanner := bufio.NewScanner(bytes.NewBuffer(contents))
 		var line int
 		for scanner.Scan() {
 			line++
-			for _, expr := range exprs {
-				regex := regexp.MustCompile(expr)
-				if regex.Match([]byte(scanner.Text())) {
-					return RuleResult{
-						File:    filename,
-						Line:    line,
-						Message: fmt.Sprintf("regexp match: \"%s\"", expr),
+			for index, regex := range regexes {
+				match := regex.FindSubmatch(scanner.Bytes())
+				if match == nil {
+					continue
+				}
+				res := RuleResult{
+					File:    filename,
+					Line:    line,
+					Message: fmt.Sprintf("regexp match: \"%s\"", exprs[index]),
+				}
+				if tmpl != nil {
+					rendered, err := renderMatchMessage(tmpl, regex, exprs[index], match)
+					if err != nil {
+						return RuleResult{Err: ex.New(ErrInvalidMessage, ex.OptMessagef("message: %s", message), ex.OptInner(err))}
 					}
+					res.Message = rendered
 				}
+				return res
 			}
 		}
 		return RuleResult{OK: true}
 	}
 }
+
+// renderMatchMessage renders a message template with the named capture groups of a match.
+func renderMatchMessage(tmpl *template.Template, regex *regexp.Regexp, expr string, match [][]byte) (string, error) {
+	data := map[string]string{
+		"match": string(match[0]),
+		"expr":  expr,
+	}
+	for index, name := range regex.SubexpNames() {
+		if name != "" {
+			data[name] = string(match[index])
+		}
+	}
+	buffer := new(bytes.Buffer)
+	if err := tmpl.Execute(buffer, data); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,52 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestMatchesAny(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := MatchesAny("foo[0-9]+")
+	assert.Nil(ok(ruleFunc("file.go", []byte("bar\nfoo\n"))))
+
+	res := ruleFunc("file.go", []byte("bar\nfoo12\n"))
+	assert.False(res.OK)
+	assert.Equal(2, res.Line)
+	assert.Equal(`regexp match: "foo[0-9]+"`, res.Message)
+}
+
+func TestMatchesAnyMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := MatchesAnyMessage("found deprecated api {{ .name }} in `{{ .match }}`", `ioutil\.(?P<name>[A-Za-z]+)\(`)
+	assert.Nil(ok(ruleFunc("file.go", []byte("os.ReadFile(path)\n"))))
+
+	res := ruleFunc("file.go", []byte("package foo\n\tioutil.ReadAll(r)\n"))
+	assert.False(res.OK)
+	assert.Equal(2, res.Line)
+	assert.Equal("found deprecated api ReadAll in `ioutil.ReadAll(`", res.Message)
+
+	res = MatchesAnyMessage("{{ .missing }}", "foo")("file.go", []byte("foo\n"))
+	assert.False(res.OK)
+	assert.Empty(res.Message)
+
+	res = MatchesAnyMessage("{{ .name ", "foo")("file.go", []byte("foo\n"))
+	assert.True(ex.Is(res.Err, ErrInvalidMessage))
+}
+
+func TestRulePatternMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := Rule{ID: "DEPRECATED", Pattern: []string{`ioutil\.(?P<name>[A-Za-z]+)`}, Message: "found deprecated api {{ .name }}"}
+	assert.Nil(rule.Validate())
+	res := rule.Apply("file.go", []byte("ioutil.WriteFile()\n"))
+	assert.False(res.OK)
+	assert.Equal("found deprecated api WriteFile", res.Message)
+
+	rule.Message = "{{ .name "
+	assert.True(ex.Is(rule.Validate(), ErrInvalidMessage))
+}
@@ -0,0 +1,21 @@
+package profanity
+
+import "fmt"
+
+func init() {
+	RegisterRuleEvaluator("maxBytes", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.MaxBytes > 0 },
+		RuleFuncFunc: func(r Rule) RuleFunc { return MaxBytes(r.MaxBytes) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[max bytes: %d]", r.MaxBytes) },
+	})
+}
+
+// MaxBytes creates a rule that fails if a corpus exceeds a given size in bytes.
+func MaxBytes(limit int) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		if actual := len(contents); actual > limit {
+			return RuleResult{File: filename, Message: fmt.Sprintf("max bytes: %d, actual: %d", limit, actual)}
+		}
+		return RuleResult{OK: true}
+	}
+}
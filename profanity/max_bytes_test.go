@@ -0,0 +1,22 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestMaxBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := MaxBytes(4)
+
+	assert.Nil(ok(ruleFunc("", []byte(``))))
+	assert.Nil(ok(ruleFunc("", []byte(`aaa`))))  // below
+	assert.Nil(ok(ruleFunc("", []byte(`aaaa`)))) // at
+
+	res := ruleFunc("foo.txt", []byte(`aaaaa`)) // above
+	assert.False(res.OK)
+	assert.Equal("foo.txt", res.File)
+	assert.Equal("max bytes: 4, actual: 5", res.Message)
+}
@@ -0,0 +1,90 @@
+package profanity
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+)
+
+func init() {
+	RegisterRuleEvaluator("maxFunctionLines", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.MaxFunctionLines > 0 },
+		RuleFuncFunc: func(r Rule) RuleFunc { return MaxFunctionLines(r.MaxFunctionLines) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[max function lines: %d]", r.MaxFunctionLines) },
+	})
+}
+
+// MaxFunctionLines creates a rule that fails if a go file has a function whose body exceeds a given number of lines.
+//
+// The body length is the number of lines between the opening and closing braces, and includes
+// any function literals in the body. Function literals are checked as well, and are named after the
+// function they are in, e.g. `Foo.func1`, or just numbered, e.g. `func1`, if not in a function. Files that are not go files are skipped.
+func MaxFunctionLines(limit int) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		if filepath.Ext(filename) != ".go" {
+			return RuleResult{OK: true}
+		}
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, filename, contents, 0)
+		if err != nil {
+			return RuleResult{Err: err}
+		}
+
+		var result *RuleResult
+		check := func(name string, body *ast.BlockStmt) {
+			if result != nil || body == nil {
+				return
+			}
+			start := fset.Position(body.Lbrace).Line
+			if actual := fset.Position(body.Rbrace).Line - start - 1; actual > limit {
+				result = &RuleResult{
+					File:    filename,
+					Line:    start,
+					Message: fmt.Sprintf("max function lines: %d, function: %s, actual: %d", limit, name, actual),
+				}
+			}
+		}
+
+		for _, decl := range file.Decls {
+			var name string
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok {
+				name = funcName(funcDecl)
+				check(name, funcDecl.Body)
+			}
+			// function literals are numbered in the order they appear in a declaration.
+			var literals int
+			ast.Inspect(decl, func(node ast.Node) bool {
+				if funcLit, ok := node.(*ast.FuncLit); ok {
+					literals++
+					if name != "" {
+						check(fmt.Sprintf("%s.func%d", name, literals), funcLit.Body)
+					} else {
+						check(fmt.Sprintf("func%d", literals), funcLit.Body)
+					}
+				}
+				return result == nil
+			})
+			if result != nil {
+				return *result
+			}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+// funcName returns the name of a function declaration, including the receiver type for methods, e.g. `Foo.Bar`.
+func funcName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return funcDecl.Name.Name
+	}
+	receiver := funcDecl.Recv.List[0].Type
+	if star, ok := receiver.(*ast.StarExpr); ok {
+		receiver = star.X
+	}
+	if ident, ok := receiver.(*ast.Ident); ok {
+		return ident.Name + "." + funcDecl.Name.Name
+	}
+	return funcDecl.Name.Name
+}
@@ -0,0 +1,94 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+const maxFunctionLinesFixture = `package foo
+
+func short() {
+	foo()
+	foo()
+}
+
+func (f *Foo) long() {
+	foo()
+	foo()
+	foo()
+	foo()
+}
+`
+
+const maxFunctionLinesLiteralFixture = `package foo
+
+func outer() {
+	handler := func() {
+		foo()
+	}
+	handler()
+}
+
+var handlers = []func(){
+	func() {
+		foo()
+	},
+	func() {
+		foo()
+		foo()
+		foo()
+		foo()
+		foo()
+	},
+}
+`
+
+func TestMaxFunctionLines(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := MaxFunctionLines(3)
+	assert.Nil(ok(ruleFunc("file.go", []byte("package foo\n\nfunc short() {\n\tfoo()\n}\n"))))
+	assert.Nil(ok(ruleFunc("file.txt", []byte(maxFunctionLinesFixture))), "non-go files should be skipped")
+	assert.Nil(ok(MaxFunctionLines(4)("file.go", []byte(maxFunctionLinesFixture))))
+
+	res := ruleFunc("file.go", []byte(maxFunctionLinesFixture))
+	assert.False(res.OK)
+	assert.Equal("file.go", res.File)
+	assert.Equal(8, res.Line)
+	assert.Equal("max function lines: 3, function: Foo.long, actual: 4", res.Message)
+
+	res = ruleFunc("file.go", []byte("package foo\n\nfunc broken( {\n"))
+	assert.False(res.OK)
+	assert.NotNil(res.Err)
+}
+
+func TestMaxFunctionLinesLiterals(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(ok(MaxFunctionLines(5)("file.go", []byte(maxFunctionLinesLiteralFixture))))
+
+	res := MaxFunctionLines(4)("file.go", []byte(maxFunctionLinesLiteralFixture))
+	assert.False(res.OK)
+	assert.Equal(14, res.Line)
+	assert.Equal("max function lines: 4, function: func2, actual: 5", res.Message)
+
+	// function literals are included in the length of the function they are in.
+	res = MaxFunctionLines(3)("file.go", []byte(maxFunctionLinesLiteralFixture))
+	assert.False(res.OK)
+	assert.Equal(3, res.Line)
+	assert.Equal("max function lines: 3, function: outer, actual: 4", res.Message)
+
+	res = MaxFunctionLines(0)("file.go", []byte("package foo\n\nfunc outer() {\n\tgo func() {\n\t}()\n}\n"))
+	assert.False(res.OK)
+	assert.Equal("max function lines: 0, function: outer, actual: 2", res.Message)
+}
+
+func TestRuleMaxFunctionLines(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := Rule{MaxFunctionLines: 3}
+	assert.False(rule.Apply("file.go", []byte(maxFunctionLinesFixture)).OK)
+	assert.True(rule.Apply("file.txt", []byte(maxFunctionLinesFixture)).OK)
+	assert.Contains(rule.String(), "[max function lines: 3]")
+}
@@ -0,0 +1,37 @@
+package profanity
+
+import (
+	"bytes"
+	"fmt"
+)
+
+func init() {
+	RegisterRuleEvaluator("maxLines", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.MaxLines > 0 },
+		RuleFuncFunc: func(r Rule) RuleFunc { return MaxLines(r.MaxLines) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[max lines: %d]", r.MaxLines) },
+	})
+}
+
+// MaxLines creates a rule that fails if a corpus exceeds a given number of lines.
+// A trailing line without a newline counts as a line.
+func MaxLines(limit int) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		if actual := LineCount(contents); actual > limit {
+			return RuleResult{File: filename, Line: limit + 1, Message: fmt.Sprintf("max lines: %d, actual: %d", limit, actual)}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+// LineCount returns the number of lines in a corpus.
+func LineCount(contents []byte) int {
+	if len(contents) == 0 {
+		return 0
+	}
+	lines := bytes.Count(contents, []byte("\n"))
+	if contents[len(contents)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
@@ -0,0 +1,35 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestLineCount(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, LineCount(nil))
+	assert.Equal(1, LineCount([]byte("a")))
+	assert.Equal(1, LineCount([]byte("a\n")))
+	assert.Equal(2, LineCount([]byte("a\nb")))
+	assert.Equal(2, LineCount([]byte("a\nb\n")))
+	assert.Equal(3, LineCount([]byte("\n\n\n")))
+}
+
+func TestMaxLines(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := MaxLines(2)
+
+	assert.Nil(ok(ruleFunc("", []byte(``))))
+	assert.Nil(ok(ruleFunc("", []byte("111\n"))))      // below
+	assert.Nil(ok(ruleFunc("", []byte("111\n222\n")))) // at
+	assert.Nil(ok(ruleFunc("", []byte("111\n222"))))   // at, no trailing newline
+
+	res := ruleFunc("foo.txt", []byte("111\n222\n333")) // above
+	assert.False(res.OK)
+	assert.Equal("foo.txt", res.File)
+	assert.Equal(3, res.Line)
+	assert.Equal("max lines: 2, actual: 3", res.Message)
+}
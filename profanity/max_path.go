@@ -0,0 +1,47 @@
+package profanity
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+func init() {
+	RegisterRuleEvaluator("maxPathLength", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.MaxPathLength > 0 },
+		RuleFuncFunc: func(r Rule) RuleFunc { return MaxPathLength(r.MaxPathLength) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[max path length: %d]", r.MaxPathLength) },
+	})
+	RegisterRuleEvaluator("maxPathDepth", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.MaxPathDepth > 0 },
+		RuleFuncFunc: func(r Rule) RuleFunc { return MaxPathDepth(r.MaxPathDepth) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[max path depth: %d]", r.MaxPathDepth) },
+	})
+}
+
+// MaxPathLength creates a rule that fails if a file path, relative to the root, is longer than a given number of characters.
+func MaxPathLength(limit int) RuleFunc {
+	return func(filename string, _ []byte) RuleResult {
+		if actual := utf8.RuneCountInString(filepath.Clean(filename)); actual > limit {
+			return RuleResult{File: filename, Message: fmt.Sprintf("max path length: %d, actual: %d", limit, actual)}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+// MaxPathDepth creates a rule that fails if a file is nested in more than a given number of directories below the root;
+// files in the root have a depth of zero.
+func MaxPathDepth(limit int) RuleFunc {
+	return func(filename string, _ []byte) RuleResult {
+		if actual := PathDepth(filename); actual > limit {
+			return RuleResult{File: filename, Message: fmt.Sprintf("max path depth: %d, actual: %d", limit, actual)}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+// PathDepth returns the number of directories a relative file path is nested in, e.g. 2 for `foo/bar/baz.go`.
+func PathDepth(filename string) int {
+	return strings.Count(filepath.ToSlash(filepath.Clean(filename)), "/")
+}
@@ -0,0 +1,63 @@
+package profanity
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestMaxPathLength(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := MaxPathLength(10)
+	assert.Nil(ok(ruleFunc("foo.go", nil)))
+	assert.Nil(ok(ruleFunc(filepath.Join("a", "bcdef.go"), nil)))
+
+	res := ruleFunc(filepath.Join("abc", "defghij.go"), nil)
+	assert.False(res.OK)
+	assert.Equal(filepath.Join("abc", "defghij.go"), res.File)
+	assert.Equal("max path length: 10, actual: 14", res.Message)
+}
+
+func TestMaxPathDepth(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, PathDepth("foo.go"))
+	assert.Equal(2, PathDepth(filepath.Join("foo", "bar", "baz.go")))
+	assert.Equal(1, PathDepth("./foo/bar.go"))
+
+	ruleFunc := MaxPathDepth(2)
+	assert.Nil(ok(ruleFunc("foo.go", nil)))
+	assert.Nil(ok(ruleFunc(filepath.Join("a", "b", "c.go"), nil)))
+
+	res := ruleFunc(filepath.Join("a", "b", "c", "d.go"), nil)
+	assert.False(res.OK)
+	assert.Equal("max path depth: 2, actual: 3", res.Message)
+}
+
+func TestProcessMaxPath(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+TIDY_PATHS:
+  description: "please keep paths short"
+  maxPathLength: 24
+  maxPathDepth: 2
+`,
+		"shallow.go":                 "package foo\n",
+		"a/b/shallow.go":             "package b\n",
+		"a/b/c/deep.go":              "package c\n",
+		"a/a_very_long_file_name.go": "package a\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "max path depth: 2, actual: 3")
+	assert.Contains(stderr, "max path length: 24, actual: 26")
+	assert.NotContains(stderr, "shallow.go")
+	assert.Contains(stderr, "scanned 4 file(s), 2 violation(s) across 1 rule(s)")
+}
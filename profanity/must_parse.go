@@ -0,0 +1,100 @@
+package profanity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blend/go-sdk/yaml"
+)
+
+func init() {
+	RegisterRuleEvaluator("mustParseYAML", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.MustParseYAML },
+		RuleFuncFunc: func(r Rule) RuleFunc { return MustParseYAML() },
+		StringFunc:   func(r Rule) string { return "[must parse yaml]" },
+	})
+	RegisterRuleEvaluator("mustParseJSON", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.MustParseJSON },
+		RuleFuncFunc: func(r Rule) RuleFunc { return MustParseJSON() },
+		StringFunc:   func(r Rule) string { return "[must parse json]" },
+	})
+}
+
+// YAMLExtensions are the extensions of yaml files.
+var YAMLExtensions = []string{".yml", ".yaml"}
+
+// JSONExtensions are the extensions of json files.
+var JSONExtensions = []string{".json"}
+
+// yamlErrorLine matches the line number in yaml parse errors, e.g. `yaml: line 3: mapping values are not allowed in this context`.
+var yamlErrorLine = regexp.MustCompile(`line ([0-9]+):`)
+
+// MustParseYAML creates a rule that fails if a yaml file, including each document in a multi document file, does not parse.
+// Files that are not yaml files, by extension, are skipped.
+func MustParseYAML() RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		if !hasExtension(filename, YAMLExtensions...) {
+			return RuleResult{OK: true}
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(contents))
+		for {
+			var document interface{}
+			err := decoder.Decode(&document)
+			if err == io.EOF {
+				return RuleResult{OK: true}
+			}
+			if err != nil {
+				var line int
+				if matches := yamlErrorLine.FindStringSubmatch(err.Error()); len(matches) > 1 {
+					line, _ = strconv.Atoi(matches[1])
+				}
+				return RuleResult{File: filename, Line: line, Message: fmt.Sprintf("must parse yaml: %v", err)}
+			}
+		}
+	}
+}
+
+// MustParseJSON creates a rule that fails if a json file does not parse.
+// Files that are not json files, by extension, are skipped.
+func MustParseJSON() RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		if !hasExtension(filename, JSONExtensions...) {
+			return RuleResult{OK: true}
+		}
+		var document interface{}
+		err := json.Unmarshal(contents, &document)
+		if err == nil {
+			return RuleResult{OK: true}
+		}
+		var line int
+		if typed, ok := err.(*json.SyntaxError); ok {
+			line = lineOfOffset(contents, typed.Offset)
+		}
+		return RuleResult{File: filename, Line: line, Message: fmt.Sprintf("must parse json: %v", err)}
+	}
+}
+
+// hasExtension returns if a file has any of a given set of extensions, ignoring case.
+func hasExtension(filename string, extensions ...string) bool {
+	ext := filepath.Ext(filename)
+	for _, extension := range extensions {
+		if strings.EqualFold(ext, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// lineOfOffset returns the 1-indexed line number of a byte offset in a corpus.
+func lineOfOffset(contents []byte, offset int64) int {
+	if offset > int64(len(contents)) {
+		offset = int64(len(contents))
+	}
+	return bytes.Count(contents[:offset], []byte("\n")) + 1
+}
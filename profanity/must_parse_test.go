@@ -0,0 +1,78 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestMustParseYAML(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := MustParseYAML()
+	assert.Nil(ok(ruleFunc("valid.yml", []byte("foo: bar\nbuzz:\n  - fuzz\n"))))
+	assert.Nil(ok(ruleFunc("multi.yaml", []byte("foo: bar\n---\nbuzz: fuzz\n"))))
+	assert.Nil(ok(ruleFunc("empty.yml", nil)))
+	assert.Nil(ok(ruleFunc("not-yaml.txt", []byte("foo: bar: buzz\n"))), "other files should be skipped")
+
+	res := ruleFunc("invalid.yml", []byte("foo: bar\nbuzz: fuzz: wuzz\n"))
+	assert.False(res.OK)
+	assert.Equal("invalid.yml", res.File)
+	assert.Equal(2, res.Line)
+	assert.Contains(res.Message, "must parse yaml: ")
+
+	res = ruleFunc("invalid-document.YAML", []byte("foo: bar\n---\nbuzz:\n\t- fuzz\n"))
+	assert.False(res.OK)
+	assert.Equal(4, res.Line)
+}
+
+func TestMustParseJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := MustParseJSON()
+	assert.Nil(ok(ruleFunc("valid.json", []byte("{\n\t\"foo\": [\"bar\", 1]\n}\n"))))
+	assert.Nil(ok(ruleFunc("not-json.js", []byte("{ foo: 'bar' }"))), "other files should be skipped")
+
+	res := ruleFunc("invalid.json", []byte("{\n\t\"foo\": \"bar\",\n}\n"))
+	assert.False(res.OK)
+	assert.Equal("invalid.json", res.File)
+	assert.Equal(3, res.Line)
+	assert.Equal("must parse json: invalid character '}' looking for beginning of object key string", res.Message)
+
+	res = ruleFunc("truncated.json", []byte("{\n\t\"foo\": \"bar\"\n"))
+	assert.False(res.OK)
+	assert.Equal(3, res.Line)
+	assert.Equal("must parse json: unexpected end of JSON input", res.Message)
+
+	res = ruleFunc("empty.json", nil)
+	assert.False(res.OK)
+	assert.Equal(1, res.Line)
+}
+
+func TestProcessMustParse(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+MUST_PARSE:
+  description: "please fix the malformed config file"
+  mustParseYAML: true
+  mustParseJSON: true
+`,
+		"valid.yml":    "foo: bar\n",
+		"invalid.yml":  "foo: bar: buzz\n",
+		"valid.json":   "{\"foo\":\"bar\"}\n",
+		"invalid.json": "{\"foo\":}\n",
+		"main.go":      "package main\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "invalid.yml")
+	assert.Contains(stderr, "must parse yaml: ")
+	assert.Contains(stderr, "invalid.json")
+	assert.Contains(stderr, "must parse json: ")
+	assert.Contains(stderr, "scanned 5 file(s), 2 violation(s) across 1 rule(s)")
+}
@@ -0,0 +1,97 @@
+package profanity
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+func init() {
+	RegisterRuleEvaluator("packageName", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.PackageName != "" },
+		RuleFuncFunc: func(r Rule) RuleFunc { return PackageName(r.PackageName) },
+		StringFunc:   func(r Rule) string { return fmt.Sprintf("[package name: %s]", r.PackageName) },
+		ValidateFunc: func(r Rule) error {
+			if _, err := regexp.Compile(r.PackageName); err != nil {
+				return ex.New(ErrInvalidPackageName, ex.OptMessagef("rule: %s, file: %s, package name: %s", r.ID, r.File, r.PackageName), ex.OptInner(err))
+			}
+			return nil
+		},
+	})
+	RegisterRuleEvaluator("packageNameMatchesDir", RuleEvaluatorFuncs{
+		IsSetFunc:    func(r Rule) bool { return r.PackageNameMatchesDir },
+		RuleFuncFunc: func(r Rule) RuleFunc { return PackageNameMatchesDir(r.Root) },
+		StringFunc:   func(r Rule) string { return "[package name matches dir]" },
+	})
+}
+
+// PackageName creates a rule that fails if the package clause of a go file does not match a given
+// regex pattern, e.g. `^[a-z][a-z0-9]*$` for lowercase names without underscores.
+//
+// The `_test` suffix of external test packages is trimmed before matching. Files that are
+// not go files, or that do not parse as go, are skipped.
+func PackageName(expr string) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		regex, err := regexp.Compile(expr)
+		if err != nil {
+			return RuleResult{Err: ex.New(ErrInvalidPackageName, ex.OptMessagef("package name: %s", expr), ex.OptInner(err))}
+		}
+		name, ok := goPackageName(filename, contents)
+		if !ok || regex.MatchString(name) {
+			return RuleResult{OK: true}
+		}
+		return RuleResult{
+			File:    filename,
+			Line:    1,
+			Message: fmt.Sprintf("package name: %s, pattern: %s", name, expr),
+		}
+	}
+}
+
+// PackageNameMatchesDir creates a rule that fails if the package clause of a go file is not the
+// name of the directory the file is in, e.g. `package foo` for `foo/bar.go`; `main` packages are allowed in any directory.
+//
+// The `_test` suffix of external test packages is trimmed before matching. Files that are not go files,
+// or that do not parse as go, are skipped. Directories are resolved relative to a given root, which is the directory the filenames are relative to.
+func PackageNameMatchesDir(root string) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		name, ok := goPackageName(filename, contents)
+		if !ok || name == "main" {
+			return RuleResult{OK: true}
+		}
+		dir, err := filepath.Abs(filepath.Join(root, filepath.Dir(filename)))
+		if err != nil {
+			return RuleResult{Err: err}
+		}
+		if expected := filepath.Base(dir); name != expected {
+			return RuleResult{
+				File:    filename,
+				Line:    1,
+				Message: fmt.Sprintf("package name: %s, expected: %s", name, expected),
+			}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+// goPackageName returns the package name of a go file, with the `_test` suffix
+// of external test packages trimmed, and if the file is a go file that parses.
+func goPackageName(filename string, contents []byte) (string, bool) {
+	if filepath.Ext(filename) != ".go" {
+		return "", false
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), filename, contents, parser.PackageClauseOnly)
+	if err != nil {
+		return "", false
+	}
+	name := file.Name.Name
+	if strings.HasSuffix(filename, GoTestFileSuffix) {
+		name = strings.TrimSuffix(name, "_test")
+	}
+	return name, true
+}
@@ -0,0 +1,79 @@
+package profanity
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestPackageName(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := PackageName("^[a-z][a-z0-9]*$")
+	assert.Nil(ok(ruleFunc("foo.go", []byte("// Package foo is a test.\npackage foo\n"))))
+	assert.Nil(ok(ruleFunc("foo_test.go", []byte("package foo_test\n"))), "external test packages should be trimmed")
+	assert.Nil(ok(ruleFunc("README.md", []byte("package Foo_Bar\n"))), "non-go files should be skipped")
+	assert.Nil(ok(ruleFunc("broken.go", []byte("not go\n"))), "files that do not parse should be skipped")
+
+	res := ruleFunc("foo.go", []byte("package foo_bar\n"))
+	assert.False(res.OK)
+	assert.Nil(res.Err)
+	assert.Equal("foo.go", res.File)
+	assert.Equal("package name: foo_bar, pattern: ^[a-z][a-z0-9]*$", res.Message)
+
+	res = PackageName("(")("foo.go", []byte("package foo\n"))
+	assert.True(ex.Is(res.Err, ErrInvalidPackageName))
+}
+
+func TestPackageNameMatchesDir(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		"foo/foo.go":       "package foo\n",
+		"foo/foo_test.go":  "package foo_test\n",
+		"foo/bar.go":       "package bar\n",
+		"cmd/tool/main.go": "package main\n",
+	})
+	defer cleanup()
+
+	ruleFunc := PackageNameMatchesDir(root)
+	assert.Nil(ok(ruleFunc(filepath.Join("foo", "foo.go"), []byte("package foo\n"))))
+	assert.Nil(ok(ruleFunc(filepath.Join("foo", "foo_test.go"), []byte("package foo_test\n"))))
+	assert.Nil(ok(ruleFunc(filepath.Join("cmd", "tool", "main.go"), []byte("package main\n"))), "main packages should be skipped")
+
+	res := ruleFunc(filepath.Join("foo", "bar.go"), []byte("package bar\n"))
+	assert.False(res.OK)
+	assert.Equal("package name: bar, expected: foo", res.Message)
+}
+
+func TestProcessPackageName(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+PACKAGE_NAMES:
+  description: "please use lowercase package names without underscores"
+  packageName: "^[a-z][a-z0-9]*$"
+  packageNameMatchesDir: true
+`,
+		"foo/foo.go":     "package foo\n",
+		"foo/bar.go":     "package bar\n",
+		"foo/broken.go":  "this is not go\n",
+		"baz_qux/baz.go": "package baz_qux\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "package name: bar, expected: foo")
+	assert.Contains(stderr, "package name: baz_qux, pattern: ^[a-z][a-z0-9]*$")
+	assert.Contains(stderr, "scanned 4 file(s), 2 violation(s) across 1 rule(s)")
+
+	rules, err := New(OptRoot(root)).RulesFromPath(filepath.Join(root, DefaultRulesFile))
+	assert.Nil(err)
+	assert.Contains(rules["PACKAGE_NAMES"].String(), "[package name: ^[a-z][a-z0-9]*$] [package name matches dir]")
+
+	assert.True(ex.Is(Rule{PackageName: "("}.Validate(), ErrInvalidPackageName))
+}
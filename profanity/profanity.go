@@ -1,12 +1,12 @@
 package profanity
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/blend/go-sdk/ansi"
 	"github.com/blend/go-sdk/ex"
@@ -33,7 +33,12 @@ type Profanity struct {
 }
 
 // Printf writes to the output stream.
+// If the output format is `jsonl`, stdout is reserved for violations and this writes to the error output stream.
 func (p *Profanity) Printf(format string, args ...interface{}) {
+	if p.Config.FormatOrDefault() == FormatJSONL {
+		p.Errorf(format, args...)
+		return
+	}
 	if p.Stdout != nil {
 		fmt.Fprintf(p.Stdout, format, args...)
 	}
@@ -41,13 +46,26 @@ func (p *Profanity) Printf(format string, args ...interface{}) {
 
 // Errorf writes to the error output stream.
 func (p *Profanity) Errorf(format string, args ...interface{}) {
-	if p.Stdout != nil {
+	if p.Stderr != nil {
 		fmt.Fprintf(p.Stderr, format, args...)
 	}
 }
 
 // Process processes the profanity rules.
+//
+// It walks the config root, which defaults to the working directory, reading the rules file of each directory;
+// file paths are relative to the root, both for matching rules and in reported violations.
+// If the docs option is set, the rules are documented with `Docs` instead.
 func (p *Profanity) Process() error {
+	switch p.Config.FormatOrDefault() {
+	case FormatText, FormatJSONL:
+	default:
+		return ex.New(ErrInvalidFormat, ex.OptMessagef("format: %s", p.Config.Format))
+	}
+	if p.Config.DocsOrDefault() {
+		return p.Docs()
+	}
+
 	if p.Config.VerboseOrDefault() {
 		p.Printf("using rules file: %s\n", p.Config.RulesFileOrDefault())
 	}
@@ -62,26 +80,52 @@ func (p *Profanity) Process() error {
 	}
 
 	var didError bool
+	var warnings, baselined int
+	var stats Stats
+
+	// if the baseline file does not exist yet, the violations found are recorded to it.
+	var baseline *Baseline
+	var recordBaseline bool
+	if p.Config.Baseline != "" {
+		var err error
+		baseline, err = ReadBaseline(p.Config.Baseline)
+		if os.IsNotExist(err) {
+			baseline, recordBaseline = new(Baseline), true
+		} else if err != nil {
+			return err
+		}
+		if p.Config.VerboseOrDefault() {
+			p.Printf("using baseline file: %s\n", p.Config.Baseline)
+		}
+	}
+
+	root := p.Config.RootOrDefault()
 
 	// rule cache is shared between files and directories during the full walk.
 	ruleCache := make(map[string]Rules)
 	// make sure the root rules are initialized if they exist.
-	if _, err := os.Stat("./" + p.Config.RulesFileOrDefault()); err == nil {
-		_, err = p.RulesForPathOrCached(ruleCache, ".")
+	if _, err := os.Stat(filepath.Join(root, p.Config.RulesFileOrDefault())); err == nil {
+		_, err = p.RulesForPathOrCached(ruleCache, Root)
 		if err != nil {
 			return err
 		}
 	}
 
 	var fileBase string
-	if err := filepath.Walk(".", func(file string, info os.FileInfo, err error) error {
+	if err := filepath.Walk(root, func(fullPath string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if info.IsDir() && strings.HasSuffix(file, ".git") { // don't ever process git directories
+		// file is the path relative to the root, and is what rules are evaluated against.
+		file, err := filepath.Rel(root, fullPath)
+		if err != nil {
+			return ex.New(err)
+		}
+
+		if info.IsDir() && file != Root && p.ShouldSkipDir(info.Name()) {
 			if p.Config.VerboseOrDefault() {
-				p.Printf("%s ... skipping (is .git dir)\n", ansi.LightWhite(file))
+				p.Printf("%s ... skipping (is skipped dir)\n", ansi.LightWhite(file))
 			}
 			return filepath.SkipDir
 		}
@@ -121,16 +165,17 @@ func (p *Profanity) Process() error {
 			return nil
 		}
 
-		fullPath := filepath.Dir(file)
-		rules, err := p.RulesForPathOrCached(ruleCache, fullPath)
+		rules, err := p.RulesForPathOrCached(ruleCache, filepath.Dir(file))
 		if err != nil {
 			return err
 		}
 
-		contents, err := ioutil.ReadFile(file)
+		contents, err := ioutil.ReadFile(fullPath)
 		if err != nil {
 			return err
 		}
+		stats.AddFile()
+		suppressions := ParseSuppressions(contents)
 
 		for _, rule := range rules {
 			if matches := rule.ShouldInclude(file); !matches {
@@ -150,17 +195,46 @@ func (p *Profanity) Process() error {
 			if p.Config.VerboseOrDefault() {
 				p.Printf("%s ... checking rule %s\n", ansi.LightWhite(file), rule.ID)
 			}
-			if res := rule.Apply(file, contents); !res.OK {
-				didError = true
-
+			res, suppressed := suppressions.Apply(rule, file, contents)
+			if suppressed > 0 {
+				if p.Config.VerboseOrDefault() {
+					p.Printf("%s ... skipping rule %s failure(s) (suppressed inline)\n", ansi.LightWhite(file), rule.ID)
+				}
+				stats.AddSuppressed(suppressed)
+			}
+			if !res.OK {
 				// check if there was an error with the rule ...
 				if res.Err != nil {
 					return res.Err
 				}
 
+				if baseline != nil {
+					violation := NewBaselineViolation(file, rule, res, contents)
+					if recordBaseline {
+						baseline.Add(violation)
+						baselined++
+						continue
+					}
+					if baseline.Has(violation) {
+						if p.Config.VerboseOrDefault() {
+							p.Printf("%s ... skipping rule %s failure (in baseline)\n", ansi.LightWhite(file), rule.ID)
+						}
+						baselined++
+						continue
+					}
+				}
+
 				// handle the failure
 				failure := res.Failure(rule)
-				p.Errorf("%v\n", failure)
+				if err := p.Report(file, rule, res, failure); err != nil {
+					return err
+				}
+				stats.AddViolation(rule)
+				if rule.IsWarning() {
+					warnings++
+					continue
+				}
+				didError = true
 				if p.Config.FailFastOrDefault() {
 					return failure
 				}
@@ -173,8 +247,23 @@ func (p *Profanity) Process() error {
 
 		return nil
 	}); err != nil {
+		if didError { // the run failed fast
+			p.Summary(stats)
+		}
 		return err
 	}
+	p.Summary(stats)
+	if recordBaseline {
+		if err := WriteBaseline(p.Config.Baseline, baseline); err != nil {
+			return err
+		}
+		p.Printf("profanity %s\n", ansi.Yellow(fmt.Sprintf("recorded %d violation(s) to baseline %s", baselined, p.Config.Baseline)))
+	} else if baselined > 0 {
+		p.Printf("profanity %s\n", ansi.Yellow(fmt.Sprintf("suppressed %d baseline violation(s)", baselined)))
+	}
+	if warnings > 0 {
+		p.Printf("profanity %s\n", ansi.Yellow(fmt.Sprintf("found %d warning(s)", warnings)))
+	}
 	if didError {
 		p.Printf("profanity %s!\n", ansi.Red("failed"))
 		return ErrFailure
@@ -183,6 +272,61 @@ func (p *Profanity) Process() error {
 	return nil
 }
 
+// Report writes a rule failure in the configured output format.
+//
+// Failures are written to the error output stream as text, or streamed to
+// the output stream as a json object per line for the `jsonl` format.
+func (p *Profanity) Report(file string, rule Rule, res RuleResult, failure error) error {
+	if p.Config.FormatOrDefault() != FormatJSONL {
+		p.Errorf("%v\n", failure)
+		return nil
+	}
+	if p.Stdout == nil {
+		return nil
+	}
+	contents, err := json.Marshal(NewViolation(file, rule, res))
+	if err != nil {
+		return ex.New(err)
+	}
+	if _, err = p.Stdout.Write(append(contents, '\n')); err != nil {
+		return ex.New(err)
+	}
+	return nil
+}
+
+// ShouldSkipDir returns if a directory with a given base name should be skipped.
+func (p *Profanity) ShouldSkipDir(name string) bool {
+	for _, skipDir := range p.Config.SkipDirsOrDefault() {
+		if name == skipDir {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary writes a summary of the stats for a run to the error output stream,
+// including the number of files each rule flagged in verbose mode.
+func (p *Profanity) Summary(stats Stats) {
+	if stats.Violations > 0 {
+		p.Errorf("profanity %s\n", ansi.Red(stats.String()))
+	} else {
+		p.Errorf("profanity %s\n", ansi.Green(stats.String()))
+	}
+	if stats.Suppressed > 0 {
+		p.Errorf("profanity %s\n", ansi.Yellow(fmt.Sprintf("suppressed %d inline violation(s)", stats.Suppressed)))
+	}
+	if p.Config.VerboseOrDefault() {
+		for _, rule := range stats.Rules() {
+			files := fmt.Sprintf("%d file(s)", stats.RuleFiles[rule])
+			if stats.RuleSeverity(rule) == SeverityWarn {
+				p.Errorf("\t%s: %s\n", ansi.LightWhite(rule), ansi.Yellow(files))
+				continue
+			}
+			p.Errorf("\t%s: %s\n", ansi.LightWhite(rule), ansi.Red(files))
+		}
+	}
+}
+
 // RulesForPathOrCached returns rules cached or rules from disk.
 // It prevents re-reading the full rules set for each file in a path.
 func (p *Profanity) RulesForPathOrCached(packageRules map[string]Rules, path string) (Rules, error) {
@@ -199,81 +343,122 @@ func (p *Profanity) RulesForPathOrCached(packageRules map[string]Rules, path str
 	return rules, nil
 }
 
-// RulesForPath adds rules in a given path and child paths to an existing rule set.
+// RulesForPath returns the rules for a given path, including the rules inherited from parent paths.
 // `workingSet` are the current working rules keyed on the path they
 // came from, including '.' for the root rules.
+//
+// If the rules file for the path sets `inherit: false`, rules from parent paths (and the root) are not
+// included for the path, or for its children.
 func (p *Profanity) RulesForPath(workingSet map[string]Rules, path string) (Rules, error) {
-	pathRules, err := p.ReadRules(path)
+	rulesFile, err := p.ReadRulesFile(path)
 	if err != nil {
 		return nil, err
 	}
-
-	for key, workingRules := range workingSet {
-		if strings.HasPrefix(path, key) && key != path {
-			if p.Config.VerboseOrDefault() {
-				p.Printf("%s including inherited rules from %s", ansi.LightWhite(path), ansi.LightWhite(key))
-			}
-			pathRules = MergeRules(workingRules, pathRules)
+	if path == Root {
+		return rulesFile.Rules, nil
+	}
+	if !rulesFile.InheritOrDefault() {
+		if p.Config.VerboseOrDefault() {
+			p.Printf("%s not including inherited rules (inherit is false)\n", ansi.LightWhite(path))
 		}
+		return rulesFile.Rules, nil
 	}
 
-	// always include rules from "." if they were set
-	if rootRules, hasRootRules := workingSet[Root]; hasRootRules && path != Root {
-		pathRules = MergeRules(rootRules, pathRules)
+	parent := filepath.Dir(path)
+	parentRules, err := p.RulesForPathOrCached(workingSet, parent)
+	if err != nil {
+		return nil, err
 	}
-
-	return pathRules, nil
+	if len(parentRules) > 0 && p.Config.VerboseOrDefault() {
+		p.Printf("%s including inherited rules from %s\n", ansi.LightWhite(path), ansi.LightWhite(parent))
+	}
+	return MergeRules(parentRules, rulesFile.Rules), nil
 }
 
 // ReadRules reads rules at a given directory path.
 // Path is meant to be the slash terminated dir, which will have the configured rule path appended to it.
 func (p *Profanity) ReadRules(path string) (Rules, error) {
+	rulesFile, err := p.ReadRulesFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return rulesFile.Rules, nil
+}
+
+// ReadRulesFile reads the rules file at a given directory path.
+// If there is no rules file at the path, an empty rules file is returned.
+func (p *Profanity) ReadRulesFile(path string) (rulesFile RulesFile, err error) {
 	if p.Config.DebugOrDefault() {
 		p.Printf("checking for profanity file: %s/%s", ansi.LightWhite(path), p.Config.RulesFileOrDefault())
 	}
-	profanityPath := filepath.Join(path, p.Config.RulesFileOrDefault())
-	if _, err := os.Stat(profanityPath); err != nil {
+	profanityPath := filepath.Join(p.Config.RootOrDefault(), path, p.Config.RulesFileOrDefault())
+	if _, statErr := os.Stat(profanityPath); statErr != nil {
 		if p.Config.VerboseOrDefault() {
 			p.Printf("%s/ local rules file not found %s\n", ansi.LightWhite(path), p.Config.RulesFileOrDefault())
 		}
-		return nil, nil
+		return
 	}
-	rules, err := p.RulesFromPath(profanityPath)
+	rulesFile, err = p.RulesFileFromPath(profanityPath)
 	if err != nil {
 		if p.Config.DebugOrDefault() {
 			p.Errorf("error reading profanity file: %s/%s %v", ansi.LightWhite(path), p.Config.RulesFileOrDefault(), err)
 		}
-		return nil, err
+		return
 	}
-	return rules, nil
+	return
 }
 
 // RulesFromPath reads rules from a path
 func (p *Profanity) RulesFromPath(path string) (rules Rules, err error) {
+	rulesFile, err := p.RulesFileFromPath(path)
+	if err != nil {
+		return
+	}
+	rules = rulesFile.Rules
+	return
+}
+
+// RulesFileFromPath reads a rules file from a path.
+func (p *Profanity) RulesFileFromPath(path string) (rulesFile RulesFile, err error) {
 	contents, readErr := os.Open(path)
 	if readErr != nil {
 		err = ex.New(readErr, ex.OptMessagef("file: %s", path))
 		return
 	}
 	defer contents.Close()
-	rules, err = p.RulesFromReader(path, contents)
+	rulesFile, err = p.RulesFileFromReader(path, contents)
 	return
 }
 
 // RulesFromReader reads rules from a reader.
 func (p *Profanity) RulesFromReader(path string, reader io.Reader) (rules Rules, err error) {
-	var fileRules Rules
-	yamlErr := yaml.NewDecoder(reader).Decode(&fileRules)
+	rulesFile, err := p.RulesFileFromReader(path, reader)
+	if err != nil {
+		return
+	}
+	rules = rulesFile.Rules
+	return
+}
+
+// RulesFileFromReader reads a rules file from a reader.
+func (p *Profanity) RulesFileFromReader(path string, reader io.Reader) (rulesFile RulesFile, err error) {
+	var fileContents RulesFile
+	yamlErr := yaml.NewDecoder(reader).Decode(&fileContents)
 	if yamlErr != nil {
 		err = ex.New("cannot unmarshal rules file", ex.OptMessagef("file: %s", path), ex.OptInnerClass(yamlErr))
 		return
 	}
-	rules = make(Rules)
-	for id, fileRule := range fileRules {
+	rulesFile.Inherit = fileContents.Inherit
+	rulesFile.Rules = make(Rules)
+	for id, fileRule := range fileContents.Rules {
 		rule := fileRule
 		rule.ID = id
 		rule.File = path
-		rules[id] = rule
+		rule = rule.withRoot(p.Config.RootOrDefault())
+		if err = rule.Validate(); err != nil {
+			return
+		}
+		rulesFile.Rules[id] = rule
 	}
 	return
 }
@@ -1,9 +1,17 @@
 package profanity
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/blend/go-sdk/ansi"
 	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
 )
 
 func TestProfanityRulesFromPath(t *testing.T) {
@@ -29,3 +37,496 @@ func TestProfanityReadRules(t *testing.T) {
 	assert.Nil(err)
 	assert.NotEmpty(rules)
 }
+
+// fixtures writes a set of files (keyed by slash separated relative path) to a temp dir
+// and returns the temp dir path and a cleanup function.
+func fixtures(t *testing.T, files map[string]string) (string, func()) {
+	t.Helper()
+	root, err := ioutil.TempDir("", "profanity")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, contents := range files {
+		fullPath := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(fullPath, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root, func() { os.RemoveAll(root) }
+}
+
+// process runs profanity against a fixture root returning the stdout and stderr output and the error.
+func process(root string, options ...ConfigOption) (stdout, stderr string, err error) {
+	engine := New(append([]ConfigOption{OptRoot(root)}, options...)...)
+	stdoutBuffer, stderrBuffer := new(bytes.Buffer), new(bytes.Buffer)
+	engine.Stdout = stdoutBuffer
+	engine.Stderr = stderrBuffer
+	err = engine.Process()
+	stdout, stderr = stdoutBuffer.String(), stderrBuffer.String()
+	return
+}
+
+func TestProfanityErrorf(t *testing.T) {
+	assert := assert.New(t)
+
+	// errors are written if stderr is set, regardless of stdout.
+	stderr := new(bytes.Buffer)
+	p := &Profanity{Stderr: stderr}
+	p.Errorf("error: %s\n", "foo")
+	assert.Equal("error: foo\n", stderr.String())
+
+	// an unset stderr discards errors rather than panicking if stdout is set.
+	stdout := new(bytes.Buffer)
+	p = &Profanity{Stdout: stdout}
+	p.Errorf("error: %s\n", "foo")
+	assert.Empty(stdout.String())
+}
+
+func TestProcessRoot(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FOO:
+  description: "no foo"
+  contains: [ "foo" ]
+`,
+		"ok.txt":         "bar\n",
+		"nested/bad.txt": "foo\n",
+		"nested/" + DefaultRulesFile: `
+NO_BUZZ:
+  description: "no buzz"
+  includeFiles: [ "nested/*" ]
+  contains: [ "buzz" ]
+`,
+		"nested/buzz.txt": "buzz\n",
+	})
+	defer cleanup()
+
+	// the root is walked rather than the working directory, and paths are relative to it.
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, filepath.Join("nested", "bad.txt"))
+	assert.Contains(stderr, filepath.Join("nested", "buzz.txt"), "nested rules files should be read relative to the root")
+	assert.Contains(stderr, "NO_BUZZ")
+	assert.NotContains(stderr, "ok.txt")
+	assert.NotContains(stderr, root)
+}
+
+func TestProcessSeverity(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FOO:
+  description: "no foo"
+  severity: warn
+  contains: [ "foo" ]
+NO_BAR:
+  description: "no bar"
+  contains: [ "bar" ]
+`,
+		"foo.txt": "foo\n",
+	})
+	defer cleanup()
+
+	stdout, stderr, err := process(root)
+	assert.Nil(err, "warnings alone should not fail the run")
+	assert.Contains(stderr, ansi.Yellow("warning"))
+	assert.Contains(stdout, "found 1 warning(s)")
+
+	assert.Nil(ioutil.WriteFile(filepath.Join(root, "bar.txt"), []byte("bar\n"), 0644))
+	stdout, stderr, err = process(root)
+	assert.True(ex.Is(err, ErrFailure), "errors should fail the run")
+	assert.Contains(stderr, ansi.Red("failed"))
+	assert.Contains(stdout, "found 1 warning(s)")
+}
+
+func TestProcessSeverityFailFast(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FOO:
+  severity: warn
+  contains: [ "foo" ]
+`,
+		"a.txt": "foo\n",
+		"b.txt": "foo\n",
+	})
+	defer cleanup()
+
+	stdout, _, err := process(root, OptFailFast(true))
+	assert.Nil(err, "warnings should not trigger fail fast")
+	assert.Contains(stdout, "found 2 warning(s)")
+}
+
+func TestProcessSeverityInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FOO:
+  severity: critical
+  contains: [ "foo" ]
+`,
+	})
+	defer cleanup()
+
+	_, _, err := process(root)
+	assert.True(ex.Is(err, ErrInvalidSeverity))
+}
+
+func TestProcessMaxSize(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+MAX_BYTES:
+  description: "no big files"
+  includeFiles: [ "*.txt" ]
+  excludeFiles: [ "*_generated.txt" ]
+  maxBytes: 8
+MAX_LINES:
+  description: "no long files"
+  includeFiles: [ "*.md" ]
+  maxLines: 2
+`,
+		"below.txt":         "1234567",
+		"at.txt":            "12345678",
+		"above.txt":         "123456789",
+		"big_generated.txt": "123456789",
+		"below.md":          "1\n",
+		"at.md":             "1\n2\n",
+		"above.md":          "1\n2\n3\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "above.txt")
+	assert.Contains(stderr, "max bytes: 8, actual: 9")
+	assert.Contains(stderr, "above.md")
+	assert.Contains(stderr, "max lines: 2, actual: 3")
+	assert.NotContains(stderr, "below.")
+	assert.NotContains(stderr, "at.")
+	assert.NotContains(stderr, "big_generated.txt")
+}
+
+func TestProcessSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FOO:
+  description: "no foo"
+  contains: [ "foo" ]
+NO_BAR:
+  description: "no bar"
+  contains: [ "bar" ]
+NO_BUZZ:
+  description: "no buzz"
+  contains: [ "buzz" ]
+WARN_FIZZ:
+  description: "no fizz"
+  severity: warn
+  contains: [ "fizz" ]
+`,
+		"ok.txt":          "ok\n",
+		"foo.txt":         "foo\n",
+		"foo_bar.txt":     "foo bar fizz\n",
+		"nested/foo.txt":  "foo\n",
+		"nested/fine.txt": "fine\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, ansi.Red("scanned 5 file(s), 5 violation(s) across 3 rule(s)"))
+	assert.NotContains(stderr, "NO_FOO: ")
+
+	_, stderr, err = process(root, OptVerbose(true))
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, ansi.Red("scanned 5 file(s), 5 violation(s) across 3 rule(s)"))
+	assert.Contains(stderr, ansi.LightWhite("NO_FOO")+": "+ansi.Red("3 file(s)"))
+	assert.Contains(stderr, ansi.LightWhite("NO_BAR")+": "+ansi.Red("1 file(s)"))
+	assert.Contains(stderr, ansi.LightWhite("WARN_FIZZ")+": "+ansi.Yellow("1 file(s)"), "warning only rules should use the warning color")
+	assert.NotContains(stderr, "NO_BUZZ: ")
+}
+
+func TestProcessSummaryOK(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FOO:
+  description: "no foo"
+  contains: [ "foo" ]
+`,
+		"ok.txt":     "ok\n",
+		"nested.txt": "fine\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.Nil(err)
+	assert.Contains(stderr, ansi.Green("scanned 2 file(s), 0 violation(s) across 0 rule(s)"))
+}
+
+func TestProcessSkipDirs(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FOO:
+  description: "no foo"
+  contains: [ "foo" ]
+`,
+		"ok.txt":                      "bar\n",
+		"vendor/bad.txt":              "foo\n",
+		"nested/node_modules/bad.txt": "foo\n",
+		"_bin/bad.txt":                "foo\n",
+		"walked/bad.txt":              "foo\n",
+		".git/bad.txt":                "foo\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, filepath.Join("walked", "bad.txt"))
+	assert.Contains(stderr, "scanned 2 file(s)")
+	assert.NotContains(stderr, "vendor")
+	assert.NotContains(stderr, "node_modules")
+	assert.NotContains(stderr, "_bin")
+
+	_, stderr, err = process(root, OptSkipDirs("walked", "node_modules"))
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, filepath.Join("vendor", "bad.txt"))
+	assert.Contains(stderr, filepath.Join("_bin", "bad.txt"))
+	assert.Contains(stderr, "scanned 3 file(s)")
+	assert.NotContains(stderr, "walked")
+	assert.NotContains(stderr, "node_modules")
+	assert.NotContains(stderr, ".git", "the git directory should always be skipped")
+}
+
+func TestProcessInherit(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FOO:
+  description: "no foo"
+  contains: [ "foo" ]
+`,
+		"ok.txt":                  "bar\n",
+		"nested/deep/bad.txt":     "foo\n",
+		"third_party/ok.txt":      "foo\n",
+		"third_party/lib/ok.txt":  "foo\n",
+		"third_party/lib/bad.txt": "bar\n",
+		"third_party/" + DefaultRulesFile: `
+inherit: false
+NO_BAR:
+  description: "no bar"
+  contains: [ "bar" ]
+`,
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, filepath.Join("nested", "deep", "bad.txt"))
+	assert.Contains(stderr, filepath.Join("third_party", "lib", "bad.txt"))
+	assert.NotContains(stderr, "ok.txt")
+	assert.Contains(stderr, "scanned 5 file(s), 2 violation(s) across 2 rule(s)")
+}
+
+func TestProfanityRulesFileFromReaderDirectives(t *testing.T) {
+	assert := assert.New(t)
+
+	profanity := New()
+	rulesFile, err := profanity.RulesFileFromReader("rules.yml", strings.NewReader(`
+NO_FOO:
+  contains: [ "foo" ]
+`))
+	assert.Nil(err)
+	assert.True(rulesFile.InheritOrDefault())
+	assert.Len(rulesFile.Rules, 1)
+
+	rulesFile, err = profanity.RulesFileFromReader("rules.yml", strings.NewReader(`
+inherit: false
+NO_FOO:
+  contains: [ "foo" ]
+`))
+	assert.Nil(err)
+	assert.False(rulesFile.InheritOrDefault())
+	assert.Len(rulesFile.Rules, 1)
+	assert.Equal("NO_FOO", rulesFile.Rules["NO_FOO"].ID)
+
+	_, err = profanity.RulesFileFromReader("rules.yml", strings.NewReader(`
+NO_FOO: true
+`))
+	assert.True(ex.Is(err, ex.Class("cannot unmarshal rules file")))
+	assert.True(ex.Is(ex.ErrInner(err), ErrInvalidDirective))
+	assert.Equal("unknown directive: NO_FOO", ex.ErrMessage(ex.ErrInner(err)))
+}
+
+func TestProcessForbidFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	rules := `
+NO_THUMBS:
+  description: "no thumbnail caches"
+  forbidFilename: "Thumbs.db"
+NO_EXE:
+  description: "no binaries"
+  forbidExtension: ".exe"
+  excludeFiles: [ "testdata/*" ]
+`
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile:       rules,
+		"ok.txt":               "ok\n",
+		"nested/ok.go":         "package nested\n",
+		"testdata/fixture.exe": "binary\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.Nil(err)
+	assert.NotContains(stderr, "forbidden")
+
+	root, cleanup = fixtures(t, map[string]string{
+		DefaultRulesFile:       rules,
+		"ok.txt":               "ok\n",
+		"nested/Thumbs.db":     "cache\n",
+		"bin/tool.exe":         "binary\n",
+		"testdata/fixture.exe": "binary\n",
+	})
+	defer cleanup()
+
+	_, stderr, err = process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, filepath.Join("nested", "Thumbs.db"))
+	assert.Contains(stderr, "forbidden filename: Thumbs.db")
+	assert.Contains(stderr, filepath.Join("bin", "tool.exe"))
+	assert.Contains(stderr, "forbidden extension: .exe")
+	assert.NotContains(stderr, "fixture.exe")
+}
+
+func TestProcessBaseline(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FOO:
+  description: "no foo"
+  contains: [ "foo" ]
+`,
+		"ok.txt":  "bar\n",
+		"old.txt": "bar\nfoo\n",
+	})
+	defer cleanup()
+
+	baselineDir, err := ioutil.TempDir("", "profanity-baseline")
+	assert.Nil(err)
+	defer os.RemoveAll(baselineDir)
+	baselinePath := filepath.Join(baselineDir, "baseline.yml")
+
+	// the first run records the existing violations.
+	stdout, _, err := process(root, OptBaseline(baselinePath))
+	assert.Nil(err)
+	assert.Contains(stdout, "recorded 1 violation(s) to baseline")
+	baseline, err := ReadBaseline(baselinePath)
+	assert.Nil(err)
+	assert.Len(baseline.Violations, 1)
+	assert.Equal("old.txt", baseline.Violations[0].File)
+	assert.Equal("NO_FOO", baseline.Violations[0].Rule)
+
+	// the unchanged violation is suppressed, even as the line moves.
+	assert.Nil(ioutil.WriteFile(filepath.Join(root, "old.txt"), []byte("bar\nbar\n  foo\n"), 0644))
+	stdout, stderr, err := process(root, OptBaseline(baselinePath))
+	assert.Nil(err)
+	assert.Contains(stdout, "suppressed 1 baseline violation(s)")
+	assert.NotContains(stderr, "old.txt")
+
+	// new violations fail the run.
+	assert.Nil(ioutil.WriteFile(filepath.Join(root, "new.txt"), []byte("foo\n"), 0644))
+	_, stderr, err = process(root, OptBaseline(baselinePath))
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "new.txt")
+	assert.NotContains(stderr, "old.txt")
+
+	// changing the violating line fails the run.
+	assert.Nil(os.Remove(filepath.Join(root, "new.txt")))
+	assert.Nil(ioutil.WriteFile(filepath.Join(root, "old.txt"), []byte("bar\nfoo bar\n"), 0644))
+	_, stderr, err = process(root, OptBaseline(baselinePath))
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "old.txt")
+}
+
+func TestProcessFormatJSONL(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FOO:
+  description: "no foo"
+  contains: [ "foo" ]
+NO_BAR:
+  description: "no bar"
+  severity: warn
+  contains: [ "bar" ]
+`,
+		"a.txt":        "ok\nfoo\n",
+		"b/bad.txt":    "bar\n",
+		"c.txt":        "ok\n",
+		"nested/d.txt": "foo\n",
+	})
+	defer cleanup()
+
+	stdout, stderr, err := process(root, OptFormat(FormatJSONL))
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "found 1 warning(s)")
+	assert.NotContains(stderr, "description")
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Len(lines, 3)
+	var violations []Violation
+	for _, line := range lines {
+		var violation Violation
+		assert.Nil(json.Unmarshal([]byte(line), &violation), line)
+		violations = append(violations, violation)
+	}
+	assert.Equal(Violation{File: "a.txt", Line: 2, Rule: "NO_FOO", Description: "no foo", Severity: SeverityError, Message: `contains: "foo"`}, violations[0])
+	assert.Equal(Violation{File: "b/bad.txt", Line: 1, Rule: "NO_BAR", Description: "no bar", Severity: SeverityWarn, Message: `contains: "bar"`}, violations[1])
+	assert.Equal(Violation{File: "nested/d.txt", Line: 1, Rule: "NO_FOO", Description: "no foo", Severity: SeverityError, Message: `contains: "foo"`}, violations[2])
+
+	_, _, err = process(root, OptFormat("xml"))
+	assert.True(ex.Is(err, ErrInvalidFormat))
+}
+
+func TestProcessLineEndings(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+LF_ONLY:
+  description: "use unix line endings"
+  excludeFiles: [ "*.png" ]
+  lineEndings: lf
+`,
+		"lf.txt":    "foo\nbar\n",
+		"crlf.txt":  "foo\r\nbar\r\n",
+		"image.png": "\x89PNG\r\n\x1a\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "crlf.txt")
+	assert.Contains(stderr, "found 2 line ending(s) that are not lf")
+	assert.NotContains(stderr, "image.png")
+	assert.Contains(stderr, "scanned 3 file(s), 1 violation(s)")
+}
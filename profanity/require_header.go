@@ -0,0 +1,48 @@
+package profanity
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterRuleEvaluator("requireHeaderContains", RuleEvaluatorFuncs{
+		IsSetFunc: func(r Rule) bool { return r.RequireHeaderContains != "" },
+		RuleFuncFunc: func(r Rule) RuleFunc {
+			return RequireHeaderContains(r.RequireHeaderContains, r.RequireHeaderWithinLinesOrDefault())
+		},
+		StringFunc: func(r Rule) string {
+			return fmt.Sprintf("[require header contains: %s, within lines: %d]", r.RequireHeaderContains, r.RequireHeaderWithinLinesOrDefault())
+		},
+	})
+}
+
+// RequireHeaderContains creates a rule that fails if none of the first given number of lines
+// of a file contain a given token, e.g. a copyright notice.
+// If the token is in the file but after the header, the failure reports the line it was found on.
+func RequireHeaderContains(token string, withinLines int) RuleFunc {
+	return func(filename string, contents []byte) RuleResult {
+		scanner := bufio.NewScanner(bytes.NewBuffer(contents))
+		var line int
+		for scanner.Scan() {
+			line++
+			if !strings.Contains(scanner.Text(), token) {
+				continue
+			}
+			if line <= withinLines {
+				return RuleResult{OK: true}
+			}
+			return RuleResult{
+				File:    filename,
+				Line:    line,
+				Message: fmt.Sprintf("header does not contain: %q, found on line %d, after the first %d line(s)", token, line, withinLines),
+			}
+		}
+		return RuleResult{
+			File:    filename,
+			Message: fmt.Sprintf("header does not contain: %q", token),
+		}
+	}
+}
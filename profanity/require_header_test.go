@@ -0,0 +1,58 @@
+package profanity
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestRequireHeaderContains(t *testing.T) {
+	assert := assert.New(t)
+
+	ruleFunc := RequireHeaderContains("Copyright", 3)
+	assert.Nil(ok(ruleFunc("header.go", []byte("// Copyright 2026 Blend Labs\n\npackage foo\n"))))
+	assert.Nil(ok(ruleFunc("edge.go", []byte("\n\n// Copyright 2026 Blend Labs\npackage foo\n"))), "the last header line should be checked")
+
+	res := ruleFunc("late.go", []byte("package foo\n\n\n\n// Copyright 2026 Blend Labs\n"))
+	assert.False(res.OK)
+	assert.Equal("late.go", res.File)
+	assert.Equal(5, res.Line)
+	assert.Equal(`header does not contain: "Copyright", found on line 5, after the first 3 line(s)`, res.Message)
+
+	res = ruleFunc("absent.go", []byte("package foo\n"))
+	assert.False(res.OK)
+	assert.Zero(res.Line)
+	assert.Equal(`header does not contain: "Copyright"`, res.Message)
+
+	assert.False(ruleFunc("empty.go", nil).OK)
+}
+
+func TestProcessRequireHeaderContains(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+COPYRIGHT:
+  description: "please add the copyright header"
+  includeFiles: [ "*.go" ]
+  requireHeaderContains: "Copyright"
+`,
+		"header.go": "// Copyright 2026 Blend Labs\n\npackage foo\n",
+		"late.go":   "package foo\n\n\n\n\n\n\n\n\n\n// Copyright 2026 Blend Labs\n",
+		"absent.go": "package foo\n",
+		"README.md": "no header\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "found on line 11, after the first 10 line(s)")
+	assert.Contains(stderr, "absent.go")
+	assert.Contains(stderr, "scanned 4 file(s), 2 violation(s) across 1 rule(s)")
+
+	rules, err := New(OptRoot(root)).RulesFromPath(filepath.Join(root, DefaultRulesFile))
+	assert.Nil(err)
+	assert.Contains(rules["COPYRIGHT"].String(), "[require header contains: Copyright, within lines: 10]")
+}
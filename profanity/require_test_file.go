@@ -0,0 +1,40 @@
+package profanity
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	RegisterRuleEvaluator("requireTestFile", RuleEvaluatorFuncs{
+		IsSetFunc: func(r Rule) bool { return r.RequireTestFile },
+		RuleFuncFunc: func(r Rule) RuleFunc {
+			return RequireTestFile(r.Root, r.RequireTestFileSuffixOrDefault(), r.RequireTestFileExemptOrDefault()...)
+		},
+		StringFunc: func(r Rule) string { return fmt.Sprintf("[require test file: %s]", r.RequireTestFileSuffixOrDefault()) },
+	})
+}
+
+// RequireTestFile creates a rule that fails if a go file does not have a matching test file in the same directory,
+// e.g. `foo_test.go` for `foo.go`, with the test file name formed by replacing the `.go` extension with a given suffix.
+//
+// Test files themselves, files that are not go files, and files whose base names match any of the
+// exempt globs are skipped. Test files are looked up relative to a given root, which is the directory the filenames are relative to.
+func RequireTestFile(root, suffix string, exempt ...string) RuleFunc {
+	return func(filename string, _ []byte) RuleResult {
+		base := filepath.Base(filename)
+		if filepath.Ext(base) != ".go" || strings.HasSuffix(base, suffix) || GlobAnyMatch(exempt, base) {
+			return RuleResult{OK: true}
+		}
+		testFile := strings.TrimSuffix(base, ".go") + suffix
+		if _, err := os.Stat(filepath.Join(root, filepath.Dir(filename), testFile)); err != nil {
+			if os.IsNotExist(err) {
+				return RuleResult{File: filename, Message: fmt.Sprintf("test file not found: %s", testFile)}
+			}
+			return RuleResult{Err: err}
+		}
+		return RuleResult{OK: true}
+	}
+}
@@ -0,0 +1,75 @@
+package profanity
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestRequireTestFile(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		"tested.go":             "package foo\n",
+		"tested_test.go":        "package foo\n",
+		"untested.go":           "package foo\n",
+		"main.go":               "package main\n",
+		"nested/tested.go":      "package nested\n",
+		"nested/tested_spec.go": "package nested\n",
+	})
+	defer cleanup()
+
+	ruleFunc := RequireTestFile(root, GoTestFileSuffix, DefaultRequireTestFileExempt...)
+	assert.Nil(ok(ruleFunc("tested.go", nil)))
+	assert.Nil(ok(ruleFunc("tested_test.go", nil)), "test files should be skipped")
+	assert.Nil(ok(ruleFunc("main.go", nil)), "exempt files should be skipped")
+	assert.Nil(ok(ruleFunc("README.md", nil)), "non-go files should be skipped")
+
+	res := ruleFunc("untested.go", nil)
+	assert.False(res.OK)
+	assert.Equal("untested.go", res.File)
+	assert.Equal("test file not found: untested_test.go", res.Message)
+
+	res = ruleFunc(filepath.Join("nested", "tested.go"), nil)
+	assert.False(res.OK)
+	assert.Equal("test file not found: tested_test.go", res.Message)
+
+	ruleFunc = RequireTestFile(root, "_spec.go", "untested.go")
+	assert.Nil(ok(ruleFunc(filepath.Join("nested", "tested.go"), nil)))
+	assert.Nil(ok(ruleFunc("untested.go", nil)))
+	assert.False(ruleFunc("main.go", nil).OK)
+}
+
+func TestProcessRequireTestFile(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+REQUIRE_TESTS:
+  description: "please add tests"
+  requireTestFile: true
+  requireTestFileExempt: [ "main.go", "doc.go" ]
+`,
+		"tested.go":          "package foo\n",
+		"tested_test.go":     "package foo\n",
+		"doc.go":             "package foo\n",
+		"cmd/tool/main.go":   "package main\n",
+		"nested/untested.go": "package nested\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, filepath.Join("nested", "untested.go"))
+	assert.Contains(stderr, "test file not found: untested_test.go")
+	assert.Contains(stderr, "scanned 5 file(s), 1 violation(s) across 1 rule(s)")
+	assert.NotContains(stderr, "doc.go")
+	assert.NotContains(stderr, "main.go")
+
+	rules, err := New(OptRoot(root)).RulesFromPath(filepath.Join(root, DefaultRulesFile))
+	assert.Nil(err)
+	assert.Equal(root, rules["REQUIRE_TESTS"].Root)
+	assert.Contains(rules["REQUIRE_TESTS"].String(), "[require test file: _test.go]")
+}
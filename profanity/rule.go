@@ -3,16 +3,28 @@ package profanity
 import (
 	"fmt"
 	"strings"
+	"text/template"
+
+	"github.com/blend/go-sdk/ex"
 )
 
 // Rule is a serialized rule.
+//
+// A rule can set more than one kind, e.g. both `maxPathLength` and `maxPathDepth`, in which case every kind it sets must pass.
+// Fields that are not fields of the rule or the kinds and settings of registered evaluators are an error; see `Validate`.
 type Rule struct {
 	// ID is a unique identifier for the rule.
 	ID string `yaml:"id"`
 	// File is the rules file path the rule came from.
 	File string `yaml:"-"`
+	// Root is the directory the files the rule is applied to are relative to.
+	// It is set when rules are read by the engine, and defaults to the working directory.
+	Root string `yaml:"-"`
 	// Description is a descriptive message for the rule.
 	Description string `yaml:"description,omitempty"`
+	// Severity is the severity of the rule, either `error` (the default) or `warn`.
+	// Failures of `warn` rules are reported but do not fail the run.
+	Severity string `yaml:"severity,omitempty"`
 
 	// IncludeFiles sets a glob filter for file inclusion by filename.
 	IncludeFiles []string `yaml:"includeFiles,omitempty"`
@@ -28,8 +40,199 @@ type Rule struct {
 	Contains []string `yaml:"contains,omitempty"`
 	// Pattern implies we should fail if a file's content matches a given regex pattern.
 	Pattern []string `yaml:"pattern,omitempty"`
+	// Message is a template for the failure message of `Pattern` rules, which can reference
+	// the named capture groups of the match, e.g. `found deprecated api {{ .name }}`.
+	Message string `yaml:"message,omitempty"`
 	// ImportsContain enforces that a given list of imports are used.
 	ImportsContain []string `yaml:"importsContain,omitempty"`
+	// MaxBytes implies we should fail if a file is larger than a given size in bytes.
+	MaxBytes int `yaml:"maxBytes,omitempty"`
+	// MaxLines implies we should fail if a file has more than a given number of lines.
+	MaxLines int `yaml:"maxLines,omitempty"`
+	// MaxPathLength implies we should fail if a file path, relative to the root, is longer than a given number of characters.
+	MaxPathLength int `yaml:"maxPathLength,omitempty"`
+	// MaxPathDepth implies we should fail if a file is nested in more than a given number of directories below the root.
+	MaxPathDepth int `yaml:"maxPathDepth,omitempty"`
+	// MaxFunctionLines implies we should fail if a go file has a function with a body longer than a given number of lines.
+	MaxFunctionLines int `yaml:"maxFunctionLines,omitempty"`
+	// GoFmt implies we should fail if a go file is not formatted as `gofmt` would format it.
+	GoFmt bool `yaml:"goFmt,omitempty"`
+	// GoImportsGrouped implies we should fail if the imports of a go file are not grouped and sorted as `goimports` would,
+	// i.e. standard library, then third party, then local imports; see `GoImportsLocalPrefix`.
+	GoImportsGrouped bool `yaml:"goImportsGrouped,omitempty"`
+	// GoImportsLocalPrefix is a comma separated list of import path prefixes of local imports, e.g. `github.com/blend/go-sdk`.
+	GoImportsLocalPrefix string `yaml:"goImportsLocalPrefix,omitempty"`
+	// MustParseYAML implies we should fail if a yaml file, by extension, does not parse.
+	MustParseYAML bool `yaml:"mustParseYAML,omitempty"`
+	// MustParseJSON implies we should fail if a json file, by extension, does not parse.
+	MustParseJSON bool `yaml:"mustParseJSON,omitempty"`
+	// ForbidBinary implies we should fail if a binary file is larger than `MaxBinaryBytes`, or any binary file if it is unset.
+	ForbidBinary bool `yaml:"forbidBinary,omitempty"`
+	// MaxBinaryBytes is the size in bytes binary files can be before `ForbidBinary` fails.
+	MaxBinaryBytes int `yaml:"maxBinaryBytes,omitempty"`
+	// ForbidBinaryAllowExtensions are the extensions of binary files that `ForbidBinary` skips, e.g. `.png`.
+	ForbidBinaryAllowExtensions []string `yaml:"forbidBinaryAllowExtensions,omitempty"`
+	// ForbidFilename implies we should fail if a file with a given base name exists, e.g. `Thumbs.db`.
+	ForbidFilename string `yaml:"forbidFilename,omitempty"`
+	// ForbidExtension implies we should fail if a file with a given extension exists, e.g. `.exe`.
+	ForbidExtension string `yaml:"forbidExtension,omitempty"`
+	// RequireHeaderContains implies we should fail if the first lines of a file, see `RequireHeaderWithinLines`, do not contain a given string.
+	RequireHeaderContains string `yaml:"requireHeaderContains,omitempty"`
+	// RequireHeaderWithinLines is the number of lines at the top of a file that `RequireHeaderContains` checks; it defaults to 10.
+	RequireHeaderWithinLines int `yaml:"requireHeaderWithinLines,omitempty"`
+	// RequireGeneratedMarker implies we should fail if a file does not have a `// Code generated ... DO NOT EDIT.` comment before its first non-comment text.
+	RequireGeneratedMarker bool `yaml:"requireGeneratedMarker,omitempty"`
+	// ForbidGoModLocalReplace implies we should fail if a `go.mod` file has a `replace` directive that points at a local path.
+	ForbidGoModLocalReplace bool `yaml:"forbidGoModLocalReplace,omitempty"`
+	// ForbidGoModReplace implies we should fail if a `go.mod` file has a `replace` directive for a module matching any of a given set of globs.
+	ForbidGoModReplace []string `yaml:"forbidGoModReplace,omitempty"`
+	// ForbidBuildTags implies we should fail if a go file has a build constraint that requires any of a given set of tags, e.g. `wip`.
+	ForbidBuildTags []string `yaml:"forbidBuildTags,omitempty"`
+	// RequireAnnotatedTodos implies we should fail if a file has a `TODO` or `FIXME` without an owner, e.g. `TODO(user):`.
+	RequireAnnotatedTodos bool `yaml:"requireAnnotatedTodos,omitempty"`
+	// RequireTestFile implies we should fail if a go file does not have a matching test file in the same directory, e.g. `foo_test.go` for `foo.go`.
+	RequireTestFile bool `yaml:"requireTestFile,omitempty"`
+	// RequireTestFileSuffix is the suffix that replaces `.go` to form test file names; it defaults to `_test.go`.
+	RequireTestFileSuffix string `yaml:"requireTestFileSuffix,omitempty"`
+	// RequireTestFileExempt are globs for file base names that do not require test files; they default to `main.go`.
+	RequireTestFileExempt []string `yaml:"requireTestFileExempt,omitempty"`
+	// DetectSecrets implies we should fail if a file has a possible hardcoded secret, e.g. an aws access key id or a private key.
+	DetectSecrets bool `yaml:"detectSecrets,omitempty"`
+	// DetectSecretsAllow are regex patterns for known safe matches of `DetectSecrets`, e.g. example keys in documentation.
+	DetectSecretsAllow []string `yaml:"detectSecretsAllow,omitempty"`
+	// PackageName implies we should fail if the package clause of a go file does not match a given regex pattern, e.g. `^[a-z][a-z0-9]*$`.
+	PackageName string `yaml:"packageName,omitempty"`
+	// PackageNameMatchesDir implies we should fail if the package clause of a go file is not the name of its directory.
+	PackageNameMatchesDir bool `yaml:"packageNameMatchesDir,omitempty"`
+	// LineEndings implies we should fail if a file has line endings other than a given style, either `lf` or `crlf`.
+	LineEndings string `yaml:"lineEndings,omitempty"`
+	// ForbidDebugStatements implies we should fail if a file has a leftover debug statement for its extension, e.g. `console.log(` in a `.js` file; see `DebugStatements`.
+	ForbidDebugStatements bool `yaml:"forbidDebugStatements,omitempty"`
+	// DebugStatements are the debug statements `ForbidDebugStatements` checks keyed by file extension, e.g. `.js`;
+	// they replace the `DefaultDebugStatements` for the extensions they set.
+	DebugStatements map[string][]string `yaml:"debugStatements,omitempty"`
+	// Indentation implies we should fail if a file has a line indented with other than a given style, either `tabs` or `spaces`.
+	Indentation string `yaml:"indentation,omitempty"`
+	// IndentationWidth is the multiple of spaces lines must be indented by for `spaces` indentation; it is unchecked if unset.
+	IndentationWidth int `yaml:"indentationWidth,omitempty"`
+
+	//
+	// the below are composite rules.
+	// child rules are applied to the same contents, and may themselves be composites.
+	//
+
+	// AllOf implies we should fail if any of the child rules fail.
+	AllOf []Rule `yaml:"allOf,omitempty"`
+	// AnyOf implies we should fail only if all of the child rules fail.
+	AnyOf []Rule `yaml:"anyOf,omitempty"`
+
+	// Extensions are the rules file fields that are not fields of the rule, i.e. the settings of
+	// rule kinds registered by other packages; see `RegisterRuleEvaluator`.
+	Extensions map[string]interface{} `yaml:",inline"`
+}
+
+// SeverityOrDefault returns the rule severity or a default.
+func (r Rule) SeverityOrDefault() string {
+	if r.Severity != "" {
+		return r.Severity
+	}
+	return SeverityError
+}
+
+// RequireHeaderWithinLinesOrDefault returns the number of header lines or a default.
+func (r Rule) RequireHeaderWithinLinesOrDefault() int {
+	if r.RequireHeaderWithinLines > 0 {
+		return r.RequireHeaderWithinLines
+	}
+	return DefaultRequireHeaderWithinLines
+}
+
+// GoImportsLocalPrefixes returns the local import prefixes.
+func (r Rule) GoImportsLocalPrefixes() (output []string) {
+	for _, prefix := range strings.Split(r.GoImportsLocalPrefix, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			output = append(output, prefix)
+		}
+	}
+	return
+}
+
+// RequireTestFileSuffixOrDefault returns the test file suffix or a default.
+func (r Rule) RequireTestFileSuffixOrDefault() string {
+	if r.RequireTestFileSuffix != "" {
+		return r.RequireTestFileSuffix
+	}
+	return GoTestFileSuffix
+}
+
+// RequireTestFileExemptOrDefault returns the test file exemptions or a default.
+func (r Rule) RequireTestFileExemptOrDefault() []string {
+	if len(r.RequireTestFileExempt) > 0 {
+		return r.RequireTestFileExempt
+	}
+	return DefaultRequireTestFileExempt
+}
+
+// DebugStatementsOrDefault returns the default debug statements with the debug statements the rule sets
+// for an extension replacing the defaults for that extension.
+func (r Rule) DebugStatementsOrDefault() map[string][]string {
+	output := make(map[string][]string, len(DefaultDebugStatements))
+	for extension, statements := range DefaultDebugStatements {
+		output[extension] = statements
+	}
+	for extension, statements := range r.DebugStatements {
+		if !strings.HasPrefix(extension, ".") {
+			extension = "." + extension
+		}
+		output[strings.ToLower(extension)] = statements
+	}
+	return output
+}
+
+// IsWarning returns if the rule severity is `warn`.
+func (r Rule) IsWarning() bool {
+	return r.SeverityOrDefault() == SeverityWarn
+}
+
+// Validate validates the rule.
+func (r Rule) Validate() error {
+	switch r.SeverityOrDefault() {
+	case SeverityError, SeverityWarn:
+	default:
+		return ex.New(ErrInvalidSeverity, ex.OptMessagef("rule: %s, file: %s, severity: %s", r.ID, r.File, r.Severity))
+	}
+	if r.Message != "" {
+		if _, err := template.New("message").Parse(r.Message); err != nil {
+			return ex.New(ErrInvalidMessage, ex.OptMessagef("rule: %s, file: %s, message: %s", r.ID, r.File, r.Message), ex.OptInner(err))
+		}
+	}
+	if err := r.validateExtensions(); err != nil {
+		return err
+	}
+	for _, kind := range RuleEvaluators() {
+		evaluator, _ := GetRuleEvaluator(kind)
+		typed, ok := evaluator.(RuleEvaluatorValidator)
+		if !ok {
+			continue
+		}
+		// extensions are validated even if they are not set, e.g. if they do not parse.
+		if r.hasAnyExtension(ruleEvaluatorFields(kind, evaluator)...) || evaluator.IsSet(r) {
+			if err := typed.Validate(r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasAnyExtension returns if the rule sets any of a given set of extension fields.
+func (r Rule) hasAnyExtension(fields ...string) bool {
+	for _, field := range fields {
+		if _, ok := r.Extensions[field]; ok {
+			return true
+		}
+	}
+	return false
 }
 
 // ShouldInclude returns if we should include a file for a given rule.
@@ -60,22 +263,51 @@ func (r Rule) ShouldExclude(file string) bool {
 }
 
 // Apply applies the rule.
+//
+// Each rule kind the rule sets is evaluated in the order the kinds are registered,
+// and the result of the first that fails is returned; a rule that sets no kinds returns an error.
 func (r Rule) Apply(filename string, contents []byte) (result RuleResult) {
-	if len(r.Contains) > 0 {
-		result = ContainsAny(r.Contains...)(filename, contents)
-		return
-	}
-	if len(r.Pattern) > 0 {
-		result = MatchesAny(r.Pattern...)(filename, contents)
+	evaluators := setRuleEvaluators(r)
+	if len(evaluators) == 0 {
+		result = RuleResult{File: filename, Err: ex.New(ErrRuleKindUnset, ex.OptMessagef("rule: %s, file: %s", r.ID, r.File))}
 		return
 	}
-	if len(r.ImportsContain) > 0 {
-		result = ImportsContainAny(r.ImportsContain...)(filename, contents)
-		return
+	for _, evaluator := range evaluators {
+		if result = evaluator.RuleFunc(r)(filename, contents); !result.OK {
+			return
+		}
 	}
+	result = RuleResult{OK: true}
 	return
 }
 
+// withRoot returns the rule with the root set, including on its child rules.
+func (r Rule) withRoot(root string) Rule {
+	r.Root = root
+	r.AllOf = rulesWithRoot(r.AllOf, root)
+	r.AnyOf = rulesWithRoot(r.AnyOf, root)
+	return r
+}
+
+func rulesWithRoot(rules []Rule, root string) []Rule {
+	if len(rules) == 0 {
+		return rules
+	}
+	output := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		output = append(output, rule.withRoot(root))
+	}
+	return output
+}
+
+// Name returns the rule id, or a given default if the id is unset.
+func (r Rule) Name(defaultName string) string {
+	if r.ID != "" {
+		return r.ID
+	}
+	return defaultName
+}
+
 // String returns a string representation of the rule.
 func (r Rule) String() string {
 	var tokens []string
@@ -86,20 +318,29 @@ func (r Rule) String() string {
 	if len(r.Description) > 0 {
 		tokens = append(tokens, "`"+r.Description+"`")
 	}
+	if r.IsWarning() {
+		tokens = append(tokens, fmt.Sprintf("[severity: %s]", r.Severity))
+	}
 	if len(r.IncludeFiles) > 0 {
 		tokens = append(tokens, fmt.Sprintf("[include files: %s]", strings.Join(r.IncludeFiles, ", ")))
 	}
 	if len(r.ExcludeFiles) > 0 {
 		tokens = append(tokens, fmt.Sprintf("[exclude files: %s]", strings.Join(r.ExcludeFiles, ",")))
 	}
-	if len(r.Contains) > 0 {
-		tokens = append(tokens, fmt.Sprintf("[contains: %s]", strings.Join(r.Contains, ",")))
-	}
-	if len(r.Pattern) > 0 {
-		tokens = append(tokens, fmt.Sprintf("[matches patterns: %s]", strings.Join(r.Pattern, ",")))
-	}
-	if len(r.ImportsContain) > 0 {
-		tokens = append(tokens, fmt.Sprintf("[go imports contain any: %s]", strings.Join(r.ImportsContain, ",")))
+	evaluators := setRuleEvaluators(r)
+	for _, evaluator := range evaluators {
+		if token := evaluator.String(r); token != "" {
+			tokens = append(tokens, token)
+		}
 	}
 	return strings.Join(tokens, " ")
 }
+
+// joinRules joins the string representations of a list of rules.
+func joinRules(rules []Rule) string {
+	var tokens []string
+	for _, rule := range rules {
+		tokens = append(tokens, "("+rule.String()+")")
+	}
+	return strings.Join(tokens, ", ")
+}
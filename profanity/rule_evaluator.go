@@ -0,0 +1,177 @@
+package profanity
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/yaml"
+)
+
+// RuleEvaluator evaluates a kind of rule.
+//
+// Rule evaluators are registered by the rules file field that sets the kind, e.g. `contains`, with `RegisterRuleEvaluator`.
+// The builtin kinds are fields of `Rule`; kinds registered by other packages are read from the rule's extensions with `rule.Extension(kind, &value)`.
+type RuleEvaluator interface {
+	// IsSet returns if a rule sets the kind.
+	IsSet(Rule) bool
+	// RuleFunc returns the rule func that evaluates a rule of the kind.
+	RuleFunc(Rule) RuleFunc
+	// String returns a string representation of the kind's settings for a rule, e.g. `[max lines: 100]`.
+	String(Rule) string
+}
+
+// RuleEvaluatorValidator is a rule evaluator that validates the kind's settings for a rule.
+type RuleEvaluatorValidator interface {
+	RuleEvaluator
+	Validate(Rule) error
+}
+
+// RuleEvaluatorSettings is a rule evaluator that reads rules file fields besides its kind,
+// e.g. a limit for a kind registered by another package; the fields are read from the rule's extensions.
+type RuleEvaluatorSettings interface {
+	RuleEvaluator
+	Settings() []string
+}
+
+// RuleEvaluators returns the registered rule kinds in the order they are evaluated.
+func RuleEvaluators() []string {
+	ruleEvaluatorsLock.RLock()
+	defer ruleEvaluatorsLock.RUnlock()
+	return append([]string(nil), ruleEvaluatorKinds...)
+}
+
+// RegisterRuleEvaluator registers a rule evaluator for a kind, keyed by the rules file field that sets it.
+// Kinds are evaluated in the order they are registered, after the builtin kinds, which are registered
+// by the files that implement them; registering a kind that is already registered replaces its evaluator but keeps its order.
+func RegisterRuleEvaluator(kind string, evaluator RuleEvaluator) {
+	ruleEvaluatorsLock.Lock()
+	defer ruleEvaluatorsLock.Unlock()
+	if _, ok := ruleEvaluators[kind]; !ok {
+		ruleEvaluatorKinds = append(ruleEvaluatorKinds, kind)
+	}
+	ruleEvaluators[kind] = evaluator
+}
+
+// GetRuleEvaluator returns the rule evaluator for a kind, if it is registered.
+func GetRuleEvaluator(kind string) (evaluator RuleEvaluator, ok bool) {
+	ruleEvaluatorsLock.RLock()
+	defer ruleEvaluatorsLock.RUnlock()
+	evaluator, ok = ruleEvaluators[kind]
+	return
+}
+
+// setRuleEvaluators returns the evaluators for the kinds set by a rule in evaluation order.
+func setRuleEvaluators(rule Rule) (evaluators []RuleEvaluator) {
+	ruleEvaluatorsLock.RLock()
+	defer ruleEvaluatorsLock.RUnlock()
+	for _, kind := range ruleEvaluatorKinds {
+		if evaluator := ruleEvaluators[kind]; evaluator.IsSet(rule) {
+			evaluators = append(evaluators, evaluator)
+		}
+	}
+	return
+}
+
+// Kinds returns the registered kinds a rule sets in evaluation order, e.g. `contains`.
+func (r Rule) Kinds() (kinds []string) {
+	ruleEvaluatorsLock.RLock()
+	defer ruleEvaluatorsLock.RUnlock()
+	for _, kind := range ruleEvaluatorKinds {
+		if ruleEvaluators[kind].IsSet(r) {
+			kinds = append(kinds, kind)
+		}
+	}
+	return
+}
+
+// Extension reads the value of a rules file field that is not a field of `Rule`, e.g. for a registered kind, into a given reference.
+// It returns false if the rule does not set the field.
+func (r Rule) Extension(kind string, ref interface{}) (bool, error) {
+	value, ok := r.Extensions[kind]
+	if !ok {
+		return false, nil
+	}
+	contents, err := yaml.Marshal(value)
+	if err != nil {
+		return true, ex.New(err)
+	}
+	if err = yaml.Unmarshal(contents, ref); err != nil {
+		return true, ex.New(ErrInvalidRuleKind, ex.OptMessagef("rule: %s, file: %s, kind: %s", r.ID, r.File, kind), ex.OptInner(err))
+	}
+	return true, nil
+}
+
+// ruleEvaluatorFields returns the rules file fields a rule evaluator reads, i.e. its kind and any settings.
+func ruleEvaluatorFields(kind string, evaluator RuleEvaluator) []string {
+	if typed, ok := evaluator.(RuleEvaluatorSettings); ok {
+		return append([]string{kind}, typed.Settings()...)
+	}
+	return []string{kind}
+}
+
+// validateExtensions validates that the extensions of a rule are registered kinds or their settings,
+// so a misspelled field, e.g. `contans`, is an error rather than a rule that checks nothing.
+func (r Rule) validateExtensions() error {
+	known := map[string]bool{}
+	ruleEvaluatorsLock.RLock()
+	for kind, evaluator := range ruleEvaluators {
+		for _, field := range ruleEvaluatorFields(kind, evaluator) {
+			known[field] = true
+		}
+	}
+	ruleEvaluatorsLock.RUnlock()
+
+	var unknown []string
+	for kind := range r.Extensions {
+		if !known[kind] {
+			unknown = append(unknown, kind)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return ex.New(ErrUnknownRuleKind, ex.OptMessagef("rule: %s, file: %s, kinds: %s", r.ID, r.File, strings.Join(unknown, ", ")))
+	}
+	return nil
+}
+
+// RuleEvaluatorFuncs is a rule evaluator composed of functions.
+type RuleEvaluatorFuncs struct {
+	IsSetFunc      func(Rule) bool
+	RuleFuncFunc   func(Rule) RuleFunc
+	StringFunc     func(Rule) string
+	ValidateFunc   func(Rule) error
+	SettingsFields []string
+}
+
+// IsSet implements RuleEvaluator.
+func (ref RuleEvaluatorFuncs) IsSet(rule Rule) bool { return ref.IsSetFunc(rule) }
+
+// RuleFunc implements RuleEvaluator.
+func (ref RuleEvaluatorFuncs) RuleFunc(rule Rule) RuleFunc { return ref.RuleFuncFunc(rule) }
+
+// String implements RuleEvaluator.
+func (ref RuleEvaluatorFuncs) String(rule Rule) string {
+	if ref.StringFunc == nil {
+		return ""
+	}
+	return ref.StringFunc(rule)
+}
+
+// Validate implements RuleEvaluatorValidator.
+func (ref RuleEvaluatorFuncs) Validate(rule Rule) error {
+	if ref.ValidateFunc == nil {
+		return nil
+	}
+	return ref.ValidateFunc(rule)
+}
+
+// Settings implements RuleEvaluatorSettings.
+func (ref RuleEvaluatorFuncs) Settings() []string { return ref.SettingsFields }
+
+var (
+	ruleEvaluatorsLock sync.RWMutex
+	ruleEvaluatorKinds []string
+	ruleEvaluators     = map[string]RuleEvaluator{}
+)
@@ -0,0 +1,117 @@
+package profanity
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+// maxWordsEvaluator is a custom rule kind that fails if a file has more than a given number of words.
+type maxWordsEvaluator struct{}
+
+func (maxWordsEvaluator) limit(rule Rule) int {
+	var limit int
+	if _, err := rule.Extension("testMaxWords", &limit); err != nil {
+		return 0
+	}
+	return limit
+}
+
+func (mwe maxWordsEvaluator) IsSet(rule Rule) bool { return mwe.limit(rule) > 0 }
+
+func (mwe maxWordsEvaluator) RuleFunc(rule Rule) RuleFunc {
+	limit := mwe.limit(rule)
+	return func(filename string, contents []byte) RuleResult {
+		if actual := len(bytes.Fields(contents)); actual > limit {
+			return RuleResult{File: filename, Message: fmt.Sprintf("max words: %d, actual: %d", limit, actual)}
+		}
+		return RuleResult{OK: true}
+	}
+}
+
+func (mwe maxWordsEvaluator) String(rule Rule) string {
+	return fmt.Sprintf("[max words: %d]", mwe.limit(rule))
+}
+
+func (mwe maxWordsEvaluator) Validate(rule Rule) error {
+	var limit int
+	if _, err := rule.Extension("testMaxWords", &limit); err != nil {
+		return err
+	}
+	return nil
+}
+
+func TestRegisterRuleEvaluator(t *testing.T) {
+	assert := assert.New(t)
+
+	RegisterRuleEvaluator("testMaxWords", maxWordsEvaluator{})
+	evaluator, ok := GetRuleEvaluator("testMaxWords")
+	assert.True(ok)
+	assert.NotNil(evaluator)
+	kinds := RuleEvaluators()
+	assert.Any(kinds, func(kind interface{}) bool { return kind == "contains" })
+	assert.Equal("testMaxWords", kinds[len(kinds)-1])
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+MAX_WORDS:
+  description: "keep it short"
+  testMaxWords: 3
+`,
+		"ok.txt":         "one two three\n",
+		"nested/bad.txt": "one two three four\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, filepath.Join("nested", "bad.txt"))
+	assert.Contains(stderr, "max words: 3, actual: 4")
+	assert.NotContains(stderr, "ok.txt")
+
+	rules, err := New().RulesFromPath(filepath.Join(root, DefaultRulesFile))
+	assert.Nil(err)
+	assert.Contains(rules["MAX_WORDS"].String(), "[max words: 3]")
+
+	_, err = New().RulesFromReader("rules.yml", bytes.NewReader([]byte("MAX_WORDS:\n  testMaxWords: \"three\"\n")))
+	assert.True(ex.Is(err, ErrInvalidRuleKind))
+}
+
+func TestRuleValidateUnknownFields(t *testing.T) {
+	assert := assert.New(t)
+
+	// a field that is not a field of the rule or the kind or setting of a registered kind is an error,
+	// so a misspelled kind does not make a rule that checks nothing.
+	_, err := New().RulesFromReader("rules.yml", bytes.NewReader([]byte("NO_FOO:\n  contans: [ \"foo\" ]\n")))
+	assert.True(ex.Is(err, ErrUnknownRuleKind))
+	assert.Contains(ex.ErrMessage(err), "contans")
+
+	// the settings of the builtin kinds are fields of the rule, and must parse as their field types.
+	rules, err := New().RulesFromReader("rules.yml", bytes.NewReader([]byte("NO_BINARY:\n  forbidBinary: true\n  maxBinaryBytes: 10\n")))
+	assert.Nil(err)
+	assert.Equal(10, rules["NO_BINARY"].MaxBinaryBytes)
+	assert.Empty(rules["NO_BINARY"].Extensions)
+	_, err = New().RulesFromReader("rules.yml", bytes.NewReader([]byte("NO_BINARY:\n  forbidBinary: true\n  maxBinaryBytes: \"ten\"\n")))
+	assert.NotNil(err)
+}
+
+func TestRuleApplyEveryKind(t *testing.T) {
+	assert := assert.New(t)
+
+	// every kind a rule sets is evaluated, in registration order, and must pass.
+	rule := Rule{Contains: []string{"foo"}, Pattern: []string{"ba[rz]"}}
+	assert.Equal([]string{"contains", "pattern"}, rule.Kinds())
+	assert.True(rule.Apply("file.txt", []byte("buzz")).OK)
+	assert.Equal(`contains: "foo"`, rule.Apply("file.txt", []byte("foo")).Message)
+	assert.Equal(`regexp match: "ba[rz]"`, rule.Apply("file.txt", []byte("bar")).Message)
+	assert.Contains(rule.String(), "[contains: foo] [matches patterns: ba[rz]]")
+
+	// a rule that sets no kinds is an error rather than a pass or a failure for every file.
+	res := Rule{ID: "EMPTY"}.Apply("file.txt", []byte("foo"))
+	assert.False(res.OK)
+	assert.True(ex.Is(res.Err, ErrRuleKindUnset))
+}
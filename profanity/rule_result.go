@@ -26,7 +26,11 @@ func (r RuleResult) Failure(rule Rule) error {
 	if rule.Description != "" {
 		tokens = append(tokens, fmt.Sprintf("\t%s: %s", ansi.LightBlack("description"), rule.Description))
 	}
-	tokens = append(tokens, fmt.Sprintf("\t%s: %s", ansi.LightBlack("status"), ansi.Red("failed")))
+	if rule.IsWarning() {
+		tokens = append(tokens, fmt.Sprintf("\t%s: %s", ansi.LightBlack("status"), ansi.Yellow("warning")))
+	} else {
+		tokens = append(tokens, fmt.Sprintf("\t%s: %s", ansi.LightBlack("status"), ansi.Red("failed")))
+	}
 	tokens = append(tokens, fmt.Sprintf("\t%s: %s", ansi.LightBlack("rule"), r.Message))
 	return fmt.Errorf(strings.Join(tokens, "\n"))
 }
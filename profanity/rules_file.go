@@ -0,0 +1,68 @@
+package profanity
+
+import (
+	"github.com/blend/go-sdk/ex"
+)
+
+// RulesFile is a parsed rules file.
+//
+// Rules files are maps of rule ids to rules, with the exception of the reserved
+// `inherit` key, which is a file level directive, e.g.
+//
+//	inherit: false
+//	NO_FMT:
+//	  contains: ["fmt.Print"]
+type RulesFile struct {
+	// Inherit determines if the rules from parent directories (and the root) apply to
+	// the directory the rules file is in and its children. It defaults to true.
+	Inherit *bool
+	// Rules are the rules in the file.
+	Rules Rules
+}
+
+// InheritOrDefault returns if parent rules are inherited or a default.
+func (rf RulesFile) InheritOrDefault() bool {
+	if rf.Inherit != nil {
+		return *rf.Inherit
+	}
+	return true
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (rf *RulesFile) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var values map[string]rulesFileValue
+	if err := unmarshal(&values); err != nil {
+		return err
+	}
+	rf.Rules = make(Rules)
+	for key, value := range values {
+		if key == DirectiveInherit {
+			if value.Directive == nil {
+				return ex.New(ErrInvalidDirective, ex.OptMessagef("`%s` must be a boolean", DirectiveInherit))
+			}
+			rf.Inherit = value.Directive
+			continue
+		}
+		if value.Directive != nil {
+			return ex.New(ErrInvalidDirective, ex.OptMessagef("unknown directive: %s", key))
+		}
+		rf.Rules[key] = value.Rule
+	}
+	return nil
+}
+
+// rulesFileValue is a top level value in a rules file, either a rule or a directive.
+type rulesFileValue struct {
+	Rule      Rule
+	Directive *bool
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (rfv *rulesFileValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var directive bool
+	if err := unmarshal(&directive); err == nil {
+		rfv.Directive = &directive
+		return nil
+	}
+	return unmarshal(&rfv.Rule)
+}
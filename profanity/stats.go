@@ -0,0 +1,69 @@
+package profanity
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Stats are counts accumulated during a profanity run.
+type Stats struct {
+	// Files is the number of files rules were checked against.
+	Files int
+	// Violations is the number of rule failures, including warnings.
+	Violations int
+	// Suppressed is the number of rule failures suppressed by inline directives.
+	Suppressed int
+	// RuleFiles is the number of files each rule flagged, keyed by rule id.
+	RuleFiles map[string]int
+	// RuleSeverities is the highest severity of the violations of each rule, keyed by rule id.
+	RuleSeverities map[string]string
+}
+
+// AddFile records a file was checked.
+func (s *Stats) AddFile() {
+	s.Files++
+}
+
+// AddSuppressed records a number of rule failures were suppressed by inline directives.
+func (s *Stats) AddSuppressed(count int) {
+	s.Suppressed += count
+}
+
+// AddViolation records a rule flagged a file.
+func (s *Stats) AddViolation(rule Rule) {
+	if s.RuleFiles == nil {
+		s.RuleFiles = make(map[string]int)
+	}
+	if s.RuleSeverities == nil {
+		s.RuleSeverities = make(map[string]string)
+	}
+	name := rule.Name(rule.String())
+	s.Violations++
+	s.RuleFiles[name]++
+	if s.RuleSeverities[name] != SeverityError {
+		s.RuleSeverities[name] = rule.SeverityOrDefault()
+	}
+}
+
+// RuleSeverity returns the highest severity of the violations of a rule, defaulting to `error`.
+func (s Stats) RuleSeverity(rule string) string {
+	if severity, ok := s.RuleSeverities[rule]; ok {
+		return severity
+	}
+	return SeverityError
+}
+
+// Rules returns the ids of the rules that flagged files, sorted.
+func (s Stats) Rules() []string {
+	var rules []string
+	for rule := range s.RuleFiles {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+// String returns the summary line for the stats.
+func (s Stats) String() string {
+	return fmt.Sprintf("scanned %d file(s), %d violation(s) across %d rule(s)", s.Files, s.Violations, len(s.RuleFiles))
+}
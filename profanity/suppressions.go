@@ -0,0 +1,109 @@
+package profanity
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// ParseSuppressions parses the inline suppression directives in a file's contents.
+//
+// A `profanity:disable=RULE_ID` comment suppresses a rule for the whole file, and
+// a `profanity:disable-next-line=RULE_ID` comment suppresses a rule for the line after it.
+// Multiple rule ids can be separated by commas, e.g. `// profanity:disable=FOO,BAR`.
+func ParseSuppressions(contents []byte) Suppressions {
+	var s Suppressions
+	if !bytes.Contains(contents, []byte(DirectiveDisablePrefix)) {
+		return s
+	}
+	scanner := bufio.NewScanner(bytes.NewBuffer(contents))
+	var line int
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		for _, id := range suppressionRuleIDs(text, DirectiveDisable) {
+			if s.File == nil {
+				s.File = make(map[string]bool)
+			}
+			s.File[id] = true
+		}
+		for _, id := range suppressionRuleIDs(text, DirectiveDisableNextLine) {
+			if s.Lines == nil {
+				s.Lines = make(map[int]map[string]bool)
+			}
+			if s.Lines[line+1] == nil {
+				s.Lines[line+1] = make(map[string]bool)
+			}
+			s.Lines[line+1][id] = true
+		}
+	}
+	return s
+}
+
+// Suppressions are the rules suppressed by inline directives in a file.
+type Suppressions struct {
+	// File are the rule ids suppressed for the whole file.
+	File map[string]bool
+	// Lines are the rule ids suppressed for a given line, keyed by line number.
+	Lines map[int]map[string]bool
+}
+
+// Has returns if a rule result is suppressed, either for the whole file or for the line it was found on.
+func (s Suppressions) Has(rule Rule, res RuleResult) bool {
+	if s.File[rule.ID] {
+		return true
+	}
+	return res.Line > 0 && s.Lines[res.Line][rule.ID]
+}
+
+// Apply applies a rule to a file's contents, skipping the failures that are suppressed, and returns
+// the result along with the number of failures that were suppressed.
+//
+// Failures suppressed for a line are skipped by re-applying the rule with that line blanked, so
+// failures found by the rule on later lines are still reported.
+func (s Suppressions) Apply(rule Rule, file string, contents []byte) (res RuleResult, suppressed int) {
+	res = rule.Apply(file, contents)
+	blanked := make(map[int]bool)
+	for !res.OK && res.Err == nil && s.Has(rule, res) {
+		if blanked[res.Line] {
+			return RuleResult{OK: true}, suppressed
+		}
+		suppressed++
+		if s.File[rule.ID] {
+			return RuleResult{OK: true}, suppressed
+		}
+		blanked[res.Line] = true
+		contents = blankLine(contents, res.Line)
+		res = rule.Apply(file, contents)
+	}
+	return
+}
+
+// blankLine returns a copy of the contents with a given line emptied, keeping the line numbers of the lines after it.
+func blankLine(contents []byte, line int) []byte {
+	lines := bytes.Split(contents, []byte("\n"))
+	if line < 1 || line > len(lines) {
+		return contents
+	}
+	lines[line-1] = nil
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// suppressionRuleIDs returns the rule ids that follow a given directive on a line.
+func suppressionRuleIDs(text, directive string) []string {
+	index := strings.Index(text, directive)
+	if index < 0 {
+		return nil
+	}
+	fields := strings.Fields(text[index+len(directive):])
+	if len(fields) == 0 {
+		return nil
+	}
+	var ids []string
+	for _, id := range strings.Split(fields[0], ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,89 @@
+package profanity
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestParseSuppressions(t *testing.T) {
+	assert := assert.New(t)
+
+	s := ParseSuppressions([]byte("package foo\n\n// profanity:disable=NO_FOO,NO_BAR\n/* profanity:disable-next-line=NO_BUZZ */\nvar buzz = 1\n"))
+	assert.True(s.File["NO_FOO"])
+	assert.True(s.File["NO_BAR"])
+	assert.False(s.File["NO_BUZZ"])
+	assert.True(s.Lines[5]["NO_BUZZ"])
+
+	assert.True(s.Has(Rule{ID: "NO_FOO"}, RuleResult{}))
+	assert.True(s.Has(Rule{ID: "NO_BUZZ"}, RuleResult{Line: 5}))
+	assert.False(s.Has(Rule{ID: "NO_BUZZ"}, RuleResult{Line: 4}))
+	assert.False(s.Has(Rule{ID: "NO_BUZZ"}, RuleResult{}))
+
+	assert.Empty(ParseSuppressions([]byte("package foo\n")).File)
+	assert.Empty(ParseSuppressions([]byte("// profanity:disable=\n")).File)
+}
+
+func TestSuppressionsApply(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := Rule{ID: "NO_FOO", Contains: []string{"foo"}}
+	contents := []byte("// profanity:disable-next-line=NO_FOO\nfoo\nbar\nfoo\n")
+	res, suppressed := ParseSuppressions(contents).Apply(rule, "file.txt", contents)
+	assert.False(res.OK, "a failure on a later line should still be found")
+	assert.Equal(4, res.Line)
+	assert.Equal(1, suppressed)
+
+	contents = []byte("// profanity:disable=NO_FOO\nfoo\n")
+	res, suppressed = ParseSuppressions(contents).Apply(rule, "file.txt", contents)
+	assert.True(res.OK)
+	assert.Equal(1, suppressed)
+}
+
+func TestProcessSuppressions(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FOO:
+  description: "no foo"
+  contains: [ "foo" ]
+NO_BAR:
+  description: "no bar"
+  contains: [ "bar" ]
+`,
+		"file.txt":        "# profanity:disable=NO_FOO\nfoo\nfoo\n",
+		"next.txt":        "# profanity:disable-next-line=NO_BAR\nbar\n",
+		"other.txt":       "# profanity:disable-next-line=NO_FOO\nbar\n",
+		"unsuppressed.go": "package foo\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.True(ex.Is(err, ErrFailure))
+	assert.Contains(stderr, "other.txt")
+	assert.Contains(stderr, "unsuppressed.go")
+	assert.NotContains(stderr, "file.txt")
+	assert.NotContains(stderr, "next.txt")
+	assert.Contains(stderr, "scanned 4 file(s), 2 violation(s) across 2 rule(s)")
+	assert.Contains(stderr, "suppressed 2 inline violation(s)")
+}
+
+func TestProcessSuppressionsOK(t *testing.T) {
+	assert := assert.New(t)
+
+	root, cleanup := fixtures(t, map[string]string{
+		DefaultRulesFile: `
+NO_FOO:
+  description: "no foo"
+  contains: [ "foo" ]
+`,
+		"file.txt": "bar\n// profanity:disable-next-line=NO_FOO\nfoo\n",
+	})
+	defer cleanup()
+
+	_, stderr, err := process(root)
+	assert.Nil(err)
+	assert.Contains(stderr, "suppressed 1 inline violation(s)")
+}
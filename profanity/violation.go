@@ -0,0 +1,25 @@
+package profanity
+
+import "path/filepath"
+
+// NewViolation returns a violation for a failed rule result.
+func NewViolation(file string, rule Rule, res RuleResult) Violation {
+	return Violation{
+		File:        filepath.ToSlash(file),
+		Line:        res.Line,
+		Rule:        rule.ID,
+		Description: rule.Description,
+		Severity:    rule.SeverityOrDefault(),
+		Message:     res.Message,
+	}
+}
+
+// Violation is a rule failure, as it is written in the `jsonl` format.
+type Violation struct {
+	File        string `json:"file"`
+	Line        int    `json:"line,omitempty"`
+	Rule        string `json:"rule"`
+	Description string `json:"description,omitempty"`
+	Severity    string `json:"severity"`
+	Message     string `json:"message"`
+}
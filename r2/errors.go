@@ -6,6 +6,12 @@ import (
 
 // Error Constants
 const (
-	ErrNoContentJSON ex.Class = "server returned an http 204 for a request expecting json"
-	ErrNoContentXML  ex.Class = "server returned an http 204 for a request expecting xml"
+	ErrNoContentJSON    ex.Class = "server returned an http 204 for a request expecting json"
+	ErrNoContentXML     ex.Class = "server returned an http 204 for a request expecting xml"
+	ErrUnexpectedStatus ex.Class = "server returned an unexpected http status"
 )
+
+// IsUnexpectedStatus returns if an error is an unexpected status error.
+func IsUnexpectedStatus(err error) bool {
+	return ex.Is(err, ErrUnexpectedStatus)
+}
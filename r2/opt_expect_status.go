@@ -0,0 +1,44 @@
+package r2
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// ExpectStatusBodyLimit is the maximum number of bytes of the response body included in unexpected status errors.
+const ExpectStatusBodyLimit = 1 << 10
+
+// OptExpectStatus returns an `ErrUnexpectedStatus` error from any of the methods that
+// execute the request if the response status code is not one of a given list of codes.
+// If no codes are given, any 2xx status code is expected.
+//
+// The error message includes the start of the response body, which is closed.
+func OptExpectStatus(statusCodes ...int) Option {
+	return OptOnResponse(func(req *http.Request, res *http.Response, _ time.Time, err error) error {
+		if err != nil || res == nil {
+			return err
+		}
+		if isExpectedStatus(res.StatusCode, statusCodes) {
+			return nil
+		}
+		defer res.Body.Close()
+		body, _ := ioutil.ReadAll(io.LimitReader(res.Body, ExpectStatusBodyLimit))
+		return ex.New(ErrUnexpectedStatus, ex.OptMessagef("%s %s; status: %d, body: %s", req.Method, req.URL.String(), res.StatusCode, string(body)))
+	})
+}
+
+func isExpectedStatus(statusCode int, statusCodes []int) bool {
+	if len(statusCodes) == 0 {
+		return statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices
+	}
+	for _, expected := range statusCodes {
+		if statusCode == expected {
+			return true
+		}
+	}
+	return false
+}
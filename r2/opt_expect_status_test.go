@@ -0,0 +1,75 @@
+package r2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/webutil"
+)
+
+type expectStatusTestObject struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestOptExpectStatusJSONRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var obj expectStatusTestObject
+		if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		obj.ID++
+		w.Header().Set(webutil.HeaderContentType, webutil.ContentTypeApplicationJSON)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(obj)
+	}))
+	defer server.Close()
+
+	var output expectStatusTestObject
+	res, err := New(server.URL,
+		OptPost(),
+		OptJSONBody(expectStatusTestObject{ID: 1, Name: "foo"}),
+		OptExpectStatus(),
+	).JSON(&output)
+	assert.Nil(err)
+	assert.Equal(http.StatusCreated, res.StatusCode)
+	assert.Equal(2, output.ID)
+	assert.Equal("foo", output.Name)
+}
+
+func TestOptExpectStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "not found")
+	}))
+	defer server.Close()
+
+	var output expectStatusTestObject
+	_, err := New(server.URL, OptExpectStatus()).JSON(&output)
+	assert.NotNil(err)
+	assert.True(IsUnexpectedStatus(err))
+	assert.Contains(ex.ErrMessage(err), "status: 404")
+	assert.Contains(ex.ErrMessage(err), "body: not found")
+
+	_, err = New(server.URL, OptExpectStatus(http.StatusOK, http.StatusNoContent)).Discard()
+	assert.True(IsUnexpectedStatus(err))
+
+	res, err := New(server.URL, OptExpectStatus(http.StatusNotFound)).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+
+	// without the option, error statuses are returned as responses.
+	res, err = New(server.URL).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+}
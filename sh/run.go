@@ -0,0 +1,123 @@
+package sh
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// RunStats are statistics about a command run.
+type RunStats struct {
+	// StdoutBytes is the number of bytes the command wrote to stdout.
+	StdoutBytes int64
+	// StderrBytes is the number of bytes the command wrote to stderr.
+	StderrBytes int64
+	// Elapsed is how long the command ran for.
+	Elapsed time.Duration
+	// ExitCode is the exit code of the command, or -1 if it did not exit, e.g. if it was killed by a signal.
+	ExitCode int
+}
+
+// Run runs a command with a given list of arguments, streaming its stdout and stderr to given writers.
+// It resolves the command name in your $PATH list for you.
+// It returns statistics about the run, including the number of bytes written to each stream.
+func Run(stdout, stderr io.Writer, command string, args ...string) (RunStats, error) {
+	cmd, err := Cmd(command, args...)
+	if err != nil {
+		return RunStats{ExitCode: -1}, err
+	}
+	return RunCmd(cmd, stdout, stderr)
+}
+
+// RunContext runs a command with a given list of arguments within a context, streaming its stdout and stderr to given writers.
+// It resolves the command name in your $PATH list for you.
+// It returns statistics about the run, including the number of bytes written to each stream.
+func RunContext(ctx context.Context, stdout, stderr io.Writer, command string, args ...string) (RunStats, error) {
+	cmd, err := CmdContext(ctx, command, args...)
+	if err != nil {
+		return RunStats{ExitCode: -1}, err
+	}
+	return RunCmd(cmd, stdout, stderr)
+}
+
+// RunCmd runs a given command, streaming its stdout and stderr to given writers, which may be nil to discard the output.
+//
+// Each stream is copied in its own goroutine so a command with large output on both cannot block.
+// If a writer returns an error, the rest of its stream is read and discarded, and the error is returned once the command exits.
+// If the command fails, the error is returned as is, e.g. as an `*exec.ExitError`, and the exit code is set on the stats.
+func RunCmd(cmd *exec.Cmd, stdout, stderr io.Writer) (stats RunStats, err error) {
+	stats.ExitCode = -1
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return stats, ex.New(err)
+	}
+	stderrPipe, err := cmd.StderrPipe()
+	if err != nil {
+		return stats, ex.New(err)
+	}
+
+	started := time.Now()
+	if err = cmd.Start(); err != nil {
+		return stats, err
+	}
+
+	var wg sync.WaitGroup
+	var stdoutErr, stderrErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		stats.StdoutBytes, stdoutErr = copyAll(stdout, stdoutPipe)
+	}()
+	go func() {
+		defer wg.Done()
+		stats.StderrBytes, stderrErr = copyAll(stderr, stderrPipe)
+	}()
+	wg.Wait()
+
+	err = cmd.Wait()
+	stats.Elapsed = time.Since(started)
+	if cmd.ProcessState != nil {
+		stats.ExitCode = cmd.ProcessState.ExitCode()
+	}
+	if err != nil {
+		return
+	}
+	if stdoutErr != nil {
+		err = ex.New(stdoutErr)
+	} else if stderrErr != nil {
+		err = ex.New(stderrErr)
+	}
+	return
+}
+
+// copyAll copies a reader to a writer, returning the number of bytes read.
+// If the writer is nil or returns an error, the rest of the reader is discarded.
+func copyAll(w io.Writer, r io.Reader) (int64, error) {
+	counter := &countingReader{Reader: r}
+	if w == nil {
+		w = ioutil.Discard
+	}
+	_, err := io.Copy(w, counter)
+	if err != nil {
+		_, _ = io.Copy(ioutil.Discard, counter)
+	}
+	return counter.Count, err
+}
+
+// countingReader counts the bytes read from a reader.
+type countingReader struct {
+	io.Reader
+	Count int64
+}
+
+// Read implements io.Reader.
+func (cr *countingReader) Read(p []byte) (n int, err error) {
+	n, err = cr.Reader.Read(p)
+	cr.Count += int64(n)
+	return
+}
@@ -0,0 +1,61 @@
+package sh
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestRun(t *testing.T) {
+	assert := assert.New(t)
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	stats, err := Run(stdout, stderr, "sh", "-c", "printf 'hello world'; printf 'oops' >&2")
+	assert.Nil(err)
+	assert.Equal("hello world", stdout.String())
+	assert.Equal("oops", stderr.String())
+	assert.Equal(11, stats.StdoutBytes)
+	assert.Equal(4, stats.StderrBytes)
+	assert.Zero(stats.ExitCode)
+	assert.NotZero(stats.Elapsed)
+}
+
+func TestRunLargeOutput(t *testing.T) {
+	assert := assert.New(t)
+
+	// write more than a pipe buffer to both streams, alternating between them.
+	stdout := new(bytes.Buffer)
+	stats, err := Run(stdout, nil, "sh", "-c", "for i in 1 2 3 4; do head -c 262144 /dev/zero; head -c 131072 /dev/zero >&2; done")
+	assert.Nil(err)
+	assert.Equal(4*262144, stats.StdoutBytes)
+	assert.Equal(4*131072, stats.StderrBytes)
+	assert.Equal(4*262144, stdout.Len())
+}
+
+func TestRunExitCode(t *testing.T) {
+	assert := assert.New(t)
+
+	stats, err := Run(nil, nil, "sh", "-c", "printf 'abc'; exit 3")
+	assert.NotNil(err)
+	_, isExitErr := err.(*exec.ExitError)
+	assert.True(isExitErr)
+	assert.Equal(3, stats.ExitCode)
+	assert.Equal(3, stats.StdoutBytes)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(_ []byte) (int, error) { return 0, fmt.Errorf("write failed") }
+
+func TestRunWriterError(t *testing.T) {
+	assert := assert.New(t)
+
+	stats, err := Run(failingWriter{}, nil, "sh", "-c", "head -c 262144 /dev/zero")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "write failed")
+	assert.Zero(stats.ExitCode, "the command should not block on the failed writer")
+	assert.Equal(262144, stats.StdoutBytes)
+}
@@ -0,0 +1,51 @@
+package stringutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatDuration returns a human friendly string representation of a duration.
+//
+// Durations are rounded to a precision relative to their size, e.g.
+// `850ns`, `350µs`, `350ms`, `1.2s`, or `2m3s`; zero components of durations
+// of a minute or longer are omitted, e.g. `1h5s`.
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + FormatDuration(-d)
+	}
+	if d == 0 {
+		return "0s"
+	}
+	if d < time.Microsecond {
+		return fmt.Sprintf("%dns", d)
+	}
+	if rounded := d.Round(time.Microsecond); rounded < time.Millisecond {
+		return fmt.Sprintf("%dµs", rounded/time.Microsecond)
+	}
+	if rounded := d.Round(time.Millisecond); rounded < time.Second {
+		return fmt.Sprintf("%dms", rounded/time.Millisecond)
+	}
+	if rounded := d.Round(100 * time.Millisecond); rounded < time.Minute {
+		return strconv.FormatFloat(rounded.Seconds(), 'f', -1, 64) + "s"
+	}
+
+	rounded := d.Round(time.Second)
+	hours := rounded / time.Hour
+	minutes := (rounded % time.Hour) / time.Minute
+	seconds := (rounded % time.Minute) / time.Second
+
+	var output strings.Builder
+	if hours > 0 {
+		fmt.Fprintf(&output, "%dh", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&output, "%dm", minutes)
+	}
+	if seconds > 0 {
+		fmt.Fprintf(&output, "%ds", seconds)
+	}
+	return output.String()
+}
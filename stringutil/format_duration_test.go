@@ -0,0 +1,33 @@
+package stringutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestFormatDuration(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := [...]struct {
+		Input    time.Duration
+		Expected string
+	}{
+		{Input: 0, Expected: "0s"},
+		{Input: 850 * time.Nanosecond, Expected: "850ns"},
+		{Input: 350*time.Microsecond + 400*time.Nanosecond, Expected: "350µs"},
+		{Input: 999*time.Microsecond + 600*time.Nanosecond, Expected: "1ms"},
+		{Input: 350*time.Millisecond + 400*time.Microsecond, Expected: "350ms"},
+		{Input: time.Second, Expected: "1s"},
+		{Input: 1234 * time.Millisecond, Expected: "1.2s"},
+		{Input: 59960 * time.Millisecond, Expected: "1m"},
+		{Input: 2*time.Minute + 3*time.Second + 400*time.Millisecond, Expected: "2m3s"},
+		{Input: time.Hour + 5*time.Second, Expected: "1h5s"},
+		{Input: -1500 * time.Millisecond, Expected: "-1.5s"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(tc.Expected, FormatDuration(tc.Input), tc.Input.String())
+	}
+}
@@ -0,0 +1,38 @@
+package tracing
+
+import "context"
+
+// Attribute keys.
+const (
+	AttributeHTTPMethod     = "http.method"
+	AttributeHTTPRoute      = "http.route"
+	AttributeHTTPStatusCode = "http.status_code"
+	AttributeElapsed        = "elapsed"
+)
+
+// Attributes are descriptive fields for a trace.
+type Attributes map[string]interface{}
+
+type attributesKey struct{}
+
+// WithAttributes returns a new context with a given set of attributes added to any existing attributes.
+func WithAttributes(ctx context.Context, attributes Attributes) context.Context {
+	combined := make(Attributes)
+	for key, value := range GetAttributes(ctx) {
+		combined[key] = value
+	}
+	for key, value := range attributes {
+		combined[key] = value
+	}
+	return context.WithValue(ctx, attributesKey{}, combined)
+}
+
+// GetAttributes gets the attributes off a context.
+func GetAttributes(ctx context.Context) Attributes {
+	if raw := ctx.Value(attributesKey{}); raw != nil {
+		if typed, ok := raw.(Attributes); ok {
+			return typed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestAttributes(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := context.Background()
+	assert.Nil(GetAttributes(ctx))
+
+	ctx = WithAttributes(ctx, Attributes{"foo": "bar", "buzz": 1})
+	child := WithAttributes(ctx, Attributes{"buzz": 2, "fuzz": true})
+	assert.Equal(Attributes{"foo": "bar", "buzz": 1}, GetAttributes(ctx), "parent attributes should not change")
+	assert.Equal(Attributes{"foo": "bar", "buzz": 2, "fuzz": true}, GetAttributes(child))
+}
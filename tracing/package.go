@@ -0,0 +1,5 @@
+/*
+Package tracing defines the tracer interface shared by the packages that trace units of work,
+e.g. `cron` job invocations and `web` requests, so a single tracer implementation can trace both.
+*/
+package tracing
@@ -0,0 +1,15 @@
+package tracing
+
+import "context"
+
+// Tracer starts traces, or spans, for named operations.
+type Tracer interface {
+	Start(context.Context, string) (context.Context, TraceFinisher)
+}
+
+// TraceFinisher is a finisher for traces.
+//
+// The context it is given may have attributes of the operation, e.g. the status code of a request; see `GetAttributes`.
+type TraceFinisher interface {
+	Finish(context.Context, error)
+}
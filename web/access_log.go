@@ -0,0 +1,141 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/blend/go-sdk/logger"
+	"github.com/blend/go-sdk/webutil"
+)
+
+// AccessLog defaults and constants.
+const (
+	// FlagHTTPAccess is the logger flag for access log events.
+	FlagHTTPAccess = "http.access"
+
+	// AccessLogFormatCommon is the apache common log format, e.g.
+	// `127.0.0.1 - bailey [10/Oct/2000:13:55:36 -0700] "GET /index.html HTTP/1.1" 200 2326`.
+	AccessLogFormatCommon = "common"
+	// AccessLogFormatCombined is the apache combined log format, which is the common
+	// log format followed by the quoted referer and user agent.
+	AccessLogFormatCombined = "combined"
+	// AccessLogFormatJSON is a json object per request, see `AccessLogEntry`.
+	AccessLogFormatJSON = "json"
+
+	// AccessLogTimeFormat is the apache time format used by the common and combined formats.
+	AccessLogTimeFormat = "02/Jan/2006:15:04:05 -0700"
+)
+
+// AccessLogEntry is the request and response data an access log line is formatted from.
+type AccessLogEntry struct {
+	RemoteAddr    string        `json:"remoteAddr"`
+	User          string        `json:"user,omitempty"`
+	Time          time.Time     `json:"time"`
+	Method        string        `json:"method"`
+	Path          string        `json:"path"`
+	Protocol      string        `json:"protocol"`
+	StatusCode    int           `json:"statusCode"`
+	ContentLength int           `json:"contentLength"`
+	Elapsed       time.Duration `json:"elapsed"`
+	Referer       string        `json:"referer,omitempty"`
+	UserAgent     string        `json:"userAgent,omitempty"`
+}
+
+// Format formats the entry in a given access log format; it defaults to the common log format.
+func (ale AccessLogEntry) Format(format string) string {
+	switch format {
+	case AccessLogFormatJSON:
+		contents, _ := json.Marshal(ale)
+		return string(contents)
+	case AccessLogFormatCombined:
+		return fmt.Sprintf("%s %q %q", ale.common(), accessLogValue(ale.Referer), accessLogValue(ale.UserAgent))
+	default:
+		return ale.common()
+	}
+}
+
+func (ale AccessLogEntry) common() string {
+	size := "-"
+	if ale.ContentLength > 0 {
+		size = strconv.Itoa(ale.ContentLength)
+	}
+	return fmt.Sprintf("%s - %s [%s] \"%s %s %s\" %d %s",
+		accessLogValue(ale.RemoteAddr),
+		accessLogValue(ale.User),
+		ale.Time.Format(AccessLogTimeFormat),
+		ale.Method, ale.Path, ale.Protocol,
+		ale.StatusCode,
+		size,
+	)
+}
+
+// accessLogValue returns the placeholder `-` for empty values.
+func accessLogValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// AccessLog returns a middleware that logs a line per request in the given format, see `AccessLogFormatCommon`,
+// `AccessLogFormatCombined` and `AccessLogFormatJSON`, through the app logger with the `http.access` flag,
+// which must be enabled on the logger.
+//
+// Lines are logged after the result is rendered so they include the response status, size and elapsed time.
+func AccessLog(format string) Middleware {
+	return func(action Action) Action {
+		return func(r *Ctx) Result {
+			if r.App == nil || r.App.Log == nil {
+				return action(r)
+			}
+			r.Response = &accessLogResponseWriter{
+				ResponseWriter: r.Response,
+				onClose: func() {
+					r.App.Log.Trigger(r.Context(), logger.NewMessageEvent(FlagHTTPAccess, newAccessLogEntry(r).Format(format)))
+				},
+			}
+			return action(r)
+		}
+	}
+}
+
+func newAccessLogEntry(r *Ctx) AccessLogEntry {
+	entry := AccessLogEntry{
+		RemoteAddr:    webutil.GetRemoteAddr(r.Request),
+		Time:          r.RequestStart,
+		Method:        r.Request.Method,
+		Path:          r.Request.URL.RequestURI(),
+		Protocol:      r.Request.Proto,
+		StatusCode:    r.Response.StatusCode(),
+		ContentLength: r.Response.ContentLength(),
+		Elapsed:       r.Elapsed(),
+		Referer:       r.Request.Referer(),
+		UserAgent:     webutil.GetUserAgent(r.Request),
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	if user, _, ok := r.Request.BasicAuth(); ok {
+		entry.User = user
+	} else if r.Request.URL.User != nil {
+		entry.User = r.Request.URL.User.Username()
+	}
+	return entry
+}
+
+// accessLogResponseWriter calls a callback when the response is closed after the result is rendered.
+type accessLogResponseWriter struct {
+	ResponseWriter
+	onClose func()
+}
+
+// Close calls the close callback and closes the inner response.
+func (alrw *accessLogResponseWriter) Close() error {
+	if alrw.onClose != nil {
+		alrw.onClose()
+		alrw.onClose = nil
+	}
+	return alrw.ResponseWriter.Close()
+}
@@ -0,0 +1,115 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/logger"
+	"github.com/blend/go-sdk/r2"
+)
+
+func accessLogTestApp(assert *assert.Assertions, format string) (*App, *bytes.Buffer) {
+	output := new(bytes.Buffer)
+	log := logger.MustNew(logger.OptEnabled(FlagHTTPAccess), logger.OptOutput(output), logger.OptText(logger.OptTextNoColor(), logger.OptTextHideTimestamp()))
+	app, err := New(OptLog(log))
+	assert.Nil(err)
+
+	app.GET("/users/:id", func(r *Ctx) Result {
+		return Text.Result("hello")
+	}, AccessLog(format))
+	app.GET("/empty", func(r *Ctx) Result {
+		return NoContent
+	}, AccessLog(format))
+	return app, output
+}
+
+// accessLogLine returns the logged access log line without the logger flag prefix and context labels.
+func accessLogLine(output *bytes.Buffer) string {
+	line := strings.TrimPrefix(strings.TrimSpace(output.String()), "["+FlagHTTPAccess+"] ")
+	if index := strings.Index(line, "\t"); index >= 0 {
+		return line[:index]
+	}
+	return line
+}
+
+func TestAccessLogCommon(t *testing.T) {
+	assert := assert.New(t)
+
+	app, output := accessLogTestApp(assert, AccessLogFormatCommon)
+	_, err := MockGet(app, "/users/1", r2.OptQueryValue("verbose", "true"), r2.OptBasicAuth("bailey", "hunter2")).Discard()
+	assert.Nil(err)
+
+	logged := accessLogLine(output)
+	expr := regexp.MustCompile(`^127\.0\.0\.1 - bailey \[\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "GET /users/1\?verbose=true HTTP/1\.1" 200 5$`)
+	assert.True(expr.MatchString(logged), logged)
+	assert.NotContains(logged, "hunter2")
+}
+
+func TestAccessLogCommonEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	app, output := accessLogTestApp(assert, "")
+	_, err := MockGet(app, "/empty").Discard()
+	assert.Nil(err)
+
+	logged := accessLogLine(output)
+	assert.True(strings.HasSuffix(logged, `] "GET /empty HTTP/1.1" 204 -`), logged)
+	assert.True(strings.HasPrefix(logged, "127.0.0.1 - - ["), logged)
+}
+
+func TestAccessLogCombined(t *testing.T) {
+	assert := assert.New(t)
+
+	app, output := accessLogTestApp(assert, AccessLogFormatCombined)
+	_, err := MockGet(app, "/users/1",
+		r2.OptHeaderValue("Referer", "https://example.com/"),
+		r2.OptHeaderValue("User-Agent", "go-sdk/test"),
+	).Discard()
+	assert.Nil(err)
+
+	logged := accessLogLine(output)
+	assert.True(strings.HasSuffix(logged, `"GET /users/1 HTTP/1.1" 200 5 "https://example.com/" "go-sdk/test"`), logged)
+}
+
+func TestAccessLogJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	app, output := accessLogTestApp(assert, AccessLogFormatJSON)
+	before := time.Now().UTC().Add(-time.Second)
+	_, err := MockGet(app, "/users/1", r2.OptHeaderValue("User-Agent", "go-sdk/test")).Discard()
+	assert.Nil(err)
+
+	logged := accessLogLine(output)
+	var entry AccessLogEntry
+	assert.Nil(json.Unmarshal([]byte(logged), &entry), logged)
+	assert.Equal("127.0.0.1", entry.RemoteAddr)
+	assert.Empty(entry.User)
+	assert.Equal(http.MethodGet, entry.Method)
+	assert.Equal("/users/1", entry.Path)
+	assert.Equal("HTTP/1.1", entry.Protocol)
+	assert.Equal(http.StatusOK, entry.StatusCode)
+	assert.Equal(5, entry.ContentLength)
+	assert.True(entry.Elapsed > 0)
+	assert.True(entry.Time.After(before))
+	assert.Equal("go-sdk/test", entry.UserAgent)
+}
+
+func TestAccessLogDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	output := new(bytes.Buffer)
+	log := logger.MustNew(logger.OptOutput(output), logger.OptText(logger.OptTextNoColor()))
+	app, err := New(OptLog(log))
+	assert.Nil(err)
+	app.GET("/", func(r *Ctx) Result { return NoContent }, AccessLog(AccessLogFormatCommon))
+
+	_, err = MockGet(app, "/").Discard()
+	assert.Nil(err)
+	assert.Empty(output.String())
+}
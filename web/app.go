@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/blend/go-sdk/async"
 	"github.com/blend/go-sdk/ex"
@@ -33,6 +34,7 @@ func New(options ...Option) (*App, error) {
 		DefaultHeaders:  CopyHeaders(DefaultHeaders),
 		Views:           views,
 		DefaultProvider: views,
+		Collector:       NopCollector{},
 	}
 
 	var err error
@@ -44,6 +46,24 @@ func New(options ...Option) (*App, error) {
 	return &a, nil
 }
 
+// NewFromConfig returns a new web app from a given config, with the
+// underlying http server set from the config.
+//
+// The config is typically read with `configutil.Read` which will call its `Resolve` method.
+// Any options given are applied after the config.
+func NewFromConfig(cfg Config, options ...Option) (*App, error) {
+	a, err := New(append([]Option{OptConfig(cfg)}, options...)...)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range append(a.httpServerOptions(), a.ServerOptions...) {
+		if err = opt(a.Server); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
 // App is the server for the app.
 type App struct {
 	*async.Latch
@@ -54,7 +74,7 @@ type App struct {
 	TLSConfig               *tls.Config
 	Server                  *http.Server
 	ServerOptions           []webutil.HTTPServerOption
-	Listener                *net.TCPListener
+	Listener                net.Listener
 	DefaultHeaders          http.Header
 	Statics                 map[string]*StaticFileServer
 	Routes                  map[string]*RouteNode
@@ -63,8 +83,12 @@ type App struct {
 	PanicAction             PanicAction
 	DefaultMiddleware       []Middleware
 	Tracer                  Tracer
+	Collector               Collector
 	DefaultProvider         ResultProvider
 	State                   *SyncState
+	StartHooks              []LifecycleHook
+	StartedHooks            []LifecycleHook
+	ShutdownHooks           []LifecycleHook
 }
 
 // Use adds a new default middleware to the middleware chain.
@@ -72,7 +96,54 @@ func (a *App) Use(middleware Middleware) {
 	a.DefaultMiddleware = append(a.DefaultMiddleware, middleware)
 }
 
-// Start starts the server and binds to the given address.
+// WithNotFoundHandler sets the handler for requests that do not match a route.
+// Use `app.RenderAction(action)` to handle them with an action.
+func (a *App) WithNotFoundHandler(handler Handler) {
+	a.NotFoundHandler = handler
+}
+
+// WithMethodNotAllowedHandler sets the handler for requests that match a route for a different method,
+// and enables method not allowed handling.
+//
+// The allowed methods are set on the `Allow` response header before the handler is called,
+// and can be read from actions with `ctx.AllowedMethods()`.
+func (a *App) WithMethodNotAllowedHandler(handler Handler) {
+	a.MethodNotAllowedHandler = handler
+	a.Config.HandleMethodNotAllowed = true
+}
+
+// WithReadTimeout sets the maximum duration for reading an entire request, including the body.
+// It is applied to the underlying server when the app is started.
+func (a *App) WithReadTimeout(timeout time.Duration) {
+	a.Config.ReadTimeout = timeout
+}
+
+// WithWriteTimeout sets the maximum duration before timing out writes of the response.
+// It is applied to the underlying server when the app is started.
+func (a *App) WithWriteTimeout(timeout time.Duration) {
+	a.Config.WriteTimeout = timeout
+}
+
+// WithIdleTimeout sets the maximum amount of time to wait for the next request on a keep-alive connection.
+// It is applied to the underlying server when the app is started.
+func (a *App) WithIdleTimeout(timeout time.Duration) {
+	a.Config.IdleTimeout = timeout
+}
+
+// WithMaxHeaderBytes sets the maximum number of bytes the server will read parsing the request headers.
+// It is applied to the underlying server when the app is started.
+func (a *App) WithMaxHeaderBytes(maxHeaderBytes int) {
+	a.Config.MaxHeaderBytes = maxHeaderBytes
+}
+
+// WithListener sets the listener the server accepts connections on, instead of binding to the config bind address.
+// Use `ListenerFromEnv()` to inherit a listener, e.g. from systemd socket activation or a parent process.
+func (a *App) WithListener(listener net.Listener) {
+	a.Listener = listener
+}
+
+// Start starts the server and binds to the given address,
+// or serves on the listener set with `WithListener`.
 func (a *App) Start() (err error) {
 	if !a.Latch.CanStart() {
 		return ex.New(async.ErrCannotStart)
@@ -89,25 +160,30 @@ func (a *App) Start() (err error) {
 	if err != nil {
 		return
 	}
+	err = a.runStartHooks(context.Background())
+	if err != nil {
+		return
+	}
 
 	serverProtocol := "http"
 	if a.Server.TLSConfig != nil {
 		serverProtocol = "https (tls)"
 	}
-	if a.Server.Addr == "" {
-		a.Server.Addr = a.Config.BindAddrOrDefault()
-	}
-	var listener net.Listener
-	listener, err = net.Listen("tcp", a.Server.Addr)
-	if err != nil {
-		err = ex.New(err)
-		return
+	if a.Listener != nil {
+		a.Server.Addr = a.Listener.Addr().String()
+	} else {
+		if a.Server.Addr == "" {
+			a.Server.Addr = a.Config.BindAddrOrDefault()
+		}
+		a.Listener, err = net.Listen("tcp", a.Server.Addr)
+		if err != nil {
+			err = ex.New(err)
+			return
+		}
 	}
-	var ok bool
-	a.Listener, ok = listener.(*net.TCPListener)
-	if !ok {
-		err = ex.New("listener returned was not a net.TCPListener")
-		return
+	listener := a.Listener
+	if typed, ok := listener.(*net.TCPListener); ok {
+		listener = TCPKeepAliveListener{typed}
 	}
 
 	logger.MaybeInfof(a.Log, "%s server started, listening on %s", serverProtocol, a.Server.Addr)
@@ -116,11 +192,17 @@ func (a *App) Start() (err error) {
 	}
 
 	var shutdownErr error
+	a.runStartedHooks(context.Background())
 	a.Started()
 	if a.Server.TLSConfig != nil {
-		shutdownErr = a.Server.Serve(tls.NewListener(TCPKeepAliveListener{a.Listener}, a.Server.TLSConfig))
+		// advertise http/2 if the tls config does not specify protocols;
+		// `Serve` only configures http/2 for tls configs that include it.
+		if len(a.Server.TLSConfig.NextProtos) == 0 {
+			a.Server.TLSConfig.NextProtos = []string{"h2", "http/1.1"}
+		}
+		shutdownErr = a.Server.Serve(tls.NewListener(listener, a.Server.TLSConfig))
 	} else {
-		shutdownErr = a.Server.Serve(TCPKeepAliveListener{a.Listener})
+		shutdownErr = a.Server.Serve(listener)
 	}
 	if shutdownErr != nil && shutdownErr != http.ErrServerClosed {
 		err = ex.New(shutdownErr)
@@ -131,6 +213,20 @@ func (a *App) Start() (err error) {
 	return
 }
 
+// StartTLS starts the server with tls using a given cert and key file, and blocks.
+// Any existing tls config, e.g. a client cert pool, is kept and its certificates are replaced.
+func (a *App) StartTLS(certFile, keyFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return ex.New(err)
+	}
+	if a.TLSConfig == nil {
+		a.TLSConfig = &tls.Config{}
+	}
+	a.TLSConfig.Certificates = []tls.Certificate{cert}
+	return a.Start()
+}
+
 // Stop stops the server.
 func (a *App) Stop() error {
 	if !a.CanStop() {
@@ -154,7 +250,7 @@ func (a *App) Stop() error {
 	a.Listener = nil
 	logger.MaybeInfof(a.Log, "server shutdown complete")
 
-	return nil
+	return a.runShutdownHooks(ctx)
 }
 
 // Register registers controllers with the app's router.
@@ -382,6 +478,7 @@ func (a *App) RenderAction(action Action) Handler {
 		ctx := a.createCtx(NewRawResponseWriter(w), r, route, p)
 		ctx.onRequestStart()
 		a.maybeLogTrigger(ctx.Context(), a.httpRequestEvent(ctx))
+		a.collectRequest(ctx)
 
 		if a.Tracer != nil {
 			tf = a.Tracer.Start(ctx)
@@ -432,6 +529,7 @@ func (a *App) RenderAction(action Action) Handler {
 		ctx.onRequestFinish()
 		ctx.Response.Close()
 		a.maybeLogTrigger(ctx.Context(), a.httpResponseEvent(ctx))
+		a.collectResponse(ctx)
 		if tf != nil {
 			tf.Finish(ctx, err)
 		}
@@ -573,8 +671,7 @@ func (a *App) httpResponseEvent(ctx *Ctx) webutil.HTTPResponseEvent {
 }
 
 func (a *App) recover(w http.ResponseWriter, req *http.Request) {
-	if rcv := recover(); rcv != nil {
-		err := ex.New(rcv)
+	if err := ex.Recover(recover()); err != nil {
 		a.maybeLogFatal(req.Context(), err, req)
 		if a.PanicAction != nil {
 			a.RenderAction(func(ctx *Ctx) Result {
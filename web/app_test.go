@@ -2,14 +2,18 @@ package web
 
 import (
 	"bytes"
+	"crypto/tls"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/async"
 	"github.com/blend/go-sdk/env"
 	"github.com/blend/go-sdk/ex"
 	"github.com/blend/go-sdk/graceful"
@@ -79,6 +83,90 @@ func TestAppNewFromConfig(t *testing.T) {
 	assert.True(app.Views.LiveReload, "we should use the view cache config for the view cache")
 }
 
+func TestNewFromConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	app, err := NewFromConfig(Config{
+		BindAddr:          "127.0.0.1:5555",
+		MaxHeaderBytes:    128,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       6 * time.Second,
+		IdleTimeout:       7 * time.Second,
+		WriteTimeout:      8 * time.Second,
+	}, OptLog(logger.None()))
+	assert.Nil(err)
+	assert.NotNil(app.Log)
+
+	assert.Equal("127.0.0.1:5555", app.Server.Addr)
+	assert.Equal(128, app.Server.MaxHeaderBytes)
+	assert.Equal(5*time.Second, app.Server.ReadHeaderTimeout)
+	assert.Equal(6*time.Second, app.Server.ReadTimeout)
+	assert.Equal(7*time.Second, app.Server.IdleTimeout)
+	assert.Equal(8*time.Second, app.Server.WriteTimeout)
+	assert.Equal(app, app.Server.Handler)
+	assert.Nil(app.Server.TLSConfig)
+}
+
+func TestAppWithTimeouts(t *testing.T) {
+	assert := assert.New(t)
+
+	app, err := New(OptBindAddr("127.0.0.1:0"))
+	assert.Nil(err)
+	app.WithReadTimeout(6 * time.Second)
+	app.WithWriteTimeout(8 * time.Second)
+	app.WithIdleTimeout(7 * time.Second)
+	app.WithMaxHeaderBytes(128)
+
+	go app.Start()
+	<-app.NotifyStarted()
+	defer app.Stop()
+
+	assert.Equal(6*time.Second, app.Server.ReadTimeout)
+	assert.Equal(8*time.Second, app.Server.WriteTimeout)
+	assert.Equal(7*time.Second, app.Server.IdleTimeout)
+	assert.Equal(128, app.Server.MaxHeaderBytes)
+}
+
+func TestNewFromConfigDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	app, err := NewFromConfig(Config{})
+	assert.Nil(err)
+
+	var defaults Config
+	assert.Equal(defaults.BindAddrOrDefault(), app.Server.Addr)
+	assert.Equal(defaults.MaxHeaderBytesOrDefault(), app.Server.MaxHeaderBytes)
+	assert.Equal(defaults.ReadHeaderTimeoutOrDefault(), app.Server.ReadHeaderTimeout)
+	assert.Equal(defaults.ReadTimeoutOrDefault(), app.Server.ReadTimeout)
+	assert.Equal(defaults.IdleTimeoutOrDefault(), app.Server.IdleTimeout)
+	assert.Equal(defaults.WriteTimeoutOrDefault(), app.Server.WriteTimeout)
+}
+
+func TestNewFromConfigTLS(t *testing.T) {
+	assert := assert.New(t)
+
+	app, err := NewFromConfig(Config{
+		TLS: TLSConfig{
+			CertPath: "testdata/testcert.pem",
+			KeyPath:  "testdata/testkey.pem",
+			CAPaths:  []string{"testdata/testcert.pem"},
+		},
+	})
+	assert.Nil(err)
+	assert.NotNil(app.TLSConfig)
+	assert.NotNil(app.Server.TLSConfig)
+	assert.Len(app.Server.TLSConfig.Certificates, 1)
+	assert.NotNil(app.Server.TLSConfig.ClientCAs)
+
+	_, err = NewFromConfig(Config{
+		TLS: TLSConfig{
+			CertPath: "testdata/not-a-cert.pem",
+			KeyPath:  "testdata/not-a-key.pem",
+		},
+	})
+	assert.NotNil(err)
+}
+
 func TestAppRegister(t *testing.T) {
 	assert := assert.New(t)
 	called := false
@@ -492,6 +580,76 @@ func TestAppAddsDefaultHeaders(t *testing.T) {
 	assert.Equal(PackageName, res.Header.Get(HeaderServer))
 }
 
+func TestAppStartTLS(t *testing.T) {
+	assert := assert.New(t)
+
+	app, err := New(OptBindAddr(DefaultMockBindAddr))
+	assert.Nil(err)
+
+	app.GET("/", func(r *Ctx) Result {
+		return Text.Result(r.Request.Proto)
+	})
+
+	startErrors := make(chan error, 1)
+	go func() { startErrors <- app.StartTLS("testdata/testcert.pem", "testdata/testkey.pem") }()
+	select {
+	case <-app.NotifyStarted():
+	case err := <-startErrors:
+		assert.FailNow(err)
+	}
+	defer app.Stop()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			// the test cert is self signed (and expired).
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			ForceAttemptHTTP2: true,
+		},
+	}
+	res, err := client.Get("https://" + app.Listener.Addr().String() + "/")
+	assert.Nil(err)
+	defer res.Body.Close()
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.NotNil(res.TLS)
+	assert.True(res.TLS.HandshakeComplete)
+	assert.Equal("HTTP/2.0", res.Proto)
+
+	contents, err := ioutil.ReadAll(res.Body)
+	assert.Nil(err)
+	assert.Equal("HTTP/2.0", string(contents))
+}
+
+func TestAppStartTLSInvalidKeyPair(t *testing.T) {
+	assert := assert.New(t)
+
+	app, err := New(OptBindAddr(DefaultMockBindAddr))
+	assert.Nil(err)
+	assert.NotNil(app.StartTLS("testdata/not-a-cert.pem", "testdata/not-a-key.pem"))
+	assert.False(app.IsStarted())
+}
+
+func TestAppLatch(t *testing.T) {
+	assert := assert.New(t)
+
+	app, err := New(OptBindAddr(DefaultMockBindAddr))
+	assert.Nil(err)
+	assert.True(app.IsStopped())
+	assert.True(ex.Is(app.Stop(), async.ErrCannotStop))
+
+	startErrors := make(chan error, 1)
+	go func() { startErrors <- app.Start() }()
+	<-app.NotifyStarted()
+	assert.True(app.IsStarted())
+	assert.True(ex.Is(app.Start(), async.ErrCannotStart))
+
+	stopped := app.NotifyStopped()
+	assert.Nil(app.Stop())
+	<-stopped
+	assert.Nil(<-startErrors)
+	assert.True(app.IsStopped())
+	assert.True(ex.Is(app.Stop(), async.ErrCannotStop))
+}
+
 func TestAppHandlesPanics(t *testing.T) {
 	assert := assert.New(t)
 
@@ -767,3 +925,31 @@ func TestAppNilLoggerPanic(t *testing.T) {
 	assert.Nil(err)
 	assert.Equal(http.StatusInternalServerError, res.StatusCode)
 }
+
+func TestAppWithNotFoundAndMethodNotAllowedHandlers(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.GET("/resource", func(r *Ctx) Result { return Text.Result("ok") })
+	app.PUT("/resource", func(r *Ctx) Result { return Text.Result("ok") })
+
+	app.WithNotFoundHandler(app.RenderAction(func(r *Ctx) Result {
+		return &JSONResult{StatusCode: http.StatusNotFound, Response: map[string]interface{}{"error": "not found", "path": r.Request.URL.Path}}
+	}))
+	app.WithMethodNotAllowedHandler(app.RenderAction(func(r *Ctx) Result {
+		allowed := r.AllowedMethods()
+		sort.Strings(allowed)
+		return &JSONResult{StatusCode: http.StatusMethodNotAllowed, Response: map[string]interface{}{"error": "method not allowed", "allowed": allowed}}
+	}))
+	assert.True(app.Config.HandleMethodNotAllowed)
+
+	contents, res, err := MockGet(app, "/missing").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+	assert.Equal(`{"error":"not found","path":"/missing"}`, strings.TrimSpace(string(contents)))
+
+	contents, res, err = MockMethod(app, http.MethodDelete, "/resource").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusMethodNotAllowed, res.StatusCode)
+	assert.Equal(`{"allowed":["GET","OPTIONS","PUT"],"error":"method not allowed"}`, strings.TrimSpace(string(contents)))
+}
@@ -0,0 +1,360 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/logger"
+	"github.com/blend/go-sdk/webutil"
+)
+
+// BodyLogger defaults and constants.
+const (
+	// FlagHTTPBody is the logger flag for request and response body events.
+	FlagHTTPBody = "http.body"
+	// DefaultBodyLoggerMaxBytes is the default maximum number of body bytes logged.
+	DefaultBodyLoggerMaxBytes = 4096
+	// BodyLoggerRedacted is the value redacted fields are replaced with.
+	BodyLoggerRedacted = "[REDACTED]"
+)
+
+// BodyLoggerOptions are options for the body logger middleware.
+type BodyLoggerOptions struct {
+	// Flag is the logger flag body events are triggered with.
+	Flag string
+	// MaxBytes is the maximum number of bytes of each body to log.
+	MaxBytes int
+	// RedactFields are dot separated json field paths, e.g. `user.password`, whose values are redacted.
+	// Paths descend into arrays, so `users.token` will redact the token field of every element of `users`.
+	// Urlencoded and multipart form fields are redacted by name, e.g. `password`.
+	RedactFields []string
+}
+
+// FlagOrDefault returns the flag or a default.
+func (blo BodyLoggerOptions) FlagOrDefault() string {
+	if blo.Flag != "" {
+		return blo.Flag
+	}
+	return FlagHTTPBody
+}
+
+// MaxBytesOrDefault returns the max bytes or a default.
+func (blo BodyLoggerOptions) MaxBytesOrDefault() int {
+	if blo.MaxBytes > 0 {
+		return blo.MaxBytes
+	}
+	return DefaultBodyLoggerMaxBytes
+}
+
+// BodyLoggerOption mutates body logger options.
+type BodyLoggerOption func(*BodyLoggerOptions)
+
+// OptBodyLoggerFlag sets the logger flag body events are triggered with.
+func OptBodyLoggerFlag(flag string) BodyLoggerOption {
+	return func(blo *BodyLoggerOptions) { blo.Flag = flag }
+}
+
+// OptBodyLoggerMaxBytes sets the maximum number of bytes of each body to log.
+func OptBodyLoggerMaxBytes(maxBytes int) BodyLoggerOption {
+	return func(blo *BodyLoggerOptions) { blo.MaxBytes = maxBytes }
+}
+
+// OptBodyLoggerRedactFields adds json field paths to redact.
+func OptBodyLoggerRedactFields(fields ...string) BodyLoggerOption {
+	return func(blo *BodyLoggerOptions) { blo.RedactFields = append(blo.RedactFields, fields...) }
+}
+
+// BodyLogger returns a middleware that logs request and response bodies
+// through the app logger with the `http.body` flag, which must be enabled on the logger.
+//
+// Only up to the max size of request and response bodies is buffered; the rest of the request body
+// is streamed to the action as it reads it. Json and form bodies have the configured fields redacted
+// before they are truncated to the max size; json and form bodies larger than the max size can't be redacted,
+// so they are logged as redacted entirely. If the logger does not have the flag enabled, bodies are not buffered at all.
+func BodyLogger(options ...BodyLoggerOption) Middleware {
+	var blo BodyLoggerOptions
+	for _, option := range options {
+		option(&blo)
+	}
+	return func(action Action) Action {
+		return func(r *Ctx) Result {
+			if r.App == nil || r.App.Log == nil || !isLogFlagEnabled(r.App.Log, blo.FlagOrDefault()) {
+				return action(r)
+			}
+
+			if r.Request.Body != nil {
+				body := r.Request.Body
+				// read one byte more than the max size to tell if the body is truncated.
+				prefix, err := ioutil.ReadAll(io.LimitReader(body, int64(blo.MaxBytesOrDefault())+1))
+				if err != nil {
+					body.Close()
+					return r.DefaultProvider.InternalError(ex.New(err))
+				}
+				r.Request.Body = bodyLoggerRequestBody{Reader: io.MultiReader(bytes.NewReader(prefix), body), Closer: body}
+				total := len(prefix)
+				if total > blo.MaxBytesOrDefault() {
+					total = int(r.Request.ContentLength)
+				}
+				blo.trigger(r.Context(), r.App.Log, fmt.Sprintf("%s %s request body: %s", r.Request.Method, r.Request.URL.Path, blo.format(r.Request.Header.Get(HeaderContentType), prefix, total)))
+			}
+
+			blrw := &bodyLoggerResponseWriter{
+				ResponseWriter: r.Response,
+				maxBytes:       blo.MaxBytesOrDefault(),
+			}
+			blrw.onClose = func(body []byte, total int) {
+				blo.trigger(r.Context(), r.App.Log, fmt.Sprintf("%s %s response body: %s", r.Request.Method, r.Request.URL.Path, blo.format(blrw.Header().Get(HeaderContentType), body, total)))
+			}
+			r.Response = blrw
+			return action(r)
+		}
+	}
+}
+
+// bodyLoggerRequestBody is a request body that reads the buffered prefix of the body
+// before the rest of the original body, and closes the original body.
+type bodyLoggerRequestBody struct {
+	io.Reader
+	io.Closer
+}
+
+func (blo BodyLoggerOptions) trigger(ctx context.Context, log logger.Triggerable, message string) {
+	log.Trigger(ctx, logger.NewMessageEvent(blo.FlagOrDefault(), message))
+}
+
+// isLogFlagEnabled returns if a flag is enabled on a log, assuming it is if the log does not expose its flags.
+func isLogFlagEnabled(log logger.Log, flag string) bool {
+	if typed, ok := log.(interface{ IsEnabled(string) bool }); ok {
+		return typed.IsEnabled(flag)
+	}
+	return true
+}
+
+// format redacts and truncates a body for logging, where total is the size of the full
+// body of which the given body may only be a prefix, or less than zero if the size is unknown.
+func (blo BodyLoggerOptions) format(contentType string, body []byte, total int) string {
+	if total == 0 && len(body) == 0 {
+		return "(empty)"
+	}
+	truncated := total < 0 || total > len(body)
+	if len(blo.RedactFields) > 0 && isRedactableBody(contentType, body) {
+		// a prefix of a json or form body does not parse, so it can't be redacted.
+		if truncated {
+			return BodyLoggerRedacted + " " + truncatedSuffix(total)
+		}
+		body = blo.redact(contentType, body)
+		total = len(body)
+	}
+	if maxBytes := blo.MaxBytesOrDefault(); len(body) > maxBytes {
+		body = body[:maxBytes]
+		truncated = true
+	}
+	if truncated {
+		return fmt.Sprintf("%s... %s", body, truncatedSuffix(total))
+	}
+	return string(body)
+}
+
+// truncatedSuffix returns the note appended to a truncated body, with the full size of the body if it is known.
+func truncatedSuffix(total int) string {
+	if total < 0 {
+		return "(truncated)"
+	}
+	return fmt.Sprintf("(truncated, %d bytes total)", total)
+}
+
+// isRedactableBody returns if a body is a form body, by content type, or looks like a json object or array.
+func isRedactableBody(contentType string, body []byte) bool {
+	switch mediaType(contentType) {
+	case webutil.ContentTypeApplicationFormEncoded, webutil.ContentTypeMultipartFormData:
+		return true
+	}
+	return isJSONBody(body)
+}
+
+// mediaType returns the media type of a content type, e.g. `application/json` for `application/json; charset=UTF-8`.
+func mediaType(contentType string) string {
+	value, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return value
+}
+
+// isJSONBody returns if a body looks like a json object or array.
+func isJSONBody(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
+
+// redact replaces the values of the redacted fields in a json or form body.
+// Bodies that are not json or form bodies are returned as is.
+func (blo BodyLoggerOptions) redact(contentType string, body []byte) []byte {
+	value, params, _ := mime.ParseMediaType(contentType)
+	switch value {
+	case webutil.ContentTypeApplicationFormEncoded:
+		return blo.redactForm(body)
+	case webutil.ContentTypeMultipartFormData:
+		return blo.redactMultipart(body, params["boundary"])
+	}
+	return blo.redactJSON(body)
+}
+
+// redactJSON replaces the values of the redacted field paths in a json body.
+func (blo BodyLoggerOptions) redactJSON(body []byte) []byte {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return body
+	}
+	for _, field := range blo.RedactFields {
+		redactPath(value, strings.Split(field, "."))
+	}
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+// redactForm replaces the values of the redacted fields in an urlencoded form body, keeping the order of the fields.
+func (blo BodyLoggerOptions) redactForm(body []byte) []byte {
+	output := new(bytes.Buffer)
+	var start int
+	for index := 0; index <= len(body); index++ {
+		if index < len(body) && body[index] != '&' && body[index] != ';' {
+			continue
+		}
+		pair := string(body[start:index])
+		key := pair
+		if split := strings.Index(pair, "="); split >= 0 {
+			key = pair[:split]
+		}
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			return []byte(BodyLoggerRedacted)
+		}
+		if blo.isRedactField(name) {
+			pair = key + "=" + BodyLoggerRedacted
+		}
+		output.WriteString(pair)
+		if index < len(body) {
+			output.WriteByte(body[index])
+		}
+		start = index + 1
+	}
+	return output.Bytes()
+}
+
+// redactMultipart replaces the contents of the redacted fields in a multipart form body.
+// Bodies that do not parse are redacted entirely.
+func (blo BodyLoggerOptions) redactMultipart(body []byte, boundary string) []byte {
+	output := new(bytes.Buffer)
+	writer := multipart.NewWriter(output)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return []byte(BodyLoggerRedacted)
+	}
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return []byte(BodyLoggerRedacted)
+		}
+		partWriter, err := writer.CreatePart(part.Header)
+		if err != nil {
+			return []byte(BodyLoggerRedacted)
+		}
+		if blo.isRedactField(part.FormName()) {
+			_, err = io.WriteString(partWriter, BodyLoggerRedacted)
+		} else {
+			_, err = io.Copy(partWriter, part)
+		}
+		if err != nil {
+			return []byte(BodyLoggerRedacted)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return []byte(BodyLoggerRedacted)
+	}
+	return output.Bytes()
+}
+
+// isRedactField returns if a form field name is one of the redacted fields.
+func (blo BodyLoggerOptions) isRedactField(name string) bool {
+	for _, field := range blo.RedactFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+func redactPath(value interface{}, path []string) {
+	if len(path) == 0 {
+		return
+	}
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		child, ok := typed[path[0]]
+		if !ok {
+			return
+		}
+		if len(path) == 1 {
+			typed[path[0]] = BodyLoggerRedacted
+			return
+		}
+		redactPath(child, path[1:])
+	case []interface{}:
+		for _, element := range typed {
+			redactPath(element, path)
+		}
+	}
+}
+
+// bodyLoggerResponseWriter captures up to a max size of the response body, handing it and the
+// full body size to a callback when the response is closed after the result is rendered.
+type bodyLoggerResponseWriter struct {
+	ResponseWriter
+	body     bytes.Buffer
+	total    int
+	maxBytes int
+	onClose  func([]byte, int)
+}
+
+// Write writes to the inner response, capturing the written bytes up to the max size.
+func (blrw *bodyLoggerResponseWriter) Write(contents []byte) (int, error) {
+	written, err := blrw.ResponseWriter.Write(contents)
+	if remaining := blrw.maxBytes - blrw.body.Len(); remaining > 0 {
+		if remaining > written {
+			remaining = written
+		}
+		blrw.body.Write(contents[:remaining])
+	}
+	blrw.total += written
+	return written, err
+}
+
+// Flush flushes the inner response, e.g. for streamed responses.
+func (blrw *bodyLoggerResponseWriter) Flush() {
+	blrw.ResponseWriter.Flush()
+}
+
+// Close calls the close callback with the captured body and closes the inner response.
+func (blrw *bodyLoggerResponseWriter) Close() error {
+	if blrw.onClose != nil {
+		blrw.onClose(blrw.body.Bytes(), blrw.total)
+		blrw.onClose = nil
+	}
+	return blrw.ResponseWriter.Close()
+}
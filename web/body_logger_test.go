@@ -0,0 +1,243 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/logger"
+	"github.com/blend/go-sdk/r2"
+	"github.com/blend/go-sdk/webutil"
+)
+
+func TestBodyLoggerRedacts(t *testing.T) {
+	assert := assert.New(t)
+
+	output := new(bytes.Buffer)
+	log := logger.MustNew(logger.OptEnabled(FlagHTTPBody), logger.OptOutput(output), logger.OptText(logger.OptTextNoColor(), logger.OptTextHideTimestamp()))
+	app, err := New(OptLog(log))
+	assert.Nil(err)
+
+	var received map[string]interface{}
+	app.POST("/login", func(r *Ctx) Result {
+		if err := r.PostBodyAsJSON(&received); err != nil {
+			return JSON.BadRequest(err)
+		}
+		return JSON.Result(map[string]interface{}{
+			"token": "secret-token",
+			"users": []interface{}{
+				map[string]interface{}{"name": "bailey", "token": "secret-user-token"},
+			},
+		})
+	}, BodyLogger(OptBodyLoggerRedactFields("password", "user.apiKey", "token", "users.token")))
+
+	body := `{"username":"bailey","password":"hunter2","user":{"apiKey":"secret-key","id":1}}`
+	res, _, err := MockPost(app, "/login", nil, r2.OptBodyBytes([]byte(body))).Bytes()
+	assert.Nil(err)
+
+	// the action should see the original, unredacted body.
+	assert.Equal("hunter2", received["password"])
+	var response map[string]interface{}
+	assert.Nil(json.Unmarshal(res, &response))
+	assert.Equal("secret-token", response["token"])
+
+	logged := output.String()
+	assert.Contains(logged, "POST /login request body:")
+	assert.Contains(logged, "POST /login response body:")
+	assert.Contains(logged, `"username":"bailey"`)
+	assert.Contains(logged, `"id":1`)
+	assert.Contains(logged, `"password":"[REDACTED]"`)
+	assert.Contains(logged, `"apiKey":"[REDACTED]"`)
+	assert.Contains(logged, `"token":"[REDACTED]"`)
+	assert.NotContains(logged, "hunter2")
+	assert.NotContains(logged, "secret-key")
+	assert.NotContains(logged, "secret-token")
+	assert.NotContains(logged, "secret-user-token")
+}
+
+func TestBodyLoggerTruncates(t *testing.T) {
+	assert := assert.New(t)
+
+	output := new(bytes.Buffer)
+	log := logger.MustNew(logger.OptEnabled(FlagHTTPBody), logger.OptOutput(output), logger.OptText(logger.OptTextNoColor(), logger.OptTextHideTimestamp()))
+	app, err := New(OptLog(log))
+	assert.Nil(err)
+
+	large := strings.Repeat("a", 64)
+	app.POST("/", func(r *Ctx) Result {
+		body, err := r.PostBodyAsString()
+		if err != nil {
+			return Text.InternalError(err)
+		}
+		return Text.Result(body)
+	}, BodyLogger(OptBodyLoggerMaxBytes(8)))
+
+	res, _, err := MockPost(app, "/", nil, r2.OptBodyBytes([]byte(large))).Bytes()
+	assert.Nil(err)
+	assert.Equal(large, string(res), "the action should receive the full body")
+
+	logged := output.String()
+	assert.Contains(logged, "request body: aaaaaaaa... (truncated, 64 bytes total)")
+	assert.Contains(logged, "response body: aaaaaaaa... (truncated, 64 bytes total)")
+	assert.NotContains(logged, strings.Repeat("a", 9))
+}
+
+func TestBodyLoggerNoLogger(t *testing.T) {
+	assert := assert.New(t)
+
+	app, err := New()
+	assert.Nil(err)
+	app.POST("/", func(r *Ctx) Result {
+		body, _ := r.PostBodyAsString()
+		return Text.Result(body)
+	}, BodyLogger())
+
+	res, _, err := MockPost(app, "/", nil, r2.OptBodyBytes([]byte("ok"))).Bytes()
+	assert.Nil(err)
+	assert.Equal("ok", string(res))
+}
+
+func TestBodyLoggerResponseTruncatedRedacted(t *testing.T) {
+	assert := assert.New(t)
+
+	output := new(bytes.Buffer)
+	log := logger.MustNew(logger.OptEnabled(FlagHTTPBody), logger.OptOutput(output), logger.OptText(logger.OptTextNoColor(), logger.OptTextHideTimestamp()))
+	app, err := New(OptLog(log))
+	assert.Nil(err)
+
+	app.GET("/", func(r *Ctx) Result {
+		return JSON.Result(map[string]interface{}{"token": "secret-token", "padding": strings.Repeat("a", 64)})
+	}, BodyLogger(OptBodyLoggerMaxBytes(16), OptBodyLoggerRedactFields("token")))
+
+	contents, _, err := MockGet(app, "/").Bytes()
+	assert.Nil(err)
+	assert.Contains(string(contents), "secret-token")
+
+	logged := output.String()
+	assert.Contains(logged, "response body: "+BodyLoggerRedacted+" (truncated, ")
+	assert.NotContains(logged, "secret-token")
+}
+
+func TestBodyLoggerResponseWriterMaxBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	inner := new(bytes.Buffer)
+	var captured []byte
+	var total int
+	blrw := &bodyLoggerResponseWriter{
+		ResponseWriter: NewRawResponseWriter(webutil.NewMockResponse(inner)),
+		maxBytes:       8,
+		onClose:        func(body []byte, size int) { captured, total = body, size },
+	}
+	for x := 0; x < 4; x++ {
+		_, err := blrw.Write([]byte("0123456789"))
+		assert.Nil(err)
+		assert.True(blrw.body.Len() <= 8)
+	}
+	assert.Nil(blrw.Close())
+	assert.Equal(strings.Repeat("0123456789", 4), inner.String(), "every byte should be passed through")
+	assert.Equal("01234567", string(captured))
+	assert.Equal(40, total)
+}
+
+func TestBodyLoggerFlagDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	output := new(bytes.Buffer)
+	log := logger.MustNew(logger.OptOutput(output), logger.OptText(logger.OptTextNoColor()))
+	app, err := New(OptLog(log))
+	assert.Nil(err)
+
+	var wrapped bool
+	app.POST("/", func(r *Ctx) Result {
+		_, wrapped = r.Response.(*bodyLoggerResponseWriter)
+		body, _ := r.PostBodyAsString()
+		return Text.Result(body)
+	}, BodyLogger())
+
+	res, _, err := MockPost(app, "/", nil, r2.OptBodyBytes([]byte("ok"))).Bytes()
+	assert.Nil(err)
+	assert.Equal("ok", string(res))
+	assert.False(wrapped, "the response should not be wrapped if the flag is disabled")
+	assert.Empty(output.String())
+}
+
+func TestBodyLoggerRedactsForms(t *testing.T) {
+	assert := assert.New(t)
+
+	output := new(bytes.Buffer)
+	log := logger.MustNew(logger.OptEnabled(FlagHTTPBody), logger.OptOutput(output), logger.OptText(logger.OptTextNoColor(), logger.OptTextHideTimestamp()))
+	app, err := New(OptLog(log))
+	assert.Nil(err)
+
+	var received string
+	app.POST("/login", func(r *Ctx) Result {
+		received = r.Request.FormValue("password")
+		return NoContent
+	}, BodyLogger(OptBodyLoggerRedactFields("password")))
+
+	_, err = MockPost(app, "/login", nil,
+		r2.OptHeaderValue(HeaderContentType, webutil.ContentTypeApplicationFormEncoded),
+		r2.OptBodyBytes([]byte("username=bailey&password=hunter2")),
+	).Discard()
+	assert.Nil(err)
+	assert.Equal("hunter2", received, "the action should see the original, unredacted form")
+
+	multipartBody := new(bytes.Buffer)
+	writer := multipart.NewWriter(multipartBody)
+	assert.Nil(writer.WriteField("username", "bailey"))
+	assert.Nil(writer.WriteField("password", "correct-horse"))
+	assert.Nil(writer.Close())
+	_, err = MockPost(app, "/login", nil,
+		r2.OptHeaderValue(HeaderContentType, writer.FormDataContentType()),
+		r2.OptBodyBytes(multipartBody.Bytes()),
+	).Discard()
+	assert.Nil(err)
+	assert.Equal("correct-horse", received)
+
+	logged := output.String()
+	assert.Contains(logged, "request body: username=bailey&password="+BodyLoggerRedacted)
+	assert.Contains(logged, "bailey")
+	assert.NotContains(logged, "hunter2")
+	assert.NotContains(logged, "correct-horse")
+}
+
+func TestBodyLoggerRequestStreamed(t *testing.T) {
+	assert := assert.New(t)
+
+	output := new(bytes.Buffer)
+	log := logger.MustNew(logger.OptEnabled(FlagHTTPBody), logger.OptOutput(output), logger.OptText(logger.OptTextNoColor(), logger.OptTextHideTimestamp()))
+	app, err := New(OptLog(log))
+	assert.Nil(err)
+
+	large := strings.Repeat("a", 64)
+	app.POST("/", func(r *Ctx) Result {
+		body, err := r.PostBodyAsString()
+		if err != nil {
+			return Text.InternalError(err)
+		}
+		return Text.Result(len(body))
+	}, BodyLogger(OptBodyLoggerMaxBytes(8)))
+
+	// the body is sent without a content length, so only the prefix read for the log is known.
+	res, _, err := MockPost(app, "/", nil, r2.OptBody(ioutil.NopCloser(strings.NewReader(large)))).Bytes()
+	assert.Nil(err)
+	assert.Equal("64", string(res), "the action should receive the full body")
+	assert.Contains(output.String(), "request body: aaaaaaaa... (truncated)")
+}
+
+func TestBodyLoggerResponseWriterFlush(t *testing.T) {
+	assert := assert.New(t)
+
+	recorder := httptest.NewRecorder()
+	blrw := &bodyLoggerResponseWriter{ResponseWriter: NewRawResponseWriter(recorder), maxBytes: 8}
+	var flusher http.Flusher = blrw
+	flusher.Flush()
+	assert.True(recorder.Flushed)
+}
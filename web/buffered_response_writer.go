@@ -0,0 +1,97 @@
+package web
+
+import (
+	"bytes"
+	"net/http"
+)
+
+var (
+	_ ResponseWriter = (*BufferedResponseWriter)(nil)
+)
+
+// NewBufferedResponseWriter creates a new buffered response writer.
+func NewBufferedResponseWriter(w http.ResponseWriter) *BufferedResponseWriter {
+	return &BufferedResponseWriter{
+		innerResponse: w,
+	}
+}
+
+// BufferedResponseWriter is a response writer that buffers the body so it can be inspected.
+//
+// Headers are forwarded to the inner response as they are set, but the status code
+// and the body are held until the response is flushed or closed.
+type BufferedResponseWriter struct {
+	innerResponse http.ResponseWriter
+	statusCode    int
+	body          bytes.Buffer
+	flushed       int
+	wroteHeader   bool
+}
+
+// Write writes the data to the body buffer.
+func (rw *BufferedResponseWriter) Write(b []byte) (int, error) {
+	return rw.body.Write(b)
+}
+
+// Header accesses the response header collection.
+func (rw *BufferedResponseWriter) Header() http.Header {
+	return rw.innerResponse.Header()
+}
+
+// WriteHeader sets the status code, it is written to the inner response when the response is flushed.
+func (rw *BufferedResponseWriter) WriteHeader(code int) {
+	rw.statusCode = code
+}
+
+// InnerResponse returns the backing writer.
+func (rw *BufferedResponseWriter) InnerResponse() http.ResponseWriter {
+	return rw.innerResponse
+}
+
+// StatusCode returns the status code.
+func (rw *BufferedResponseWriter) StatusCode() int {
+	return rw.statusCode
+}
+
+// ContentLength returns the content length.
+func (rw *BufferedResponseWriter) ContentLength() int {
+	return rw.body.Len()
+}
+
+// Body returns the body bytes written so far, including any that have been flushed.
+func (rw *BufferedResponseWriter) Body() []byte {
+	return rw.body.Bytes()
+}
+
+// Flush implements http.Flusher.
+// It writes the status code and any unflushed body bytes to the inner response, and flushes the inner response.
+func (rw *BufferedResponseWriter) Flush() {
+	if err := rw.writeBuffered(); err != nil {
+		return
+	}
+	if typed, ok := rw.innerResponse.(http.Flusher); ok {
+		typed.Flush()
+	}
+}
+
+// Close writes the status code and any unflushed body bytes to the inner response.
+func (rw *BufferedResponseWriter) Close() error {
+	return rw.writeBuffered()
+}
+
+func (rw *BufferedResponseWriter) writeBuffered() error {
+	if !rw.wroteHeader {
+		if rw.statusCode == 0 {
+			rw.statusCode = http.StatusOK
+		}
+		rw.innerResponse.WriteHeader(rw.statusCode)
+		rw.wroteHeader = true
+	}
+	pending := rw.body.Bytes()[rw.flushed:]
+	if len(pending) == 0 {
+		return nil
+	}
+	written, err := rw.innerResponse.Write(pending)
+	rw.flushed += written
+	return err
+}
@@ -0,0 +1,51 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestBufferedResponseWriter(t *testing.T) {
+	assert := assert.New(t)
+
+	inner := httptest.NewRecorder()
+	rw := NewBufferedResponseWriter(inner)
+	rw.Header().Set(HeaderContentType, ContentTypeText)
+	rw.WriteHeader(http.StatusCreated)
+	fmt.Fprint(rw, "hello ")
+	fmt.Fprint(rw, "world")
+
+	assert.Equal(http.StatusCreated, rw.StatusCode())
+	assert.Equal(11, rw.ContentLength())
+	assert.Equal("hello world", string(rw.Body()))
+	assert.Equal(ContentTypeText, inner.Header().Get(HeaderContentType))
+	assert.Zero(inner.Body.Len(), "the body should be held until close")
+	assert.Equal(inner, rw.InnerResponse())
+
+	assert.Nil(rw.Close())
+	assert.Equal(http.StatusCreated, inner.Code)
+	assert.Equal("hello world", inner.Body.String())
+	assert.Equal("hello world", string(rw.Body()))
+}
+
+func TestBufferedResponseWriterFlush(t *testing.T) {
+	assert := assert.New(t)
+
+	inner := httptest.NewRecorder()
+	rw := NewBufferedResponseWriter(inner)
+	fmt.Fprint(rw, "hello ")
+	rw.Flush()
+	assert.True(inner.Flushed)
+	assert.Equal(http.StatusOK, inner.Code)
+	assert.Equal("hello ", inner.Body.String())
+
+	fmt.Fprint(rw, "world")
+	assert.Equal("hello ", inner.Body.String())
+	assert.Nil(rw.Close())
+	assert.Equal("hello world", inner.Body.String())
+	assert.Equal("hello world", string(rw.Body()))
+}
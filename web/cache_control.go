@@ -0,0 +1,62 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Cache control directives.
+const (
+	CacheControlPublic         = "public"
+	CacheControlPrivate        = "private"
+	CacheControlNoCache        = "no-cache"
+	CacheControlNoStore        = "no-store"
+	CacheControlMustRevalidate = "must-revalidate"
+	CacheControlMaxAge         = "max-age"
+)
+
+// CacheFor sets the response cache headers so clients and shared caches may cache the response for a given duration.
+//
+// It sets `Cache-Control` to `public, max-age=<seconds>`, with the duration truncated to whole seconds,
+// sets `Expires` to the time the response expires, and removes any `Pragma` header.
+func (rc *Ctx) CacheFor(d time.Duration) *Ctx {
+	if d < 0 {
+		d = 0
+	}
+	header := rc.Response.Header()
+	header.Set(HeaderCacheControl, fmt.Sprintf("%s, %s=%d", CacheControlPublic, CacheControlMaxAge, int64(d/time.Second)))
+	header.Set(HeaderExpires, time.Now().UTC().Add(d).Format(http.TimeFormat))
+	header.Del(HeaderPragma)
+	return rc
+}
+
+// NoCache sets the response cache headers so the response is not cached by clients or shared caches.
+//
+// It sets `Cache-Control` to `no-cache, no-store, must-revalidate`, `Pragma` to `no-cache`
+// for http/1.0 caches, and `Expires` to `0`.
+func (rc *Ctx) NoCache() *Ctx {
+	header := rc.Response.Header()
+	header.Set(HeaderCacheControl, strings.Join([]string{CacheControlNoCache, CacheControlNoStore, CacheControlMustRevalidate}, ", "))
+	header.Set(HeaderPragma, CacheControlNoCache)
+	header.Set(HeaderExpires, "0")
+	return rc
+}
+
+// Private marks the response as cacheable only by the client and not by shared caches.
+//
+// It replaces a `public` directive in the `Cache-Control` header with `private`, keeping the other directives,
+// e.g. `r.CacheFor(time.Hour).Private()` sets `private, max-age=3600`.
+func (rc *Ctx) Private() *Ctx {
+	directives := []string{CacheControlPrivate}
+	for _, directive := range strings.Split(rc.Response.Header().Get(HeaderCacheControl), ",") {
+		directive = strings.TrimSpace(directive)
+		if directive == "" || directive == CacheControlPublic || directive == CacheControlPrivate {
+			continue
+		}
+		directives = append(directives, directive)
+	}
+	rc.Response.Header().Set(HeaderCacheControl, strings.Join(directives, ", "))
+	return rc
+}
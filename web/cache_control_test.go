@@ -0,0 +1,53 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestCtxCacheFor(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewCtx(NewRawResponseWriter(httptest.NewRecorder()), httptest.NewRequest(http.MethodGet, "/", nil))
+	r.Response.Header().Set(HeaderPragma, CacheControlNoCache)
+
+	before := time.Now().UTC().Truncate(time.Second)
+	assert.Equal(r, r.CacheFor(90*time.Minute+500*time.Millisecond))
+	header := r.Response.Header()
+	assert.Equal("public, max-age=5400", header.Get(HeaderCacheControl))
+	assert.Empty(header.Get(HeaderPragma))
+
+	expires, err := http.ParseTime(header.Get(HeaderExpires))
+	assert.Nil(err)
+	assert.False(expires.Before(before.Add(90 * time.Minute)))
+	assert.False(expires.After(time.Now().UTC().Add(91 * time.Minute)))
+
+	r.CacheFor(-time.Second)
+	assert.Equal("public, max-age=0", header.Get(HeaderCacheControl))
+}
+
+func TestCtxNoCache(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewCtx(NewRawResponseWriter(httptest.NewRecorder()), httptest.NewRequest(http.MethodGet, "/", nil))
+	r.CacheFor(time.Hour).NoCache()
+	header := r.Response.Header()
+	assert.Equal("no-cache, no-store, must-revalidate", header.Get(HeaderCacheControl))
+	assert.Equal("no-cache", header.Get(HeaderPragma))
+	assert.Equal("0", header.Get(HeaderExpires))
+}
+
+func TestCtxPrivate(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewCtx(NewRawResponseWriter(httptest.NewRecorder()), httptest.NewRequest(http.MethodGet, "/", nil))
+	r.Private()
+	assert.Equal("private", r.Response.Header().Get(HeaderCacheControl))
+
+	r.CacheFor(time.Hour).Private().Private()
+	assert.Equal("private, max-age=3600", r.Response.Header().Get(HeaderCacheControl))
+}
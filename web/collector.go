@@ -0,0 +1,95 @@
+package web
+
+import (
+	"strconv"
+
+	"github.com/blend/go-sdk/stats"
+	"github.com/blend/go-sdk/timeutil"
+	"github.com/blend/go-sdk/webutil"
+)
+
+// Collector metric names and tags.
+const (
+	MetricNameHTTPRequest         = string(webutil.HTTPRequest)
+	MetricNameHTTPResponse        = string(webutil.HTTPResponse)
+	MetricNameHTTPResponseSize    = MetricNameHTTPResponse + ".size"
+	MetricNameHTTPResponseElapsed = MetricNameHTTPResponse + ".elapsed"
+
+	MetricTagRoute  = "route"
+	MetricTagMethod = "method"
+	MetricTagStatus = "status"
+
+	// MetricRouteNotFound is the route tag value for requests that did not match a route.
+	MetricRouteNotFound = "not_found"
+)
+
+var (
+	_ Collector = (*NopCollector)(nil)
+	_ Collector = (*statsCollector)(nil)
+)
+
+// Collector is a type that receives metrics for each request the app handles.
+//
+// For each request the app counts `http.request`, and once the response is
+// written it counts `http.response`, gauges `http.response.size` in bytes, and observes
+// `http.response.elapsed` in milliseconds; metrics are tagged with the route, method and status.
+type Collector interface {
+	Count(name string, value int64, tags ...string) error
+	Gauge(name string, value float64, tags ...string) error
+	Observe(name string, value float64, tags ...string) error
+}
+
+// NopCollector is a collector that discards metrics; it is the app default.
+type NopCollector struct{}
+
+// Count implements Collector.
+func (NopCollector) Count(_ string, _ int64, _ ...string) error { return nil }
+
+// Gauge implements Collector.
+func (NopCollector) Gauge(_ string, _ float64, _ ...string) error { return nil }
+
+// Observe implements Collector.
+func (NopCollector) Observe(_ string, _ float64, _ ...string) error { return nil }
+
+// NewStatsCollector returns a collector that sends metrics to a stats collector, e.g. statsd or datadog.
+// Observations are sent as histograms.
+func NewStatsCollector(collector stats.Collector) Collector {
+	return statsCollector{collector}
+}
+
+type statsCollector struct {
+	stats.Collector
+}
+
+// Observe implements Collector.
+func (sc statsCollector) Observe(name string, value float64, tags ...string) error {
+	return sc.Collector.Histogram(name, value, tags...)
+}
+
+func (a *App) collectRequest(ctx *Ctx) {
+	if a.Collector == nil {
+		return
+	}
+	_ = a.Collector.Count(MetricNameHTTPRequest, 1, metricRouteTag(ctx), stats.Tag(MetricTagMethod, ctx.Request.Method))
+}
+
+func (a *App) collectResponse(ctx *Ctx) {
+	if a.Collector == nil {
+		return
+	}
+	tags := []string{
+		metricRouteTag(ctx),
+		stats.Tag(MetricTagMethod, ctx.Request.Method),
+		stats.Tag(MetricTagStatus, strconv.Itoa(ctx.Response.StatusCode())),
+	}
+	_ = a.Collector.Count(MetricNameHTTPResponse, 1, tags...)
+	_ = a.Collector.Gauge(MetricNameHTTPResponseSize, float64(ctx.Response.ContentLength()), tags...)
+	_ = a.Collector.Observe(MetricNameHTTPResponseElapsed, timeutil.Milliseconds(ctx.Elapsed()), tags...)
+}
+
+func metricRouteTag(ctx *Ctx) string {
+	if ctx.Route != nil {
+		return stats.Tag(MetricTagRoute, ctx.Route.String())
+	}
+	return stats.Tag(MetricTagRoute, MetricRouteNotFound)
+}
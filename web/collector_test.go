@@ -0,0 +1,99 @@
+package web
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/stats"
+)
+
+type collectedMetric struct {
+	Kind  string
+	Name  string
+	Value float64
+	Tags  []string
+}
+
+type fakeCollector struct {
+	sync.Mutex
+	Metrics []collectedMetric
+}
+
+func (fc *fakeCollector) collect(kind, name string, value float64, tags []string) error {
+	fc.Lock()
+	defer fc.Unlock()
+	fc.Metrics = append(fc.Metrics, collectedMetric{Kind: kind, Name: name, Value: value, Tags: tags})
+	return nil
+}
+
+func (fc *fakeCollector) Count(name string, value int64, tags ...string) error {
+	return fc.collect("count", name, float64(value), tags)
+}
+
+func (fc *fakeCollector) Gauge(name string, value float64, tags ...string) error {
+	return fc.collect("gauge", name, value, tags)
+}
+
+func (fc *fakeCollector) Observe(name string, value float64, tags ...string) error {
+	return fc.collect("observe", name, value, tags)
+}
+
+func TestAppCollector(t *testing.T) {
+	assert := assert.New(t)
+
+	collector := new(fakeCollector)
+	app := MustNew(OptCollector(collector))
+	app.GET("/users/:id", func(r *Ctx) Result { return Text.Result("ok") })
+	app.WithNotFoundHandler(app.RenderAction(func(r *Ctx) Result { return Text.NotFound() }))
+
+	_, err := MockGet(app, "/users/1").Discard()
+	assert.Nil(err)
+
+	assert.Len(collector.Metrics, 4)
+	assert.Equal(collectedMetric{Kind: "count", Name: MetricNameHTTPRequest, Value: 1, Tags: []string{"route:/users/:id", "method:GET"}}, collector.Metrics[0])
+	responseTags := []string{"route:/users/:id", "method:GET", "status:200"}
+	assert.Equal(collectedMetric{Kind: "count", Name: MetricNameHTTPResponse, Value: 1, Tags: responseTags}, collector.Metrics[1])
+	assert.Equal(collectedMetric{Kind: "gauge", Name: MetricNameHTTPResponseSize, Value: 2, Tags: responseTags}, collector.Metrics[2])
+	assert.Equal("observe", collector.Metrics[3].Kind)
+	assert.Equal(MetricNameHTTPResponseElapsed, collector.Metrics[3].Name)
+	assert.Equal(responseTags, collector.Metrics[3].Tags)
+
+	collector.Metrics = nil
+	_, err = MockGet(app, "/missing").Discard()
+	assert.Nil(err)
+	assert.Len(collector.Metrics, 4)
+	assert.Equal([]string{"route:" + MetricRouteNotFound, "method:GET", "status:404"}, collector.Metrics[1].Tags)
+}
+
+func TestAppCollectorDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	assert.Equal(NopCollector{}, app.Collector)
+	app.GET("/", func(r *Ctx) Result { return Text.Result("ok") })
+	meta, err := MockGet(app, "/").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, meta.StatusCode)
+}
+
+func TestNewStatsCollector(t *testing.T) {
+	assert := assert.New(t)
+
+	mock := stats.NewMockCollector()
+	collector := NewStatsCollector(mock)
+	assert.Nil(collector.Count("count", 2, "a:b"))
+	assert.Nil(collector.Gauge("gauge", 3.5))
+	assert.Nil(collector.Observe("observe", 4.5))
+
+	metric := <-mock.Events
+	assert.Equal("count", metric.Name)
+	assert.Equal(int64(2), metric.Count)
+	assert.Equal([]string{"a:b"}, metric.Tags)
+	metric = <-mock.Events
+	assert.Equal(3.5, metric.Gauge)
+	metric = <-mock.Events
+	assert.Equal("observe", metric.Name)
+	assert.Equal(4.5, metric.Histogram)
+}
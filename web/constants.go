@@ -39,6 +39,14 @@ const (
 	// Typical values for this include "no-cache", "max-age", "min-fresh", and "max-stale" variants.
 	HeaderCacheControl = "Cache-Control"
 
+	// HeaderExpires is the "Expires" header.
+	// It indicates the time after which a response is considered stale.
+	HeaderExpires = "Expires"
+
+	// HeaderPragma is the "Pragma" header.
+	// It is the http/1.0 equivalent of "Cache-Control", and is typically only set to "no-cache".
+	HeaderPragma = "Pragma"
+
 	// HeaderConnection is the "Connection" header.
 	// It is used to indicate if the connection should remain open by the server
 	// after the final response bytes are sent.
@@ -46,6 +54,10 @@ const (
 	// penalites in making requests.
 	HeaderConnection = "Connection"
 
+	// HeaderContentDisposition is the "Content-Disposition" header.
+	// It indicates if the response should be displayed inline or downloaded as an attachment, and with what filename.
+	HeaderContentDisposition = "Content-Disposition"
+
 	// HeaderContentEncoding is the "Content-Encoding" header.
 	// It is used to indicate what the response encoding is.
 	// Typical values are "gzip", "deflate", "compress", "br", and "identity" indicating no compression.
@@ -93,6 +105,12 @@ const (
 	// We specify chartset=utf-8 so that clients know to use the UTF-8 string encoding.
 	ContentTypeApplicationJSON = "application/json; charset=UTF-8"
 
+	// ContentTypeApplicationProblemJSON is a content type for problem details (RFC 7807) responses.
+	ContentTypeApplicationProblemJSON = "application/problem+json; charset=UTF-8"
+
+	// ContentTypeApplicationOctetStream is a content type for binary responses, e.g. downloads.
+	ContentTypeApplicationOctetStream = "application/octet-stream"
+
 	// ContentTypeHTML is a content type for html responses.
 	// We specify chartset=utf-8 so that clients know to use the UTF-8 string encoding.
 	ContentTypeHTML = "text/html; charset=utf-8"
@@ -184,6 +202,12 @@ const (
 	// EnvironmentVariableTLSKey is an env var that contains the TLS key.
 	EnvironmentVariableTLSKey = "TLS_KEY"
 
+	// EnvironmentVariableListenFDs is an env var that contains the number of listener file descriptors passed to the process.
+	EnvironmentVariableListenFDs = "LISTEN_FDS"
+
+	// EnvironmentVariableListenPID is an env var that contains the pid of the process the listener file descriptors were passed to.
+	EnvironmentVariableListenPID = "LISTEN_PID"
+
 	// EnvironmentVariableTLSCertFile is an env var that contains the file path to the TLS cert.
 	EnvironmentVariableTLSCertFile = "TLS_CERT_FILE"
 
@@ -222,6 +246,8 @@ const (
 	DefaultCookieName = "SID"
 	// DefaultSecureCookieName is the default name of the field that contains the secure session id.
 	DefaultSecureCookieName = "SSID"
+	// DefaultFlashCookieName is the name of the cookie that holds flash messages.
+	DefaultFlashCookieName = "flash"
 	// DefaultCookiePath is the default cookie path.
 	DefaultCookiePath = "/"
 	// DefaultCookieSecure returns what the default value for the `Secure` bit of issued cookies will be.
@@ -244,6 +270,11 @@ const (
 	// DefaultHealthzFailureThreshold is the default healthz failure threshold.
 	DefaultHealthzFailureThreshold = 3
 
+	// DefaultStatuszInterval is the default interval statusz checks are run on.
+	DefaultStatuszInterval = 10 * time.Second
+	// DefaultStatuszTimeout is the default timeout for each statusz check.
+	DefaultStatuszTimeout = 5 * time.Second
+
 	// DefaultBufferPoolSize is the default buffer pool size.
 	DefaultViewBufferPoolSize = 256
 )
@@ -3,8 +3,11 @@ package web
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
+	"hash"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -75,12 +78,16 @@ type Ctx struct {
 }
 
 // WithContext sets the background context for the request.
+//
+// The request is updated in place, so middleware can thread values (e.g. request ids, deadlines or
+// trace spans) through to the actions it wraps, which read them with `ctx.Context()`.
 func (rc *Ctx) WithContext(context context.Context) *Ctx {
 	*rc.Request = *rc.Request.WithContext(context)
 	return rc
 }
 
 // Context returns the context.
+// It is the request context with the logger labels and annotations for the request added.
 func (rc *Ctx) Context() context.Context {
 	ctx := rc.Request.Context()
 	ctx = logger.WithLabels(ctx, rc.loggerLabels())
@@ -168,6 +175,16 @@ func (rc *Ctx) RouteParam(key string) (output string, err error) {
 	return
 }
 
+// ParamInt returns a parameter from any location (see `Param`) parsed as an int.
+func (rc *Ctx) ParamInt(name string) (int, error) {
+	return IntValue(rc.Param(name))
+}
+
+// RouteParamInt returns a route parameter parsed as an int.
+func (rc *Ctx) RouteParamInt(key string) (int, error) {
+	return IntValue(rc.RouteParam(key))
+}
+
 // QueryValue returns a query value.
 func (rc *Ctx) QueryValue(key string) (value string, err error) {
 	if value = rc.Request.URL.Query().Get(key); len(value) > 0 {
@@ -199,6 +216,30 @@ func (rc *Ctx) HeaderValue(key string) (value string, err error) {
 	return
 }
 
+// Header returns a request header value, or an empty string if the header is unset.
+// Header names are case insensitive.
+func (rc *Ctx) Header(name string) string {
+	if rc.Request == nil || rc.Request.Header == nil {
+		return ""
+	}
+	return rc.Request.Header.Get(name)
+}
+
+// RequireHeader returns a request header value, or a parameter missing error if the header is unset,
+// which can be rendered as a bad request.
+func (rc *Ctx) RequireHeader(name string) (string, error) {
+	if value := rc.Header(name); value != "" {
+		return value, nil
+	}
+	return "", NewParameterMissingError(name)
+}
+
+// HeaderInt returns a request header value parsed as an int.
+// It returns a parameter missing error if the header is unset.
+func (rc *Ctx) HeaderInt(name string) (int, error) {
+	return IntValue(rc.RequireHeader(name))
+}
+
 // PostBody reads, caches and returns the bytes on a request post body.
 // It will store those bytes for re-use on this context object.
 // If you're expecting a large post body, or a large post body is even possible
@@ -261,6 +302,54 @@ func (rc *Ctx) PostBodyAsXML(response interface{}) error {
 	return nil
 }
 
+// VerifySignature verifies a request body was signed with a given secret by computing an hmac
+// of the body with a given hash algorithm (e.g. `sha256.New`) and comparing it to the value of a given header,
+// as is used by webhooks from services like GitHub.
+//
+// The header value is expected to be hex encoded, optionally prefixed with the algorithm name, e.g. `sha256=<hex>`.
+// The body is cached on the context and re-supplied on `.Request.Body` so it can still be read by the handler.
+// If the header is missing or the signature does not match, an error is returned
+// for which `IsErrSignatureInvalid` is true, and which should typically result in a 401.
+func (rc *Ctx) VerifySignature(secret []byte, headerName string, algo func() hash.Hash) error {
+	signature := rc.Request.Header.Get(headerName)
+	if signature == "" {
+		return ex.New(ErrSignatureMissing, ex.OptMessagef("header: %s", headerName))
+	}
+	body, err := rc.PostBody()
+	if err != nil {
+		return err
+	}
+	rc.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if index := strings.Index(signature, "="); index >= 0 {
+		signature = signature[index+1:]
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return ex.New(ErrSignatureInvalid, ex.OptMessagef("header: %s; signature is not hex encoded", headerName))
+	}
+	mac := hmac.New(algo, secret)
+	_, _ = mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ex.New(ErrSignatureInvalid, ex.OptMessagef("header: %s", headerName))
+	}
+	return nil
+}
+
+// AllowedMethods returns the methods set on the `Allow` response header,
+// e.g. the methods allowed for the path of a method not allowed request.
+func (rc *Ctx) AllowedMethods() []string {
+	allow := rc.Response.Header().Get(HeaderAllow)
+	if allow == "" {
+		return nil
+	}
+	methods := strings.Split(allow, ",")
+	for index := range methods {
+		methods[index] = strings.TrimSpace(methods[index])
+	}
+	return methods
+}
+
 // CookieDomain returns the cookie domain for a request.
 func (rc *Ctx) CookieDomain() string {
 	if rc.App != nil && rc.App.Config.BaseURL != "" {
@@ -333,6 +422,16 @@ func (rc *Ctx) ExpireCookie(name string, path string) {
 	http.SetCookie(rc.Response, c)
 }
 
+// Redirect returns a redirect result to a given url, with an optional status code that defaults to `302`.
+// Status codes that are not redirect (3xx) status codes also fall back to `302`.
+func (rc *Ctx) Redirect(url string, status ...int) Result {
+	statusCode := http.StatusFound
+	if len(status) > 0 && status[0] >= http.StatusMultipleChoices && status[0] < http.StatusBadRequest {
+		statusCode = status[0]
+	}
+	return &RedirectResult{RedirectURI: url, StatusCode: statusCode}
+}
+
 // Elapsed is the time delta between start and end.
 func (rc *Ctx) Elapsed() time.Duration {
 	if !rc.RequestEnd.IsZero() {
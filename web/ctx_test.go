@@ -1,11 +1,19 @@
 package web
 
 import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/r2"
 	"github.com/blend/go-sdk/webutil"
 )
 
@@ -140,6 +148,69 @@ func TestCtxRouteParam(t *testing.T) {
 	assert.Equal("bar", value)
 }
 
+func TestCtxRouteParamInt(t *testing.T) {
+	assert := assert.New(t)
+
+	var id, paramID int
+	var idErr, paramErr, missingErr error
+	app, err := New()
+	assert.Nil(err)
+	app.GET("/users/:id", func(r *Ctx) Result {
+		id, idErr = r.RouteParamInt("id")
+		paramID, paramErr = r.ParamInt("id")
+		_, missingErr = r.RouteParamInt("name")
+		return NoContent
+	})
+
+	_, err = MockGet(app, "/users/1234").Discard()
+	assert.Nil(err)
+	assert.Nil(idErr)
+	assert.Equal(1234, id)
+	assert.Nil(paramErr)
+	assert.Equal(1234, paramID)
+	assert.True(IsErrParameterMissing(missingErr))
+
+	_, err = MockGet(app, "/users/foo").Discard()
+	assert.Nil(err)
+	assert.NotNil(idErr)
+	assert.False(IsErrParameterMissing(idErr))
+	assert.NotNil(paramErr)
+
+	context := MockCtx("GET", "/")
+	_, err = context.ParamInt("id")
+	assert.True(IsErrParameterMissing(err))
+}
+
+func TestCtxHeader(t *testing.T) {
+	assert := assert.New(t)
+
+	r := webutil.NewMockRequest("GET", "/")
+	r.Header.Set("X-Request-Count", "42")
+	r.Header.Set("X-Request-Name", "bailey")
+	r.Header.Set("X-Request-Bad", "forty-two")
+	ctx := NewCtx(nil, r)
+
+	assert.Equal("bailey", ctx.Header("x-request-name"), "header names should be case insensitive")
+	assert.Empty(ctx.Header("X-Not-Set"))
+
+	value, err := ctx.RequireHeader("X-REQUEST-NAME")
+	assert.Nil(err)
+	assert.Equal("bailey", value)
+	_, err = ctx.RequireHeader("X-Not-Set")
+	assert.True(IsErrParameterMissing(err))
+
+	count, err := ctx.HeaderInt("x-request-count")
+	assert.Nil(err)
+	assert.Equal(42, count)
+	_, err = ctx.HeaderInt("X-Not-Set")
+	assert.True(IsErrParameterMissing(err))
+	_, err = ctx.HeaderInt("X-Request-Bad")
+	assert.NotNil(err)
+	assert.False(IsErrParameterMissing(err))
+
+	assert.Empty(NewCtx(nil, nil).Header("X-Request-Name"))
+}
+
 func TestCtxSession(t *testing.T) {
 	assert := assert.New(t)
 
@@ -202,3 +273,102 @@ func TestCtxCookieDomain(t *testing.T) {
 	domain = ctx.CookieDomain()
 	assert.Equal("localhost", domain)
 }
+
+func TestCtxVerifySignature(t *testing.T) {
+	assert := assert.New(t)
+
+	secret := []byte("a webhook secret")
+	payload := []byte(`{"action":"opened"}`)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	var verifyErr error
+	var handlerBody []byte
+	app, err := New()
+	assert.Nil(err)
+	app.POST("/webhook", func(r *Ctx) Result {
+		if verifyErr = r.VerifySignature(secret, "X-Hub-Signature-256", sha256.New); verifyErr != nil {
+			return Text.NotAuthorized()
+		}
+		handlerBody, _ = ioutil.ReadAll(r.Request.Body)
+		return Text.OK()
+	})
+
+	res, err := MockPost(app, "/webhook", nil, r2.OptBodyBytes(payload), r2.OptHeaderValue("X-Hub-Signature-256", "sha256="+signature)).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Nil(verifyErr)
+	assert.Equal(payload, handlerBody, "the body should be preserved for the handler")
+
+	res, err = MockPost(app, "/webhook", nil, r2.OptBodyBytes(payload), r2.OptHeaderValue("X-Hub-Signature-256", signature)).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode, "the algorithm prefix should be optional")
+
+	res, err = MockPost(app, "/webhook", nil, r2.OptBodyBytes([]byte(`{"action":"closed"}`)), r2.OptHeaderValue("X-Hub-Signature-256", "sha256="+signature)).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusUnauthorized, res.StatusCode)
+	assert.True(IsErrSignatureInvalid(verifyErr))
+	assert.True(ex.Is(verifyErr, ErrSignatureInvalid))
+
+	res, err = MockPost(app, "/webhook", nil, r2.OptBodyBytes(payload), r2.OptHeaderValue("X-Hub-Signature-256", "sha256=not-hex")).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusUnauthorized, res.StatusCode)
+	assert.True(ex.Is(verifyErr, ErrSignatureInvalid))
+
+	res, err = MockPost(app, "/webhook", nil, r2.OptBodyBytes(payload)).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusUnauthorized, res.StatusCode)
+	assert.True(ex.Is(verifyErr, ErrSignatureMissing))
+	assert.True(IsErrSignatureInvalid(verifyErr))
+	assert.False(IsErrSignatureInvalid(nil))
+}
+
+type ctxTestContextKey struct{}
+
+func TestCtxContextFromMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	withValue := func(action Action) Action {
+		return func(r *Ctx) Result {
+			r.WithContext(context.WithValue(r.Context(), ctxTestContextKey{}, "from middleware"))
+			return action(r)
+		}
+	}
+
+	var value, requestValue interface{}
+	app := MustNew()
+	app.GET("/", func(r *Ctx) Result {
+		value = r.Context().Value(ctxTestContextKey{})
+		requestValue = r.Request.Context().Value(ctxTestContextKey{})
+		return NoContent
+	}, withValue)
+
+	res, err := MockGet(app, "/").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusNoContent, res.StatusCode)
+	assert.Equal("from middleware", value)
+	assert.Equal("from middleware", requestValue)
+}
+
+func TestCtxRedirect(t *testing.T) {
+	assert := assert.New(t)
+
+	res := webutil.NewMockResponse(new(bytes.Buffer))
+	ctx := NewCtx(res, webutil.NewMockRequest("POST", "/form"))
+	assert.Nil(ctx.Redirect("/done").Render(ctx))
+	assert.Equal(http.StatusFound, res.StatusCode())
+	assert.Equal("/done", res.Header().Get("Location"))
+
+	res = webutil.NewMockResponse(new(bytes.Buffer))
+	ctx = NewCtx(res, webutil.NewMockRequest("POST", "/form"))
+	assert.Nil(ctx.Redirect("/moved", http.StatusSeeOther).Render(ctx))
+	assert.Equal(http.StatusSeeOther, res.StatusCode())
+	assert.Equal("/moved", res.Header().Get("Location"))
+
+	res = webutil.NewMockResponse(new(bytes.Buffer))
+	ctx = NewCtx(res, webutil.NewMockRequest("POST", "/form"))
+	assert.Nil(ctx.Redirect("/invalid", http.StatusOK).Render(ctx))
+	assert.Equal(http.StatusFound, res.StatusCode(), "non redirect status codes should fall back to a 302")
+	assert.Equal("/invalid", res.Header().Get("Location"))
+}
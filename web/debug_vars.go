@@ -0,0 +1,38 @@
+package web
+
+import (
+	"bytes"
+	"expvar"
+	"fmt"
+	"runtime"
+	"strconv"
+)
+
+// DebugVarsGoroutines is the debug vars key for the current number of goroutines.
+const DebugVarsGoroutines = "goroutines"
+
+// DebugVars is an action that renders the `expvar` published variables in the
+// `/debug/vars` json format, e.g. `app.GET("/debug/vars", web.DebugVars)`.
+//
+// Along with any variables published with `expvar.Publish` it includes the runtime
+// `memstats` and `cmdline` variables and the current number of goroutines.
+func DebugVars(_ *Ctx) Result {
+	buffer := new(bytes.Buffer)
+	buffer.WriteString("{\n")
+	first := true
+	write := func(key, value string) {
+		if !first {
+			buffer.WriteString(",\n")
+		}
+		first = false
+		fmt.Fprintf(buffer, "%q: %s", key, value)
+	}
+	expvar.Do(func(kv expvar.KeyValue) {
+		write(kv.Key, kv.Value.String())
+	})
+	if expvar.Get(DebugVarsGoroutines) == nil {
+		write(DebugVarsGoroutines, strconv.Itoa(runtime.NumGoroutine()))
+	}
+	buffer.WriteString("\n}\n")
+	return RawWithContentType(ContentTypeApplicationJSON, buffer.Bytes())
+}
@@ -0,0 +1,34 @@
+package web
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestDebugVars(t *testing.T) {
+	assert := assert.New(t)
+
+	expvar.NewString("web.test.debugVars").Set("ok")
+
+	app := MustNew()
+	app.GET("/debug/vars", DebugVars)
+
+	contents, res, err := MockGet(app, "/debug/vars").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal(ContentTypeApplicationJSON, res.Header.Get("Content-Type"))
+
+	var vars map[string]json.RawMessage
+	assert.Nil(json.Unmarshal(contents, &vars), string(contents))
+	assert.NotEmpty(vars["memstats"])
+	assert.NotEmpty(vars["cmdline"])
+	assert.Equal(`"ok"`, string(vars["web.test.debugVars"]))
+
+	var goroutines int
+	assert.Nil(json.Unmarshal(vars[DebugVarsGoroutines], &goroutines))
+	assert.True(goroutines > 0)
+}
@@ -0,0 +1,18 @@
+package web
+
+import "net/http"
+
+// WithDefaultHeaders returns a middleware that sets the given headers on the response
+// before the action is called, so headers set by the action (or its result) take precedence.
+//
+// It is useful for setting headers on a subset of routes; use `OptDefaultHeader` to set headers for every route.
+func WithDefaultHeaders(headers http.Header) Middleware {
+	return func(action Action) Action {
+		return func(r *Ctx) Result {
+			for key, values := range headers {
+				r.Response.Header()[key] = append([]string(nil), values...)
+			}
+			return action(r)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package web
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestWithDefaultHeaders(t *testing.T) {
+	assert := assert.New(t)
+
+	app, err := New()
+	assert.Nil(err)
+
+	defaults := http.Header{
+		"X-Foo":         []string{"bar"},
+		"Cache-Control": []string{"no-cache"},
+	}
+	app.GET("/", func(r *Ctx) Result {
+		return Text.Result("ok")
+	}, WithDefaultHeaders(defaults))
+	app.GET("/override", func(r *Ctx) Result {
+		r.Response.Header().Set("Cache-Control", "max-age=60")
+		return Text.Result("ok")
+	}, WithDefaultHeaders(defaults))
+	app.GET("/none", func(r *Ctx) Result {
+		return Text.Result("ok")
+	})
+
+	meta, err := MockGet(app, "/").Discard()
+	assert.Nil(err)
+	assert.Equal("bar", meta.Header.Get("X-Foo"))
+	assert.Equal("no-cache", meta.Header.Get("Cache-Control"))
+
+	meta, err = MockGet(app, "/override").Discard()
+	assert.Nil(err)
+	assert.Equal("bar", meta.Header.Get("X-Foo"))
+	assert.Equal("max-age=60", meta.Header.Get("Cache-Control"))
+	assert.Equal([]string{"no-cache"}, defaults["Cache-Control"], "the defaults should not be mutated")
+
+	meta, err = MockGet(app, "/none").Discard()
+	assert.Nil(err)
+	assert.Empty(meta.Header.Get("X-Foo"))
+}
+
+func TestAppDefaultHeadersOverride(t *testing.T) {
+	assert := assert.New(t)
+
+	app, err := New(OptConfig(Config{
+		DefaultHeaders: map[string]string{
+			"X-Frame-Options": "DENY",
+			"Cache-Control":   "no-cache",
+		},
+	}))
+	assert.Nil(err)
+	assert.Equal(PackageName, app.DefaultHeaders.Get(HeaderServer), "we should keep the package defaults")
+
+	app.GET("/", func(r *Ctx) Result {
+		r.Response.Header().Set("Cache-Control", "max-age=60")
+		return Text.Result("ok")
+	})
+
+	meta, err := MockGet(app, "/").Discard()
+	assert.Nil(err)
+	assert.Equal(PackageName, meta.Header.Get(HeaderServer))
+	assert.Equal("DENY", meta.Header.Get("X-Frame-Options"))
+	assert.Equal("max-age=60", meta.Header.Get("Cache-Control"))
+}
@@ -0,0 +1,133 @@
+package web
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// Download returns a result that streams a body to the client as an attachment with a given filename, e.g.
+//
+//	f, err := os.Open(path)
+//	if err != nil {
+//		return r.DefaultProvider.InternalError(err)
+//	}
+//	return r.Download("report.csv", "text/csv", f)
+//
+// The content length is set if the body is seekable, and the body is closed after it is streamed if it is an `io.Closer`.
+func (rc *Ctx) Download(filename, contentType string, body io.Reader) Result {
+	return &DownloadResult{
+		Filename:    filename,
+		ContentType: contentType,
+		Body:        body,
+	}
+}
+
+// DownloadResult streams a body to the client as an attachment.
+type DownloadResult struct {
+	StatusCode  int
+	Filename    string
+	ContentType string
+	Body        io.Reader
+}
+
+// Render renders the result.
+func (dr *DownloadResult) Render(ctx *Ctx) error {
+	if closer, ok := dr.Body.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	contentType := dr.ContentType
+	if contentType == "" {
+		contentType = ContentTypeApplicationOctetStream
+	}
+	ctx.Response.Header().Set(HeaderContentType, contentType)
+	ctx.Response.Header().Set(HeaderContentDisposition, ContentDispositionAttachment(dr.Filename))
+	if seeker, ok := dr.Body.(io.Seeker); ok {
+		length, err := seekerLength(seeker)
+		if err != nil {
+			return err
+		}
+		ctx.Response.Header().Set(HeaderContentLength, strconv.FormatInt(length, 10))
+	}
+
+	if dr.StatusCode == 0 {
+		ctx.Response.WriteHeader(http.StatusOK)
+	} else {
+		ctx.Response.WriteHeader(dr.StatusCode)
+	}
+	if dr.Body == nil {
+		return nil
+	}
+	if _, err := io.Copy(ctx.Response, dr.Body); err != nil {
+		return ex.New(err)
+	}
+	return nil
+}
+
+// ContentDispositionAttachment returns a `Content-Disposition` header value for an attachment with a given filename.
+//
+// Filenames with characters outside printable ascii get an ascii fallback `filename` parameter
+// and an RFC 5987 encoded `filename*` parameter with the full utf-8 name.
+func ContentDispositionAttachment(filename string) string {
+	var fallback strings.Builder
+	var isASCII = true
+	for _, r := range filename {
+		switch {
+		case r < ' ' || r > '~':
+			isASCII = false
+			fallback.WriteByte('_')
+		case r == '"' || r == '\\':
+			fallback.WriteByte('_')
+		default:
+			fallback.WriteRune(r)
+		}
+	}
+	if isASCII {
+		return fmt.Sprintf("attachment; filename=\"%s\"", fallback.String())
+	}
+	return fmt.Sprintf("attachment; filename=\"%s\"; filename*=UTF-8''%s", fallback.String(), rfc5987Encode(filename))
+}
+
+// rfc5987Encode percent encodes a value for an RFC 5987 extended header parameter.
+func rfc5987Encode(value string) string {
+	var output strings.Builder
+	for _, b := range []byte(value) {
+		if isRFC5987AttrChar(b) {
+			output.WriteByte(b)
+			continue
+		}
+		fmt.Fprintf(&output, "%%%02X", b)
+	}
+	return output.String()
+}
+
+// isRFC5987AttrChar returns if a byte can be used unencoded in an RFC 5987 extended header parameter.
+func isRFC5987AttrChar(b byte) bool {
+	switch {
+	case b >= 'a' && b <= 'z', b >= 'A' && b <= 'Z', b >= '0' && b <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$&+-.^_`|~", b) >= 0
+}
+
+// seekerLength returns the number of bytes from the current offset of a seeker to its end,
+// leaving the offset where it was.
+func seekerLength(seeker io.Seeker) (int64, error) {
+	current, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, ex.New(err)
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, ex.New(err)
+	}
+	if _, err = seeker.Seek(current, io.SeekStart); err != nil {
+		return 0, ex.New(err)
+	}
+	return end - current, nil
+}
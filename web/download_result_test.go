@@ -0,0 +1,63 @@
+package web
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/webutil"
+)
+
+func TestContentDispositionAttachment(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(`attachment; filename="report.csv"`, ContentDispositionAttachment("report.csv"))
+	assert.Equal(`attachment; filename="say _hi_.txt"`, ContentDispositionAttachment(`say "hi".txt`))
+	assert.Equal(`attachment; filename="r_sum_.pdf"; filename*=UTF-8''r%C3%A9sum%C3%A9.pdf`, ContentDispositionAttachment("résumé.pdf"))
+	assert.Equal(`attachment; filename="__ 1.txt"; filename*=UTF-8''%E6%8A%A5%E5%91%8A%201.txt`, ContentDispositionAttachment("报告 1.txt"))
+}
+
+func TestCtxDownload(t *testing.T) {
+	assert := assert.New(t)
+
+	app, err := New()
+	assert.Nil(err)
+	app.GET("/seekable", func(r *Ctx) Result {
+		return r.Download("résumé.pdf", "application/pdf", bytes.NewReader([]byte("seekable contents")))
+	})
+	app.GET("/streamed", func(r *Ctx) Result {
+		return r.Download("report.csv", "", ioutil.NopCloser(strings.NewReader("a,b\n1,2\n")))
+	})
+
+	contents, res, err := MockGet(app, "/seekable").Bytes()
+	assert.Nil(err)
+	assert.Equal(200, res.StatusCode)
+	assert.Equal("seekable contents", string(contents))
+	assert.Equal("application/pdf", res.Header.Get(HeaderContentType))
+	assert.Equal(`attachment; filename="r_sum_.pdf"; filename*=UTF-8''r%C3%A9sum%C3%A9.pdf`, res.Header.Get(HeaderContentDisposition))
+	assert.Equal("17", res.Header.Get(HeaderContentLength))
+
+	contents, res, err = MockGet(app, "/streamed").Bytes()
+	assert.Nil(err)
+	assert.Equal(200, res.StatusCode)
+	assert.Equal("a,b\n1,2\n", string(contents))
+	assert.Equal(ContentTypeApplicationOctetStream, res.Header.Get(HeaderContentType))
+	assert.Equal(`attachment; filename="report.csv"`, res.Header.Get(HeaderContentDisposition))
+}
+
+func TestDownloadResultSeekedBody(t *testing.T) {
+	assert := assert.New(t)
+
+	body := bytes.NewReader([]byte("skipped:contents"))
+	_, err := body.Seek(int64(len("skipped:")), io.SeekStart)
+	assert.Nil(err)
+
+	buffer := new(bytes.Buffer)
+	rc := NewCtx(webutil.NewMockResponse(buffer), webutil.NewMockRequest("GET", "/"))
+	assert.Nil((&DownloadResult{Filename: "file.txt", Body: body}).Render(rc))
+	assert.Equal("8", rc.Response.Header().Get(HeaderContentLength))
+	assert.Equal("contents", buffer.String())
+}
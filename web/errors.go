@@ -14,6 +14,18 @@ const (
 	ErrUnsetViewTemplate ex.Class = "view result template is unset"
 	// ErrParameterMissing is an error on request validation.
 	ErrParameterMissing ex.Class = "parameter is missing"
+	// ErrSignatureMissing is an error returned when a request signature header is missing.
+	ErrSignatureMissing ex.Class = "request signature is missing"
+	// ErrSignatureInvalid is an error returned when a request signature does not match the body.
+	ErrSignatureInvalid ex.Class = "request signature is invalid"
+	// ErrFlashSecretUnset is an error returned when flash messages are used without a flash secret configured.
+	ErrFlashSecretUnset ex.Class = "flash secret is unset"
+	// ErrFlashInvalid is an error returned when a flash message cookie is malformed or its signature does not match.
+	ErrFlashInvalid ex.Class = "flash message is invalid"
+	// ErrJSONArrayStreamClosed is an error returned when an element is pushed to a json array stream that is closed.
+	ErrJSONArrayStreamClosed ex.Class = "json array stream is closed"
+	// ErrRequestTimeout is the cause of a request context cancellation when the request exceeds its timeout.
+	ErrRequestTimeout ex.Class = "request timed out"
 )
 
 // NewParameterMissingError returns a new parameter missing error.
@@ -41,3 +53,11 @@ func IsErrParameterMissing(err error) bool {
 	}
 	return ex.Is(err, ErrParameterMissing)
 }
+
+// IsErrSignatureInvalid returns if an error is a signature missing or invalid error.
+func IsErrSignatureInvalid(err error) bool {
+	if err == nil {
+		return false
+	}
+	return ex.Is(err, ErrSignatureMissing) || ex.Is(err, ErrSignatureInvalid)
+}
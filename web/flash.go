@@ -0,0 +1,83 @@
+package web
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// SetFlash sets a one time message that can be read with `Flash` on the next request, e.g. after a redirect.
+//
+// The message is stored in a cookie signed with the app config `FlashSecret`.
+func (rc *Ctx) SetFlash(message string) error {
+	secret, err := rc.flashSecret()
+	if err != nil {
+		return err
+	}
+	value := base64.RawURLEncoding.EncodeToString([]byte(message))
+	rc.WriteNewCookie(&http.Cookie{
+		Name:     DefaultFlashCookieName,
+		Value:    value + "." + signFlash(secret, value),
+		Path:     DefaultCookiePath,
+		HttpOnly: true,
+		Secure:   rc.App.Config.CookieSecureOrDefault(),
+		SameSite: http.SameSiteLaxMode,
+	})
+	return nil
+}
+
+// Flash returns the flash message set by a previous request, or an empty string if there is none.
+//
+// Reading the message clears it; the cookie is expired and the message will not be returned again.
+// If the cookie is not validly signed it is cleared and an `ErrFlashInvalid` exception is returned.
+func (rc *Ctx) Flash() (string, error) {
+	cookie := rc.Cookie(DefaultFlashCookieName)
+	if cookie == nil {
+		return "", nil
+	}
+	secret, err := rc.flashSecret()
+	if err != nil {
+		return "", err
+	}
+	rc.ExpireCookie(DefaultFlashCookieName, DefaultCookiePath)
+	rc.removeRequestCookie(DefaultFlashCookieName)
+
+	parts := strings.SplitN(cookie.Value, ".", 2)
+	if len(parts) != 2 || !hmac.Equal([]byte(signFlash(secret, parts[0])), []byte(parts[1])) {
+		return "", ex.New(ErrFlashInvalid)
+	}
+	message, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", ex.New(ErrFlashInvalid, ex.OptInner(err))
+	}
+	return string(message), nil
+}
+
+func (rc *Ctx) flashSecret() ([]byte, error) {
+	if rc.App == nil || rc.App.Config.FlashSecret == "" {
+		return nil, ex.New(ErrFlashSecretUnset)
+	}
+	return []byte(rc.App.Config.FlashSecret), nil
+}
+
+// removeRequestCookie removes a cookie from the request so it is not read again.
+func (rc *Ctx) removeRequestCookie(name string) {
+	cookies := rc.Request.Cookies()
+	rc.Request.Header.Del(HeaderCookie)
+	for _, cookie := range cookies {
+		if cookie.Name != name {
+			rc.Request.AddCookie(cookie)
+		}
+	}
+}
+
+func signFlash(secret []byte, value string) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
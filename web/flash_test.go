@@ -0,0 +1,71 @@
+package web
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/webutil"
+)
+
+func TestCtxFlash(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptConfig(Config{FlashSecret: "test-secret"}))
+
+	// the first request sets the flash message and redirects.
+	res := webutil.NewMockResponse(new(bytes.Buffer))
+	ctx := NewCtx(res, webutil.NewMockRequest("POST", "/form"), OptCtxApp(app))
+	assert.Nil(ctx.SetFlash("saved; all good"))
+	assert.Nil(ctx.Redirect("/done").Render(ctx))
+	assert.Equal(http.StatusFound, res.StatusCode())
+
+	cookies := ReadSetCookies(res.Header())
+	assert.Len(cookies, 1)
+	assert.Equal(DefaultFlashCookieName, cookies[0].Name)
+	assert.True(cookies[0].HttpOnly)
+
+	// the next request reads the message once.
+	res = webutil.NewMockResponse(new(bytes.Buffer))
+	req := webutil.NewMockRequest("GET", "/done")
+	req.AddCookie(&http.Cookie{Name: DefaultFlashCookieName, Value: cookies[0].Value})
+	req.AddCookie(&http.Cookie{Name: "other", Value: "value"})
+	ctx = NewCtx(res, req, OptCtxApp(app))
+
+	message, err := ctx.Flash()
+	assert.Nil(err)
+	assert.Equal("saved; all good", message)
+	expired := ReadSetCookies(res.Header())
+	assert.Len(expired, 1)
+	assert.Equal(DefaultFlashCookieName, expired[0].Name)
+	assert.True(expired[0].MaxAge < 0 || !expired[0].Expires.IsZero())
+
+	message, err = ctx.Flash()
+	assert.Nil(err)
+	assert.Empty(message)
+	assert.NotNil(ctx.Cookie("other"))
+}
+
+func TestCtxFlashInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptConfig(Config{FlashSecret: "test-secret"}))
+	res := webutil.NewMockResponse(new(bytes.Buffer))
+	ctx := NewCtx(res, webutil.NewMockRequest("GET", "/"), OptCtxApp(app))
+	assert.Nil(ctx.SetFlash("message"))
+	cookie := ReadSetCookies(res.Header())[0]
+
+	other := MustNew(OptConfig(Config{FlashSecret: "other-secret"}))
+	ctx = MockCtx("GET", "/", OptCtxApp(other), OptCtxCookieValue(DefaultFlashCookieName, cookie.Value))
+	_, err := ctx.Flash()
+	assert.True(ex.Is(err, ErrFlashInvalid))
+
+	ctx = MockCtx("GET", "/", OptCtxApp(app), OptCtxCookieValue(DefaultFlashCookieName, "garbage"))
+	_, err = ctx.Flash()
+	assert.True(ex.Is(err, ErrFlashInvalid))
+
+	ctx = MockCtx("GET", "/")
+	assert.True(ex.Is(ctx.SetFlash("message"), ErrFlashSecretUnset))
+}
@@ -0,0 +1,113 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+const (
+	// DefaultJSONArrayStreamFlushEvery is the default number of elements written between flushes of a json array stream.
+	DefaultJSONArrayStreamFlushEvery = 100
+)
+
+// JSONStream returns a stream that writes a json array to the response one element at a time,
+// which avoids building large result sets in memory, e.g.
+//
+//	stream := r.JSONStream()
+//	defer stream.Close()
+//	for rows.Next() {
+//		...
+//		if err := stream.Push(row); err != nil {
+//			logger.MaybeError(r.App.Log, err)
+//			return nil
+//		}
+//	}
+//	return nil
+//
+// The stream must be closed to write the end of the array, and the action should return a nil result.
+func (r *Ctx) JSONStream() *JSONArrayStream {
+	return &JSONArrayStream{
+		Response:   r.Response,
+		FlushEvery: DefaultJSONArrayStreamFlushEvery,
+	}
+}
+
+// JSONArrayStream writes a json array to a response one element at a time.
+//
+// The response headers and the start of the array are written with the first element (or on close),
+// so an error result can still be returned before anything is pushed.
+type JSONArrayStream struct {
+	// Response is the response the array is written to.
+	Response ResponseWriter
+	// FlushEvery is the number of elements written between flushes of the response.
+	// If it is zero or less, the response is only flushed on close.
+	FlushEvery int
+
+	count   int
+	started bool
+	closed  bool
+}
+
+// Count returns the number of elements written to the stream.
+func (js *JSONArrayStream) Count() int {
+	return js.count
+}
+
+// Push encodes an element and writes it to the stream.
+func (js *JSONArrayStream) Push(element interface{}) error {
+	if js.closed {
+		return ex.New(ErrJSONArrayStreamClosed)
+	}
+	contents, err := json.Marshal(element)
+	if err != nil {
+		return ex.New(err)
+	}
+	if err = js.start(); err != nil {
+		return err
+	}
+	if js.count > 0 {
+		if _, err = js.Response.Write([]byte(",")); err != nil {
+			return ex.New(err)
+		}
+	}
+	if _, err = js.Response.Write(contents); err != nil {
+		return ex.New(err)
+	}
+	js.count++
+	if js.FlushEvery > 0 && js.count%js.FlushEvery == 0 {
+		js.Response.Flush()
+	}
+	return nil
+}
+
+// Close writes the end of the array and flushes the response.
+// It is safe to call more than once.
+func (js *JSONArrayStream) Close() error {
+	if js.closed {
+		return nil
+	}
+	if err := js.start(); err != nil {
+		return err
+	}
+	js.closed = true
+	if _, err := js.Response.Write([]byte("]")); err != nil {
+		return ex.New(err)
+	}
+	js.Response.Flush()
+	return nil
+}
+
+func (js *JSONArrayStream) start() error {
+	if js.started {
+		return nil
+	}
+	js.started = true
+	js.Response.Header().Set(HeaderContentType, ContentTypeApplicationJSON)
+	js.Response.WriteHeader(http.StatusOK)
+	if _, err := js.Response.Write([]byte("[")); err != nil {
+		return ex.New(err)
+	}
+	return nil
+}
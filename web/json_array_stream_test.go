@@ -0,0 +1,83 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+type jsonStreamElement struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestCtxJSONStream(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.GET("/export", func(r *Ctx) Result {
+		stream := r.JSONStream()
+		for id := 0; id < 1000; id++ {
+			if err := stream.Push(jsonStreamElement{ID: id, Name: "element"}); err != nil {
+				return JSON.InternalError(err)
+			}
+		}
+		if err := stream.Close(); err != nil {
+			return JSON.InternalError(err)
+		}
+		return nil
+	})
+
+	contents, res, err := MockGet(app, "/export").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal(ContentTypeApplicationJSON, res.Header.Get(HeaderContentType))
+
+	var elements []jsonStreamElement
+	assert.Nil(json.Unmarshal(contents, &elements), string(contents))
+	assert.Len(elements, 1000)
+	for index, element := range elements {
+		assert.Equal(index, element.ID)
+		assert.Equal("element", element.Name)
+	}
+}
+
+func TestJSONArrayStreamEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	recorder := httptest.NewRecorder()
+	stream := NewCtx(NewRawResponseWriter(recorder), nil).JSONStream()
+	assert.Nil(stream.Close())
+	assert.Nil(stream.Close())
+	assert.Equal("[]", recorder.Body.String())
+	assert.Equal(ContentTypeApplicationJSON, recorder.Header().Get(HeaderContentType))
+	assert.True(recorder.Flushed)
+	assert.Zero(stream.Count())
+
+	assert.True(ex.Is(stream.Push("late"), ErrJSONArrayStreamClosed))
+	assert.Equal("[]", recorder.Body.String())
+}
+
+func TestJSONArrayStreamPush(t *testing.T) {
+	assert := assert.New(t)
+
+	recorder := httptest.NewRecorder()
+	stream := NewCtx(NewRawResponseWriter(recorder), nil).JSONStream()
+	stream.FlushEvery = 2
+
+	assert.NotNil(stream.Push(func() {}))
+	assert.Empty(recorder.Body.String(), "nothing should be written if the first element does not encode")
+
+	assert.Nil(stream.Push("one"))
+	assert.False(recorder.Flushed)
+	assert.Nil(stream.Push(2))
+	assert.True(recorder.Flushed)
+	assert.Nil(stream.Push(map[string]bool{"three": true}))
+	assert.Nil(stream.Close())
+	assert.Equal(3, stream.Count())
+	assert.Equal(`["one",2,{"three":true}]`, recorder.Body.String())
+}
@@ -1,14 +1,40 @@
 package web
 
-import "github.com/blend/go-sdk/webutil"
+import (
+	"net/http"
+
+	"github.com/blend/go-sdk/webutil"
+)
+
+// JSONWith returns a json result for a given status code and response that also sets a given set of response headers.
+//
+// The headers are set before the result is rendered, so the json content type header takes precedence.
+func (rc *Ctx) JSONWith(statusCode int, headers map[string]string, response interface{}) Result {
+	result := &JSONResult{
+		StatusCode: statusCode,
+		Response:   response,
+	}
+	if len(headers) > 0 {
+		result.Headers = make(http.Header, len(headers))
+		for key, value := range headers {
+			result.Headers.Set(key, value)
+		}
+	}
+	return result
+}
 
 // JSONResult is a json result.
 type JSONResult struct {
 	StatusCode int
 	Response   interface{}
+	// Headers are optional response headers set before the response is written.
+	Headers http.Header
 }
 
 // Render renders the result
 func (jr *JSONResult) Render(ctx *Ctx) error {
+	for key, values := range jr.Headers {
+		ctx.Response.Header()[key] = values
+	}
 	return webutil.WriteJSON(ctx.Response, jr.StatusCode, jr.Response)
 }
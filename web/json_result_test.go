@@ -46,3 +46,40 @@ func TestJSONResultRenderStatusCode(t *testing.T) {
 	assert.Equal(http.StatusBadRequest, w.StatusCode())
 	assert.Equal("{\"foo\":\"bar\"}\n", buf.String())
 }
+
+func TestCtxJSONWith(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.GET("/", func(r *Ctx) Result {
+		return r.JSONWith(http.StatusCreated, map[string]string{
+			"x-request-id": "test-request-id",
+			"Location":     "/things/1",
+		}, map[string]interface{}{
+			"id": 1,
+		})
+	})
+
+	contents, res, err := MockGet(app, "/").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusCreated, res.StatusCode)
+	assert.Equal("test-request-id", res.Header.Get("X-Request-Id"))
+	assert.Equal("/things/1", res.Header.Get("Location"))
+	assert.Equal(webutil.ContentTypeApplicationJSON, res.Header.Get(HeaderContentType))
+	assert.Equal("{\"id\":1}\n", string(contents))
+}
+
+func TestCtxJSONWithContentType(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := new(bytes.Buffer)
+	w := webutil.NewMockResponse(buf)
+	r := NewCtx(w, webutil.NewMockRequest("GET", "/"))
+
+	assert.Nil(r.JSONWith(http.StatusOK, map[string]string{HeaderContentType: "text/plain"}, "foo").Render(r))
+	assert.Equal(webutil.ContentTypeApplicationJSON, w.Header().Get(HeaderContentType))
+	assert.Equal("\"foo\"\n", buf.String())
+
+	result := r.JSONWith(http.StatusOK, nil, "foo").(*JSONResult)
+	assert.Nil(result.Headers)
+}
@@ -0,0 +1,58 @@
+package web
+
+import (
+	"context"
+
+	"github.com/blend/go-sdk/logger"
+)
+
+// LifecycleHook is a function that is called at a point in the app lifecycle, e.g. `OnStart`.
+type LifecycleHook func(context.Context) error
+
+// OnStart adds hooks that are called, in order, when the app is started before it listens for requests,
+// e.g. to run migrations; if a hook returns an error, the app is not started and `Start` returns the error.
+func (a *App) OnStart(hooks ...LifecycleHook) {
+	a.StartHooks = append(a.StartHooks, hooks...)
+}
+
+// OnStarted adds hooks that are called, in order, once the app is listening and before it serves requests;
+// they are called before the app signals it has started. Errors returned by the hooks are logged.
+func (a *App) OnStarted(hooks ...LifecycleHook) {
+	a.StartedHooks = append(a.StartedHooks, hooks...)
+}
+
+// OnShutdown adds hooks that are called, in order, when the app is stopped after in flight requests complete,
+// e.g. to flush resources. The hooks are given a context that is cancelled when the shutdown grace period elapses,
+// and each is called even if another returns an error; `Stop` returns the first error.
+func (a *App) OnShutdown(hooks ...LifecycleHook) {
+	a.ShutdownHooks = append(a.ShutdownHooks, hooks...)
+}
+
+// runStartHooks runs the start hooks, returning the first error.
+func (a *App) runStartHooks(ctx context.Context) error {
+	for _, hook := range a.StartHooks {
+		if err := hook(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runStartedHooks runs the started hooks, logging any errors.
+func (a *App) runStartedHooks(ctx context.Context) {
+	for _, hook := range a.StartedHooks {
+		if err := hook(ctx); err != nil {
+			logger.MaybeError(a.Log, err)
+		}
+	}
+}
+
+// runShutdownHooks runs every shutdown hook, returning the first error.
+func (a *App) runShutdownHooks(ctx context.Context) (err error) {
+	for _, hook := range a.ShutdownHooks {
+		if hookErr := hook(ctx); hookErr != nil && err == nil {
+			err = hookErr
+		}
+	}
+	return
+}
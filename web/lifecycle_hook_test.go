@@ -0,0 +1,76 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestAppLifecycleHooks(t *testing.T) {
+	assert := assert.New(t)
+
+	var calledLock sync.Mutex
+	var called []string
+	hook := func(name string, err error) LifecycleHook {
+		return func(_ context.Context) error {
+			calledLock.Lock()
+			defer calledLock.Unlock()
+			called = append(called, name)
+			return err
+		}
+	}
+
+	app := MustNew(
+		OptBindAddr(DefaultMockBindAddr),
+		OptOnStart(hook("start", nil)),
+		OptOnStarted(hook("started", fmt.Errorf("started errors are logged"))),
+		OptOnShutdown(hook("shutdown", fmt.Errorf("shutdown")), hook("shutdown2", nil)),
+	)
+	app.OnStart(hook("start2", nil))
+	app.GET("/", func(_ *Ctx) Result {
+		return NoContent
+	})
+
+	startErrors := make(chan error, 1)
+	go func() { startErrors <- app.Start() }()
+	<-app.NotifyStarted()
+
+	res, err := http.Get("http://" + app.Listener.Addr().String() + "/")
+	assert.Nil(err)
+	assert.Nil(res.Body.Close())
+	assert.Equal(http.StatusNoContent, res.StatusCode)
+
+	err = app.Stop()
+	assert.NotNil(err)
+	assert.Equal("shutdown", err.Error())
+	assert.Nil(<-startErrors)
+
+	calledLock.Lock()
+	defer calledLock.Unlock()
+	assert.Equal([]string{"start", "start2", "started", "shutdown", "shutdown2"}, called)
+}
+
+func TestAppOnStartError(t *testing.T) {
+	assert := assert.New(t)
+
+	var didStart bool
+	app := MustNew(
+		OptBindAddr(DefaultMockBindAddr),
+		OptOnStart(func(_ context.Context) error { return fmt.Errorf("migrations failed") }),
+		OptOnStarted(func(_ context.Context) error {
+			didStart = true
+			return nil
+		}),
+	)
+
+	err := app.Start()
+	assert.NotNil(err)
+	assert.Equal("migrations failed", err.Error())
+	assert.False(didStart)
+	assert.Nil(app.Listener, "the app should not listen for requests")
+	assert.True(app.CanStart())
+}
@@ -0,0 +1,65 @@
+package web
+
+import (
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/blend/go-sdk/env"
+	"github.com/blend/go-sdk/ex"
+)
+
+const (
+	// ListenFDsStart is the first file descriptor listeners are passed on, following stdin, stdout and stderr.
+	ListenFDsStart = 3
+)
+
+// ListenerFromEnv returns a listener inherited from the environment, following the systemd socket activation
+// protocol, i.e. `LISTEN_FDS` is the number of file descriptors passed starting at `ListenFDsStart`, and
+// `LISTEN_PID` (if set) is the pid they were passed to. Parent processes handing off a listener for a
+// zero-downtime restart can pass it the same way.
+//
+// It returns a nil listener if no file descriptors were passed to this process; if more than
+// one were passed, the first is used. Set the listener on an app with `app.WithListener(listener)`.
+func ListenerFromEnv() (net.Listener, error) {
+	vars := env.Env()
+	if !vars.Has(EnvironmentVariableListenFDs) {
+		return nil, nil
+	}
+	if vars.Has(EnvironmentVariableListenPID) {
+		pid, err := vars.Int(EnvironmentVariableListenPID)
+		if err != nil {
+			return nil, ex.New(err)
+		}
+		if pid != os.Getpid() {
+			return nil, nil
+		}
+	}
+	fds, err := vars.Int(EnvironmentVariableListenFDs)
+	if err != nil {
+		return nil, ex.New(err)
+	}
+	if fds < 1 {
+		return nil, nil
+	}
+	return listenerFromFD(ListenFDsStart)
+}
+
+func listenerFromFD(fd uintptr) (net.Listener, error) {
+	file := os.NewFile(fd, "listen_fd_"+strconv.Itoa(int(fd)))
+	if file == nil {
+		return nil, ex.New("invalid listener file descriptor", ex.OptMessagef("fd: %d", fd))
+	}
+	return listenerFromFile(file)
+}
+
+// listenerFromFile returns a listener for a file and closes the file;
+// `net.FileListener` duplicates the underlying file descriptor.
+func listenerFromFile(file *os.File) (net.Listener, error) {
+	defer file.Close()
+	listener, err := net.FileListener(file)
+	if err != nil {
+		return nil, ex.New(err, ex.OptMessagef("file: %s", file.Name()))
+	}
+	return listener, nil
+}
@@ -0,0 +1,78 @@
+package web
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/env"
+)
+
+func TestAppWithListener(t *testing.T) {
+	assert := assert.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(err)
+
+	app, err := New(OptBindAddr("127.0.0.1:1"))
+	assert.Nil(err)
+	app.WithListener(listener)
+	app.GET("/", func(r *Ctx) Result {
+		return Text.Result("OK!")
+	})
+
+	go app.Start()
+	<-app.NotifyStarted()
+	defer app.Stop()
+
+	assert.Equal(listener.Addr().String(), app.Server.Addr)
+	res, err := http.Get("http://" + listener.Addr().String() + "/")
+	assert.Nil(err)
+	defer res.Body.Close()
+	assert.Equal(http.StatusOK, res.StatusCode)
+	contents, err := ioutil.ReadAll(res.Body)
+	assert.Nil(err)
+	assert.Equal("OK!", string(contents))
+}
+
+func TestListenerFromEnv(t *testing.T) {
+	assert := assert.New(t)
+	defer env.Restore()
+
+	env.SetEnv(env.New())
+	listener, err := ListenerFromEnv()
+	assert.Nil(err)
+	assert.Nil(listener)
+
+	env.SetEnv(env.New())
+	env.Env().Set(EnvironmentVariableListenFDs, "1")
+	env.Env().Set(EnvironmentVariableListenPID, strconv.Itoa(os.Getpid()+1))
+	listener, err = ListenerFromEnv()
+	assert.Nil(err)
+	assert.Nil(listener)
+
+	env.SetEnv(env.New())
+	env.Env().Set(EnvironmentVariableListenFDs, "not a number")
+	_, err = ListenerFromEnv()
+	assert.NotNil(err)
+}
+
+func TestListenerFromFile(t *testing.T) {
+	assert := assert.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(err)
+	defer listener.Close()
+
+	file, err := listener.(*net.TCPListener).File()
+	assert.Nil(err)
+
+	inherited, err := listenerFromFile(file)
+	assert.Nil(err)
+	defer inherited.Close()
+	assert.Equal(listener.Addr().String(), inherited.Addr().String())
+}
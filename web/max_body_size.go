@@ -0,0 +1,70 @@
+package web
+
+import (
+	"io"
+	"net/http"
+)
+
+// MaxBodySize returns a middleware that limits request bodies to a given number of bytes with `http.MaxBytesReader`,
+// so actions cannot be made to read unbounded bodies; if an action reads past the limit, the read returns an error
+// and the request is rejected with a 413 (Request Entity Too Large).
+//
+// The limit can be overridden per route, e.g. for uploads, as a route's middleware replaces the app default limit:
+//
+//	app := web.MustNew(web.OptUse(web.MaxBodySize(1<<20)))
+//	app.POST("/upload", upload, web.MaxBodySize(32<<20))
+func MaxBodySize(n int64) Middleware {
+	return func(action Action) Action {
+		return func(r *Ctx) Result {
+			if r.Request == nil || r.Request.Body == nil || r.Request.Body == http.NoBody {
+				return action(r)
+			}
+			body := r.Request.Body
+			if typed, ok := body.(*maxBodySizeReader); ok {
+				body = typed.original
+			}
+			counted := &maxBodySizeCounter{ReadCloser: body}
+			limited := &maxBodySizeReader{
+				ReadCloser: http.MaxBytesReader(r.Response, counted, n),
+				original:   body,
+				counted:    counted,
+				limit:      n,
+			}
+			r.Request.Body = limited
+			// a `GetBody` would let `PostBody` read the body around the limit.
+			r.Request.GetBody = nil
+
+			res := action(r)
+			if limited.Exceeded() {
+				return r.DefaultProvider.Status(http.StatusRequestEntityTooLarge)
+			}
+			return res
+		}
+	}
+}
+
+// maxBodySizeReader is a request body limited by `http.MaxBytesReader` that records if the limit was exceeded.
+type maxBodySizeReader struct {
+	io.ReadCloser
+	original io.ReadCloser
+	counted  *maxBodySizeCounter
+	limit    int64
+}
+
+// Exceeded returns if more than the limit was read from the body.
+func (mbsr *maxBodySizeReader) Exceeded() bool {
+	return mbsr.counted.read > mbsr.limit
+}
+
+// maxBodySizeCounter counts the bytes read from a body.
+type maxBodySizeCounter struct {
+	io.ReadCloser
+	read int64
+}
+
+// Read reads from the body.
+func (mbsc *maxBodySizeCounter) Read(p []byte) (n int, err error) {
+	n, err = mbsc.ReadCloser.Read(p)
+	mbsc.read += int64(n)
+	return
+}
@@ -0,0 +1,66 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/r2"
+)
+
+func maxBodySizeEcho(r *Ctx) Result {
+	body, err := r.PostBodyAsString()
+	if err != nil {
+		return r.DefaultProvider.InternalError(err)
+	}
+	return Text.Result(body)
+}
+
+func TestMaxBodySize(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(TextProviderAsDefault))
+	app.POST("/", maxBodySizeEcho, MaxBodySize(8))
+	app.POST("/ignored", ok, MaxBodySize(8))
+
+	contents, res, err := MockPost(app, "/", nil, r2.OptBodyBytes([]byte("12345678"))).Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal("12345678", string(contents))
+
+	res, err = MockPost(app, "/", nil, r2.OptBodyBytes([]byte("123456789"))).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusRequestEntityTooLarge, res.StatusCode)
+
+	res, err = MockPost(app, "/ignored", nil, r2.OptBodyBytes([]byte(strings.Repeat("a", 64)))).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode, "bodies that are not read should not be rejected")
+
+	res, err = MockPost(app, "/", nil).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+}
+
+func TestMaxBodySizeOverride(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(TextProviderAsDefault), OptUse(MaxBodySize(8)))
+	app.POST("/", maxBodySizeEcho)
+	app.POST("/upload", maxBodySizeEcho, MaxBodySize(64))
+	app.POST("/small", maxBodySizeEcho, MaxBodySize(4))
+
+	body := []byte(strings.Repeat("a", 32))
+	res, err := MockPost(app, "/", nil, r2.OptBodyBytes(body)).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusRequestEntityTooLarge, res.StatusCode)
+
+	contents, res, err := MockPost(app, "/upload", nil, r2.OptBodyBytes(body)).Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode, "a route limit should override the app limit")
+	assert.Equal(string(body), string(contents))
+
+	res, err = MockPost(app, "/small", nil, r2.OptBodyBytes([]byte("123456"))).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusRequestEntityTooLarge, res.StatusCode)
+}
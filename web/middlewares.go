@@ -0,0 +1,32 @@
+package web
+
+// Middlewares is an ordered middleware chain.
+//
+// The first middleware in the chain is the outermost and runs first, that is
+// `Middlewares{a, b}.Then(action)` is the same as `a(b(action))`.
+// Chains are not modified by `Append` or `Prepend`, so they can be shared and extended.
+type Middlewares []Middleware
+
+// Append returns a new chain with the given middleware added after the existing middleware.
+func (m Middlewares) Append(middleware ...Middleware) Middlewares {
+	output := make(Middlewares, 0, len(m)+len(middleware))
+	output = append(output, m...)
+	return append(output, middleware...)
+}
+
+// Prepend returns a new chain with the given middleware added before the existing middleware.
+func (m Middlewares) Prepend(middleware ...Middleware) Middlewares {
+	output := make(Middlewares, 0, len(m)+len(middleware))
+	output = append(output, middleware...)
+	return append(output, m...)
+}
+
+// Then wraps an action with the chain, returning an action that runs the middleware in order.
+func (m Middlewares) Then(action Action) Action {
+	for index := len(m) - 1; index >= 0; index-- {
+		if m[index] != nil {
+			action = m[index](action)
+		}
+	}
+	return action
+}
@@ -0,0 +1,61 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func orderedMiddleware(calls *[]string, name string) Middleware {
+	return func(action Action) Action {
+		return func(ctx *Ctx) Result {
+			*calls = append(*calls, name)
+			return action(ctx)
+		}
+	}
+}
+
+func TestMiddlewaresThen(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls []string
+	auth, logging, timeout := orderedMiddleware(&calls, "auth"), orderedMiddleware(&calls, "logging"), orderedMiddleware(&calls, "timeout")
+	action := func(_ *Ctx) Result {
+		calls = append(calls, "action")
+		return nil
+	}
+
+	Middlewares{auth, logging, timeout}.Then(action)(nil)
+	assert.Equal([]string{"auth", "logging", "timeout", "action"}, calls)
+
+	// the chain matches wrapping manually.
+	calls = nil
+	auth(logging(timeout(action)))(nil)
+	assert.Equal([]string{"auth", "logging", "timeout", "action"}, calls)
+
+	calls = nil
+	Middlewares(nil).Then(action)(nil)
+	assert.Equal([]string{"action"}, calls)
+}
+
+func TestMiddlewaresAppendPrepend(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls []string
+	action := func(_ *Ctx) Result {
+		calls = append(calls, "action")
+		return nil
+	}
+
+	base := Middlewares{orderedMiddleware(&calls, "logging")}
+	chain := base.Append(orderedMiddleware(&calls, "timeout")).Prepend(orderedMiddleware(&calls, "auth"))
+	assert.Len(base, 1)
+	assert.Len(chain, 3)
+
+	chain.Then(action)(nil)
+	assert.Equal([]string{"auth", "logging", "timeout", "action"}, calls)
+
+	calls = nil
+	base.Then(action)(nil)
+	assert.Equal([]string{"logging", "action"}, calls)
+}
@@ -3,6 +3,7 @@ package web
 import (
 	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 type Option func(*App) error
 
 // OptConfig sets the config.
+// If the config has a tls key pair set, it will also set the tls config.
 func OptConfig(cfg Config) Option {
 	return func(a *App) error {
 		var err error
@@ -22,6 +24,20 @@ func OptConfig(cfg Config) Option {
 		if err != nil {
 			return err
 		}
+		if len(cfg.DefaultHeaders) > 0 {
+			if a.DefaultHeaders == nil {
+				a.DefaultHeaders = make(http.Header)
+			}
+			for key, value := range cfg.DefaultHeaders {
+				a.DefaultHeaders.Set(key, value)
+			}
+		}
+		if cfg.TLS.HasKeyPair() {
+			a.TLSConfig, err = cfg.TLS.GetConfig()
+			if err != nil {
+				return err
+			}
+		}
 		a.Config = cfg
 		a.Views = NewViewCache(OptViewCacheConfig(&cfg.Views))
 		return nil
@@ -35,14 +51,7 @@ func OptConfigFromEnv() Option {
 		if err := env.Env().ReadInto(&cfg); err != nil {
 			return err
 		}
-		var err error
-		a.Auth, err = NewAuthManager(OptAuthManagerFromConfig(cfg))
-		if err != nil {
-			return err
-		}
-		a.Config = cfg
-		a.Views = NewViewCache(OptViewCacheConfig(&cfg.Views))
-		return nil
+		return OptConfig(cfg)(a)
 	}
 }
 
@@ -71,6 +80,14 @@ func OptLog(log logger.Log) Option {
 	}
 }
 
+// OptListener sets the listener the server accepts connections on.
+func OptListener(listener net.Listener) Option {
+	return func(a *App) error {
+		a.WithListener(listener)
+		return nil
+	}
+}
+
 // OptServer sets the underlying server.
 func OptServer(server *http.Server) Option {
 	return func(a *App) error {
@@ -98,6 +115,14 @@ func OptTracer(tracer Tracer) Option {
 	}
 }
 
+// OptCollector sets the metrics collector.
+func OptCollector(collector Collector) Option {
+	return func(a *App) error {
+		a.Collector = collector
+		return nil
+	}
+}
+
 // OptViews sets the view cache.
 func OptViews(views *ViewCache) Option {
 	return func(a *App) error {
@@ -149,6 +174,30 @@ func OptUse(m Middleware) Option {
 	}
 }
 
+// OptOnStart adds hooks that are called when the app is started before it listens for requests.
+func OptOnStart(hooks ...LifecycleHook) Option {
+	return func(a *App) error {
+		a.OnStart(hooks...)
+		return nil
+	}
+}
+
+// OptOnStarted adds hooks that are called once the app is listening.
+func OptOnStarted(hooks ...LifecycleHook) Option {
+	return func(a *App) error {
+		a.OnStarted(hooks...)
+		return nil
+	}
+}
+
+// OptOnShutdown adds hooks that are called when the app is stopped.
+func OptOnShutdown(hooks ...LifecycleHook) Option {
+	return func(a *App) error {
+		a.OnShutdown(hooks...)
+		return nil
+	}
+}
+
 // OptMethodNotAllowedHandler sets default headers.
 func OptMethodNotAllowedHandler(action Action) Option {
 	return func(a *App) error {
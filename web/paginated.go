@@ -0,0 +1,55 @@
+package web
+
+import "net/http"
+
+// Pagination defaults.
+const (
+	// DefaultPageSize is the page size used when a page size is not positive.
+	DefaultPageSize = 50
+	// MaxPageSize is the largest page size; larger page sizes are clamped to it.
+	MaxPageSize = 1000
+)
+
+// PaginatedResponse is the json envelope for paginated list responses.
+type PaginatedResponse struct {
+	Data       interface{} `json:"data"`
+	Total      int         `json:"total"`
+	Page       int         `json:"page"`
+	PageSize   int         `json:"pageSize"`
+	TotalPages int         `json:"totalPages"`
+}
+
+// NewPaginatedResponse returns a new paginated response for a page of items.
+//
+// Pages are 1-indexed; a page less than 1 is clamped to 1, a page size
+// less than 1 is set to `DefaultPageSize`, and a page size greater than
+// `MaxPageSize` is clamped to `MaxPageSize`.
+func NewPaginatedResponse(items interface{}, total, page, pageSize int) PaginatedResponse {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	} else if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	if total < 0 {
+		total = 0
+	}
+	return PaginatedResponse{
+		Data:       items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: (total + pageSize - 1) / pageSize,
+	}
+}
+
+// Paginated returns a json result for a page of items out of a total, in a `PaginatedResponse` envelope.
+// See `NewPaginatedResponse` for how the page and page size are validated.
+func (rc *Ctx) Paginated(items interface{}, total, page, pageSize int) Result {
+	return &JSONResult{
+		StatusCode: http.StatusOK,
+		Response:   NewPaginatedResponse(items, total, page, pageSize),
+	}
+}
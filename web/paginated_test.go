@@ -0,0 +1,62 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/r2"
+)
+
+func TestCtxPaginated(t *testing.T) {
+	assert := assert.New(t)
+
+	app, err := New()
+	assert.Nil(err)
+	app.GET("/items", func(r *Ctx) Result {
+		page, _ := r.ParamInt("page")
+		pageSize, _ := r.ParamInt("pageSize")
+		return r.Paginated([]string{"foo", "bar"}, 101, page, pageSize)
+	})
+
+	contents, res, err := MockGet(app, "/items", r2.OptQueryValue("page", "2"), r2.OptQueryValue("pageSize", "10")).Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+
+	var envelope struct {
+		Data       []string `json:"data"`
+		Total      int      `json:"total"`
+		Page       int      `json:"page"`
+		PageSize   int      `json:"pageSize"`
+		TotalPages int      `json:"totalPages"`
+	}
+	assert.Nil(json.Unmarshal(contents, &envelope))
+	assert.Equal([]string{"foo", "bar"}, envelope.Data)
+	assert.Equal(101, envelope.Total)
+	assert.Equal(2, envelope.Page)
+	assert.Equal(10, envelope.PageSize)
+	assert.Equal(11, envelope.TotalPages)
+}
+
+func TestNewPaginatedResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	res := NewPaginatedResponse(nil, 100, 0, 0)
+	assert.Equal(1, res.Page)
+	assert.Equal(DefaultPageSize, res.PageSize)
+	assert.Equal(2, res.TotalPages)
+
+	res = NewPaginatedResponse(nil, 2500, -1, MaxPageSize+1)
+	assert.Equal(1, res.Page)
+	assert.Equal(MaxPageSize, res.PageSize)
+	assert.Equal(3, res.TotalPages)
+
+	res = NewPaginatedResponse(nil, -1, 3, 10)
+	assert.Equal(3, res.Page)
+	assert.Zero(res.Total)
+	assert.Zero(res.TotalPages)
+
+	res = NewPaginatedResponse(nil, 20, 1, 10)
+	assert.Equal(2, res.TotalPages)
+}
@@ -0,0 +1,82 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// ProblemTypeDefault is the problem type for problems that have no type other than their status code.
+const ProblemTypeDefault = "about:blank"
+
+// Problem returns a problem details (RFC 7807) result for a given status code, title and detail.
+//
+// If the title is empty, the status text of the status code is used.
+func (rc *Ctx) Problem(status int, title, detail string) Result {
+	if title == "" {
+		title = http.StatusText(status)
+	}
+	return &ProblemResult{
+		Type:     ProblemTypeDefault,
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: rc.problemInstance(),
+	}
+}
+
+// ProblemError returns a problem details (RFC 7807) result for a given status code and error.
+//
+// If the error is an exception, its class is the problem type and its message is the detail;
+// otherwise the error text is the detail. Errors for server error status codes are logged.
+func (rc *Ctx) ProblemError(status int, err error) Result {
+	problem := &ProblemResult{
+		Type:     ProblemTypeDefault,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Instance: rc.problemInstance(),
+	}
+	if typed := ex.As(err); typed != nil && typed.Class != nil {
+		problem.Type = typed.Class.Error()
+		problem.Detail = typed.Message
+	} else if err != nil {
+		problem.Detail = err.Error()
+	}
+	if status >= http.StatusInternalServerError {
+		return ResultWithLoggedError(problem, err)
+	}
+	return problem
+}
+
+func (rc *Ctx) problemInstance() string {
+	if rc.Request == nil || rc.Request.URL == nil {
+		return ""
+	}
+	return rc.Request.URL.Path
+}
+
+// ProblemResult is a problem details (RFC 7807) result, rendered as `application/problem+json`.
+type ProblemResult struct {
+	// Type identifies the problem type; it is `about:blank` for problems that are described by their status code.
+	Type string `json:"type,omitempty"`
+	// Title is a short summary of the problem type.
+	Title string `json:"title,omitempty"`
+	// Status is the http status code.
+	Status int `json:"status,omitempty"`
+	// Detail is an explanation specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Instance identifies this occurrence of the problem; it is the request path.
+	Instance string `json:"instance,omitempty"`
+}
+
+// Render renders the result.
+func (pr *ProblemResult) Render(ctx *Ctx) error {
+	status := pr.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	ctx.Response.Header().Set(HeaderContentType, ContentTypeApplicationProblemJSON)
+	ctx.Response.WriteHeader(status)
+	return ex.New(json.NewEncoder(ctx.Response).Encode(pr))
+}
@@ -0,0 +1,70 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/ex"
+)
+
+func TestCtxProblem(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.GET("/widgets/:id", func(r *Ctx) Result {
+		return r.Problem(http.StatusNotFound, "", "widget 1234 does not exist")
+	})
+
+	body, res, err := MockGet(app, "/widgets/1234").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+	assert.Equal(ContentTypeApplicationProblemJSON, res.Header.Get(HeaderContentType))
+
+	var problem map[string]interface{}
+	assert.Nil(json.Unmarshal(body, &problem))
+	assert.Equal(map[string]interface{}{
+		"type":     ProblemTypeDefault,
+		"title":    "Not Found",
+		"status":   float64(http.StatusNotFound),
+		"detail":   "widget 1234 does not exist",
+		"instance": "/widgets/1234",
+	}, problem)
+}
+
+func TestCtxProblemError(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.GET("/class", func(r *Ctx) Result {
+		return r.ProblemError(http.StatusBadRequest, ex.New(ErrParameterMissing, ex.OptMessage("`id` is required")))
+	})
+	app.GET("/plain", func(r *Ctx) Result {
+		return r.ProblemError(http.StatusInternalServerError, fmt.Errorf("something broke"))
+	})
+
+	var problem ProblemResult
+	body, res, err := MockGet(app, "/class").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusBadRequest, res.StatusCode)
+	assert.Equal(ContentTypeApplicationProblemJSON, res.Header.Get(HeaderContentType))
+	assert.Nil(json.Unmarshal(body, &problem))
+	assert.Equal(ProblemResult{
+		Type:     string(ErrParameterMissing),
+		Title:    "Bad Request",
+		Status:   http.StatusBadRequest,
+		Detail:   "`id` is required",
+		Instance: "/class",
+	}, problem)
+
+	problem = ProblemResult{}
+	body, res, err = MockGet(app, "/plain").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusInternalServerError, res.StatusCode)
+	assert.Nil(json.Unmarshal(body, &problem))
+	assert.Equal(ProblemTypeDefault, problem.Type)
+	assert.Equal("Internal Server Error", problem.Title)
+	assert.Equal("something broke", problem.Detail)
+}
@@ -20,26 +20,45 @@ type RawResponseWriter struct {
 	innerResponse http.ResponseWriter
 	contentLength int
 	statusCode    int
+	wroteHeader   bool
 }
 
 // Write writes the data to the response.
+// If the status code has not been written, it writes `200 OK` first.
 func (rw *RawResponseWriter) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
 	written, err := rw.innerResponse.Write(b)
 	rw.contentLength += written
 	return written, err
 }
 
 // Header accesses the response header collection.
+//
+// Headers must be set before the status code is written (by `WriteHeader` or the first `Write`);
+// headers set afterward are not sent, except for trailers, which are declared with the `Trailer` header
+// before the status code is written or are prefixed with `http.TrailerPrefix`.
 func (rw *RawResponseWriter) Header() http.Header {
 	return rw.innerResponse.Header()
 }
 
 // WriteHeader is actually a terrible name and this writes the status code.
+// Only the first call has an effect; later calls are ignored.
 func (rw *RawResponseWriter) WriteHeader(code int) {
+	if rw.wroteHeader {
+		return
+	}
+	rw.wroteHeader = true
 	rw.statusCode = code
 	rw.innerResponse.WriteHeader(code)
 }
 
+// HeaderWritten returns if the status code and headers have been written.
+func (rw *RawResponseWriter) HeaderWritten() bool {
+	return rw.wroteHeader
+}
+
 // InnerResponse returns the backing writer.
 func (rw *RawResponseWriter) InnerResponse() http.ResponseWriter {
 	return rw.innerResponse
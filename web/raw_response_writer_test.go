@@ -0,0 +1,63 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestRawResponseWriterHeaders(t *testing.T) {
+	assert := assert.New(t)
+
+	inner := httptest.NewRecorder()
+	rw := NewRawResponseWriter(inner)
+	rw.Header().Set(HeaderContentType, ContentTypeText)
+	assert.False(rw.HeaderWritten())
+	rw.WriteHeader(http.StatusAccepted)
+	assert.True(rw.HeaderWritten())
+
+	rw.Header().Set("X-Too-Late", "true")
+	rw.WriteHeader(http.StatusInternalServerError)
+	fmt.Fprint(rw, "ok")
+
+	assert.Equal(http.StatusAccepted, rw.StatusCode())
+	assert.Equal(http.StatusAccepted, inner.Code)
+	assert.Equal(2, rw.ContentLength())
+	assert.Equal(ContentTypeText, inner.Header().Get(HeaderContentType))
+	assert.Equal(ContentTypeText, rw.Header().Get(HeaderContentType), "written headers should still be readable")
+	assert.Empty(inner.Result().Header.Get("X-Too-Late"), "headers set after the status is written should be dropped")
+}
+
+func TestRawResponseWriterTrailers(t *testing.T) {
+	assert := assert.New(t)
+
+	inner := httptest.NewRecorder()
+	rw := NewRawResponseWriter(inner)
+	rw.Header().Set("Trailer", "X-Checksum")
+	fmt.Fprint(rw, "ok")
+	assert.True(rw.HeaderWritten())
+
+	rw.Header().Set("X-Checksum", "abc123")
+	rw.Header().Set(http.TrailerPrefix+"X-Undeclared", "true")
+
+	res := inner.Result()
+	assert.Equal("abc123", res.Trailer.Get("X-Checksum"), "declared trailers should be sent after the status is written")
+	assert.Equal("true", res.Trailer.Get("X-Undeclared"), "prefixed trailers should be sent after the status is written")
+}
+
+func TestRawResponseWriterImplicitStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	inner := httptest.NewRecorder()
+	rw := NewRawResponseWriter(inner)
+	fmt.Fprint(rw, "ok")
+	assert.True(rw.HeaderWritten())
+	assert.Equal(http.StatusOK, rw.StatusCode())
+	assert.Equal(http.StatusOK, inner.Code)
+
+	rw.WriteHeader(http.StatusNotFound)
+	assert.Equal(http.StatusOK, rw.StatusCode())
+}
@@ -41,6 +41,8 @@ func RedirectWithMethodf(method, format string, args ...interface{}) *RedirectRe
 type RedirectResult struct {
 	Method      string `json:"redirect_method"`
 	RedirectURI string `json:"redirect_uri"`
+	// StatusCode is the redirect status code; if unset it is `302` if a method is set or `307` otherwise.
+	StatusCode int `json:"redirect_status,omitempty"`
 }
 
 // Render writes the result to the response.
@@ -48,6 +50,10 @@ func (rr *RedirectResult) Render(ctx *Ctx) error {
 	ctx.WithContext(logger.WithLabel(ctx.Context(), "web.redirect", rr.RedirectURI))
 	if len(rr.Method) > 0 {
 		ctx.Request.Method = rr.Method
+	}
+	if rr.StatusCode > 0 {
+		http.Redirect(ctx.Response, ctx.Request, rr.RedirectURI, rr.StatusCode)
+	} else if len(rr.Method) > 0 {
 		http.Redirect(ctx.Response, ctx.Request, rr.RedirectURI, http.StatusFound)
 	} else {
 		http.Redirect(ctx.Response, ctx.Request, rr.RedirectURI, http.StatusTemporaryRedirect)
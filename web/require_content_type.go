@@ -0,0 +1,46 @@
+package web
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/blend/go-sdk/webutil"
+)
+
+// RequireContentType returns a middleware that rejects requests with a body
+// whose content type is not one of the given types with a 415 (Unsupported Media Type).
+//
+// Media type parameters (e.g. `; charset=utf-8`) are ignored on both the request
+// and the allowed types, and comparison is case insensitive.
+// Requests without a body and without a content type are passed through.
+func RequireContentType(contentTypes ...string) Middleware {
+	allowed := make(map[string]bool, len(contentTypes))
+	for _, contentType := range contentTypes {
+		allowed[normalizeMediaType(contentType)] = true
+	}
+	return func(action Action) Action {
+		return func(r *Ctx) Result {
+			contentType := webutil.GetContentType(r.Request.Header)
+			if contentType == "" && r.Request.ContentLength == 0 {
+				return action(r)
+			}
+			if !allowed[normalizeMediaType(contentType)] {
+				return r.DefaultProvider.Status(http.StatusUnsupportedMediaType)
+			}
+			return action(r)
+		}
+	}
+}
+
+// normalizeMediaType returns the lowercased media type without parameters.
+func normalizeMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		if index := strings.IndexByte(contentType, ';'); index >= 0 {
+			contentType = contentType[:index]
+		}
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
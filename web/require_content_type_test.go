@@ -0,0 +1,49 @@
+package web
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/r2"
+	"github.com/blend/go-sdk/webutil"
+)
+
+func TestRequireContentType(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew(OptUse(JSONProviderAsDefault))
+	app.POST("/", ok, RequireContentType(webutil.ContentTypeApplicationJSON))
+	app.GET("/", ok, RequireContentType(webutil.ContentTypeApplicationJSON))
+
+	res, err := MockMethod(app, "POST", "/",
+		r2.OptHeaderValue(HeaderContentType, "application/json"),
+		r2.OptBodyBytes([]byte(`{"foo":"bar"}`)),
+	).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+
+	res, err = MockMethod(app, "POST", "/",
+		r2.OptHeaderValue(HeaderContentType, "Application/JSON; charset=utf-8"),
+		r2.OptBodyBytes([]byte(`{"foo":"bar"}`)),
+	).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+
+	res, err = MockMethod(app, "POST", "/",
+		r2.OptHeaderValue(HeaderContentType, webutil.ContentTypeApplicationFormEncoded),
+		r2.OptBodyBytes([]byte(`foo=bar`)),
+	).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusUnsupportedMediaType, res.StatusCode)
+
+	res, err = MockMethod(app, "POST", "/",
+		r2.OptBodyBytes([]byte(`{"foo":"bar"}`)),
+	).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusUnsupportedMediaType, res.StatusCode)
+
+	res, err = MockGet(app, "/").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode, "requests without a body or content type should pass")
+}
@@ -0,0 +1,157 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/blend/go-sdk/webutil"
+)
+
+const (
+	// DefaultHSTSMaxAge is the default max age for the hsts header (one year).
+	DefaultHSTSMaxAge = 365 * 24 * time.Hour
+	// DefaultContentTypeOptions is the default value for the "X-Content-Type-Options" header.
+	DefaultContentTypeOptions = "nosniff"
+	// DefaultFrameOptions is the default value for the "X-Frame-Options" header.
+	DefaultFrameOptions = "DENY"
+)
+
+// SecureHeadersOption mutates secure headers options.
+type SecureHeadersOption func(*SecureHeadersOptions)
+
+// OptSecureHeadersHSTS sets the hsts header options.
+// A max age of zero disables the hsts header.
+func OptSecureHeadersHSTS(maxAge time.Duration, includeSubDomains, preload bool) SecureHeadersOption {
+	return func(sho *SecureHeadersOptions) {
+		sho.HSTSMaxAge = maxAge
+		sho.HSTSIncludeSubDomains = includeSubDomains
+		sho.HSTSPreload = preload
+	}
+}
+
+// OptSecureHeadersContentTypeOptions sets the "X-Content-Type-Options" header value.
+// An empty value disables the header.
+func OptSecureHeadersContentTypeOptions(value string) SecureHeadersOption {
+	return func(sho *SecureHeadersOptions) { sho.ContentTypeOptions = value }
+}
+
+// OptSecureHeadersFrameOptions sets the "X-Frame-Options" header value.
+// An empty value disables the header.
+func OptSecureHeadersFrameOptions(value string) SecureHeadersOption {
+	return func(sho *SecureHeadersOptions) { sho.FrameOptions = value }
+}
+
+// OptSecureHeadersRedirectHTTPS sets if plaintext requests should be redirected to https.
+func OptSecureHeadersRedirectHTTPS(redirect bool) SecureHeadersOption {
+	return func(sho *SecureHeadersOptions) { sho.RedirectHTTPS = redirect }
+}
+
+// OptSecureHeadersTrustForwardedHost sets if the https redirect should use the host from the `X-Forwarded-Host` header.
+// It should only be enabled if the app is behind a proxy that sets the header, as it can otherwise be spoofed by clients.
+func OptSecureHeadersTrustForwardedHost(trust bool) SecureHeadersOption {
+	return func(sho *SecureHeadersOptions) { sho.TrustForwardedHost = trust }
+}
+
+// SecureHeadersOptions are the options for the secure headers middleware.
+type SecureHeadersOptions struct {
+	// HSTSMaxAge is the max age for the "Strict-Transport-Security" header.
+	// If it is zero, the header is not set.
+	HSTSMaxAge time.Duration
+	// HSTSIncludeSubDomains adds the `includeSubDomains` token to the hsts header.
+	HSTSIncludeSubDomains bool
+	// HSTSPreload adds the `preload` token to the hsts header.
+	HSTSPreload bool
+	// ContentTypeOptions is the value of the "X-Content-Type-Options" header.
+	// If it is empty, the header is not set.
+	ContentTypeOptions string
+	// FrameOptions is the value of the "X-Frame-Options" header.
+	// If it is empty, the header is not set.
+	FrameOptions string
+	// RedirectHTTPS will redirect requests that did not arrive over https
+	// (as determined by the request `X-Forwarded-Proto` header or tls state)
+	// to the https url with a 301.
+	RedirectHTTPS bool
+	// TrustForwardedHost will use the request `X-Forwarded-Host` header rather than the request host
+	// for the https redirect url; it should only be set if a proxy in front of the app sets the header.
+	TrustForwardedHost bool
+}
+
+// HSTSValue returns the value for the "Strict-Transport-Security" header.
+func (sho SecureHeadersOptions) HSTSValue() string {
+	if sho.HSTSMaxAge <= 0 {
+		return ""
+	}
+	tokens := []string{fmt.Sprintf(HSTSMaxAgeFormat, int64(sho.HSTSMaxAge/time.Second))}
+	if sho.HSTSIncludeSubDomains {
+		tokens = append(tokens, HSTSIncludeSubDomains)
+	}
+	if sho.HSTSPreload {
+		tokens = append(tokens, HSTSPreload)
+	}
+	return strings.Join(tokens, "; ")
+}
+
+// SecureHeaders returns a middleware that sets common security headers
+// (hsts, content type options and frame options) and optionally redirects
+// plaintext requests to https.
+func SecureHeaders(options ...SecureHeadersOption) Middleware {
+	opts := SecureHeadersOptions{
+		HSTSMaxAge:            DefaultHSTSMaxAge,
+		HSTSIncludeSubDomains: true,
+		ContentTypeOptions:    DefaultContentTypeOptions,
+		FrameOptions:          DefaultFrameOptions,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+	hsts := opts.HSTSValue()
+
+	return func(action Action) Action {
+		return func(r *Ctx) Result {
+			if opts.RedirectHTTPS && !isSecureRequest(r.Request) {
+				http.Redirect(r.Response, r.Request, httpsURL(r.Request, opts.TrustForwardedHost), http.StatusMovedPermanently)
+				return nil
+			}
+			if hsts != "" {
+				r.Response.Header().Set(HeaderStrictTransportSecurity, hsts)
+			}
+			if opts.ContentTypeOptions != "" {
+				r.Response.Header().Set(HeaderXContentTypeOptions, opts.ContentTypeOptions)
+			}
+			if opts.FrameOptions != "" {
+				r.Response.Header().Set(HeaderXFrameOptions, opts.FrameOptions)
+			}
+			return action(r)
+		}
+	}
+}
+
+func isSecureRequest(r *http.Request) bool {
+	if r.TLS != nil {
+		return true
+	}
+	return webutil.GetProto(r) == SchemeHTTPS
+}
+
+// httpsURL returns the https url for a request, using the host from the `X-Forwarded-Host` header only if it is trusted.
+func httpsURL(r *http.Request, trustForwardedHost bool) string {
+	host := r.Host
+	if trustForwardedHost {
+		if forwarded, ok := webutil.HeaderLastValue(r.Header, webutil.HeaderXForwardedHost); ok {
+			host = forwarded
+		}
+	}
+	requestURI := r.RequestURI
+	if r.URL != nil {
+		if host == "" {
+			host = r.URL.Host
+		}
+		requestURI = r.URL.RequestURI()
+	}
+	if requestURI == "" {
+		requestURI = "/"
+	}
+	return SchemeHTTPS + "://" + host + requestURI
+}
@@ -0,0 +1,112 @@
+package web
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/r2"
+	"github.com/blend/go-sdk/webutil"
+)
+
+func TestSecureHeaders(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.Use(SecureHeaders())
+	app.GET("/", ok)
+
+	res, err := MockGet(app, "/").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal("max-age=31536000; includeSubDomains", res.Header.Get(HeaderStrictTransportSecurity))
+	assert.Equal("nosniff", res.Header.Get(HeaderXContentTypeOptions))
+	assert.Equal("DENY", res.Header.Get(HeaderXFrameOptions))
+}
+
+func TestSecureHeadersOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.Use(SecureHeaders(
+		OptSecureHeadersHSTS(time.Hour, false, true),
+		OptSecureHeadersContentTypeOptions(""),
+		OptSecureHeadersFrameOptions("SAMEORIGIN"),
+	))
+	app.GET("/", ok)
+
+	res, err := MockGet(app, "/").Discard()
+	assert.Nil(err)
+	assert.Equal("max-age=3600; preload", res.Header.Get(HeaderStrictTransportSecurity))
+	assert.Empty(res.Header.Get(HeaderXContentTypeOptions))
+	assert.Equal("SAMEORIGIN", res.Header.Get(HeaderXFrameOptions))
+
+	app = MustNew()
+	app.Use(SecureHeaders(OptSecureHeadersHSTS(0, false, false)))
+	app.GET("/", ok)
+
+	res, err = MockGet(app, "/").Discard()
+	assert.Nil(err)
+	assert.Empty(res.Header.Get(HeaderStrictTransportSecurity))
+}
+
+func TestSecureHeadersRedirectHTTPS(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.Use(SecureHeaders(OptSecureHeadersRedirectHTTPS(true), OptSecureHeadersTrustForwardedHost(true)))
+	app.GET("/foo", ok)
+
+	res, err := MockGet(app, "/foo",
+		r2.OptNoFollow(),
+		r2.OptQueryValue("bar", "baz"),
+		r2.OptHeaderValue(webutil.HeaderXForwardedProto, SchemeHTTP),
+		r2.OptHeaderValue(webutil.HeaderXForwardedHost, "example.com"),
+	).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusMovedPermanently, res.StatusCode)
+	assert.Equal("https://example.com/foo?bar=baz", res.Header.Get("Location"))
+
+	res, err = MockGet(app, "/foo",
+		r2.OptHeaderValue(webutil.HeaderXForwardedProto, SchemeHTTPS),
+	).Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.NotEmpty(res.Header.Get(HeaderStrictTransportSecurity))
+}
+
+func TestSecureHeadersRedirectHTTPSSpoofedHost(t *testing.T) {
+	assert := assert.New(t)
+
+	app := MustNew()
+	app.Use(SecureHeaders(OptSecureHeadersRedirectHTTPS(true)))
+	app.GET("/foo", ok)
+
+	// the forwarded host is not trusted by default, so a client can't redirect to another host.
+	req := MockGet(app, "/foo",
+		r2.OptNoFollow(),
+		r2.OptHeaderValue(webutil.HeaderXForwardedProto, SchemeHTTP),
+		r2.OptHeaderValue(webutil.HeaderXForwardedHost, "evil.example.com"),
+	)
+	res, err := req.Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusMovedPermanently, res.StatusCode)
+	assert.Equal("https://"+req.URL.Host+"/foo", res.Header.Get("Location"))
+	assert.NotContains(res.Header.Get("Location"), "evil.example.com")
+}
+
+func TestSecureHeadersHTTPSURL(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("https://example.com/foo?bar=baz", httpsURL(&http.Request{Host: "example.com", RequestURI: "/foo?bar=baz"}, false))
+	assert.Equal("https://example.com/", httpsURL(&http.Request{Host: "example.com"}, false))
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com/foo?bar=baz", nil)
+	assert.Nil(err)
+	req.Host = ""
+	assert.Equal("https://example.com/foo?bar=baz", httpsURL(req, false))
+	req.Header.Set(webutil.HeaderXForwardedHost, "public.example.com")
+	assert.Equal("https://example.com/foo?bar=baz", httpsURL(req, false))
+	assert.Equal("https://public.example.com/foo?bar=baz", httpsURL(req, true))
+}
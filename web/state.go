@@ -13,6 +13,7 @@ type State interface {
 	Keys() []string
 	Get(key string) Any
 	Set(key string, value Any)
+	Increment(key string) int64
 	Remove(key string)
 	Copy() State
 }
@@ -61,6 +62,28 @@ func (s *SyncState) Set(key string, value Any) {
 	return
 }
 
+// Increment increments an int64 counter for a key by one and returns the new value.
+// If the key is unset, or is not an integer, the counter starts at zero.
+func (s *SyncState) Increment(key string) int64 {
+	s.Lock()
+	defer s.Unlock()
+	if s.Values == nil {
+		s.Values = make(map[string]Any)
+	}
+	var value int64
+	switch typed := s.Values[key].(type) {
+	case int64:
+		value = typed
+	case int32:
+		value = int64(typed)
+	case int:
+		value = int64(typed)
+	}
+	value++
+	s.Values[key] = value
+	return value
+}
+
 // Remove removes a key.
 func (s *SyncState) Remove(key string) {
 	s.Lock()
@@ -73,10 +96,19 @@ func (s *SyncState) Remove(key string) {
 }
 
 // Copy creates a new copy of the vars.
+// The underlying map is copied so the result can be mutated
+// independently of (and concurrently with) the original.
 func (s *SyncState) Copy() State {
 	s.Lock()
 	defer s.Unlock()
+	if s.Values == nil {
+		return &SyncState{}
+	}
+	values := make(map[string]Any, len(s.Values))
+	for key, value := range s.Values {
+		values[key] = value
+	}
 	return &SyncState{
-		Values: s.Values,
+		Values: values,
 	}
 }
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/blend/go-sdk/assert"
@@ -25,3 +26,64 @@ func TestSyncState(t *testing.T) {
 	state.Remove("bar")
 	assert.Nil(state.Get("bar"))
 }
+
+func TestSyncStateIncrement(t *testing.T) {
+	assert := assert.New(t)
+
+	state := &SyncState{}
+	assert.Equal(1, state.Increment("foo"))
+	assert.Equal(2, state.Increment("foo"))
+	assert.Equal(int64(2), state.Get("foo"))
+
+	state.Set("bar", 5)
+	assert.Equal(6, state.Increment("bar"))
+
+	state.Set("buzz", "not a number")
+	assert.Equal(1, state.Increment("buzz"))
+}
+
+func TestSyncStateCopy(t *testing.T) {
+	assert := assert.New(t)
+
+	state := &SyncState{
+		Values: Values{
+			"foo": "bar",
+		},
+	}
+	copied := state.Copy()
+	copied.Set("buzz", "fuzz")
+	assert.Equal("bar", copied.Get("foo"))
+	assert.Nil(state.Get("buzz"), "mutating the copy should not mutate the original")
+}
+
+func TestSyncStateConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	state := &SyncState{}
+	copied := state.Copy()
+
+	const workers, iterations = 16, 256
+	wg := sync.WaitGroup{}
+	wg.Add(workers * 2)
+	for x := 0; x < workers; x++ {
+		go func() {
+			defer wg.Done()
+			for y := 0; y < iterations; y++ {
+				state.Increment("counter")
+				copied.Increment("counter")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for y := 0; y < iterations; y++ {
+				_ = state.Keys()
+				_ = state.Get("counter")
+				_ = state.Copy()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(int64(workers*iterations), state.Get("counter"))
+	assert.Equal(int64(workers*iterations), copied.Get("counter"))
+}
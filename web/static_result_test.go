@@ -1 +1,37 @@
 package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/webutil"
+)
+
+func TestStaticResultRenderLastModified(t *testing.T) {
+	assert := assert.New(t)
+
+	info, err := os.Stat("testdata/test_file.html")
+	assert.Nil(err)
+	modTime := info.ModTime().UTC()
+
+	render := func(ifModifiedSince time.Time) *httptest.ResponseRecorder {
+		req := webutil.NewMockRequest(http.MethodGet, "/test_file.html")
+		req.Header.Set(webutil.HeaderIfModifiedSince, ifModifiedSince.Format(http.TimeFormat))
+		res := httptest.NewRecorder()
+		assert.Nil(Static("testdata/test_file.html").Render(NewCtx(NewRawResponseWriter(res), req)))
+		return res
+	}
+
+	res := render(modTime.Add(time.Hour))
+	assert.Equal(http.StatusNotModified, res.Code)
+	assert.Empty(res.Body.String())
+	assert.Equal(modTime.Format(http.TimeFormat), res.Header().Get(webutil.HeaderLastModified))
+
+	res = render(modTime.Add(-time.Hour))
+	assert.Equal(http.StatusOK, res.Code)
+	assert.NotEmpty(res.Body.String())
+}
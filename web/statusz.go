@@ -0,0 +1,225 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/blend/go-sdk/async"
+	"github.com/blend/go-sdk/ex"
+)
+
+// Status check statuses.
+const (
+	StatusCheckOK      = "ok"
+	StatusCheckFailing = "failing"
+	StatusCheckPending = "pending"
+)
+
+// StatusCheck is a dependency check; it should return an error if the dependency is unhealthy.
+type StatusCheck func(context.Context) error
+
+// StatusCheckResult is the cached result of a status check.
+type StatusCheckResult struct {
+	Name        string        `json:"name"`
+	Status      string        `json:"status"`
+	Error       string        `json:"error,omitempty"`
+	LastChecked time.Time     `json:"lastChecked,omitempty"`
+	Latency     time.Duration `json:"latency"`
+}
+
+// StatuszResponse is the json document rendered by the statusz action.
+type StatuszResponse struct {
+	Status string              `json:"status"`
+	Checks []StatusCheckResult `json:"checks"`
+}
+
+// NewStatusz returns a new statusz.
+func NewStatusz(options ...StatuszOption) *Statusz {
+	s := &Statusz{
+		checks:  make(map[string]StatusCheck),
+		results: make(map[string]StatusCheckResult),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// StatuszOption is an option for statusz.
+type StatuszOption func(*Statusz)
+
+// OptStatuszInterval sets the interval checks are run on.
+func OptStatuszInterval(d time.Duration) StatuszOption {
+	return func(s *Statusz) { s.Interval = d }
+}
+
+// OptStatuszTimeout sets the timeout for each check.
+func OptStatuszTimeout(d time.Duration) StatuszOption {
+	return func(s *Statusz) { s.Timeout = d }
+}
+
+// OptStatuszCheck registers a status check.
+func OptStatuszCheck(name string, check StatusCheck) StatuszOption {
+	return func(s *Statusz) { s.Register(name, check) }
+}
+
+// Statusz runs a set of dependency checks on a background interval and caches
+// their results, so the `/statusz` action is cheap to poll, e.g.
+//
+//	statusz := web.NewStatusz(web.OptStatuszCheck("db", conn.Ping))
+//	go statusz.Start()
+//	app.GET("/statusz", statusz.Action)
+//
+// The action returns a 503 if any checked dependency is failing.
+type Statusz struct {
+	Interval time.Duration
+	Timeout  time.Duration
+
+	mu       sync.Mutex
+	checks   map[string]StatusCheck
+	results  map[string]StatusCheckResult
+	interval *async.Interval
+}
+
+// IntervalOrDefault returns the check interval or a default.
+func (s *Statusz) IntervalOrDefault() time.Duration {
+	if s.Interval > 0 {
+		return s.Interval
+	}
+	return DefaultStatuszInterval
+}
+
+// TimeoutOrDefault returns the check timeout or a default.
+func (s *Statusz) TimeoutOrDefault() time.Duration {
+	if s.Timeout > 0 {
+		return s.Timeout
+	}
+	return DefaultStatuszTimeout
+}
+
+// Register registers a status check by name, replacing any existing check with that name.
+func (s *Statusz) Register(name string, check StatusCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checks[name] = check
+	delete(s.results, name)
+}
+
+// Start runs the checks once and then on the interval until stopped.
+//
+// This call will block.
+func (s *Statusz) Start() error {
+	s.mu.Lock()
+	if s.interval != nil {
+		s.mu.Unlock()
+		return ex.New(async.ErrCannotStart)
+	}
+	s.interval = async.NewInterval(func(ctx context.Context) error {
+		s.Check(ctx)
+		return nil
+	}, s.IntervalOrDefault())
+	interval := s.interval
+	s.mu.Unlock()
+
+	s.Check(context.Background())
+	return interval.Start()
+}
+
+// Stop stops the background checks.
+func (s *Statusz) Stop() error {
+	s.mu.Lock()
+	interval := s.interval
+	s.interval = nil
+	s.mu.Unlock()
+	if interval == nil {
+		return ex.New(async.ErrCannotStop)
+	}
+	return interval.Stop()
+}
+
+// Check runs each registered check concurrently and caches the results.
+func (s *Statusz) Check(ctx context.Context) {
+	s.mu.Lock()
+	checks := make(map[string]StatusCheck, len(s.checks))
+	for name, check := range s.checks {
+		checks[name] = check
+	}
+	s.mu.Unlock()
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(checks))
+	for name, check := range checks {
+		go func(name string, check StatusCheck) {
+			defer wg.Done()
+			result := s.run(ctx, name, check)
+			s.mu.Lock()
+			if _, ok := s.checks[name]; ok {
+				s.results[name] = result
+			}
+			s.mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+}
+
+// Results returns the cached check results sorted by name.
+//
+// Checks that have not run yet are returned as pending.
+func (s *Statusz) Results() []StatusCheckResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	output := make([]StatusCheckResult, 0, len(s.checks))
+	for name := range s.checks {
+		if result, ok := s.results[name]; ok {
+			output = append(output, result)
+			continue
+		}
+		output = append(output, StatusCheckResult{Name: name, Status: StatusCheckPending})
+	}
+	sort.Slice(output, func(i, j int) bool { return output[i].Name < output[j].Name })
+	return output
+}
+
+// Action renders the cached check results as json.
+func (s *Statusz) Action(_ *Ctx) Result {
+	response := StatuszResponse{
+		Status: StatusCheckOK,
+		Checks: s.Results(),
+	}
+	statusCode := http.StatusOK
+	for _, result := range response.Checks {
+		if result.Status == StatusCheckFailing {
+			response.Status = StatusCheckFailing
+			statusCode = http.StatusServiceUnavailable
+			break
+		}
+	}
+	return &JSONResult{StatusCode: statusCode, Response: response}
+}
+
+func (s *Statusz) run(ctx context.Context, name string, check StatusCheck) (result StatusCheckResult) {
+	ctx, cancel := context.WithTimeout(ctx, s.TimeoutOrDefault())
+	defer cancel()
+
+	started := time.Now()
+	result.Name = name
+	result.LastChecked = started.UTC()
+	defer func() {
+		result.Latency = time.Since(started)
+		if r := recover(); r != nil {
+			result.Status = StatusCheckFailing
+			result.Error = ex.New(r).Error()
+		}
+	}()
+
+	if err := check(ctx); err != nil {
+		result.Status = StatusCheckFailing
+		result.Error = err.Error()
+		return
+	}
+	result.Status = StatusCheckOK
+	return
+}
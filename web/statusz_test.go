@@ -0,0 +1,129 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestStatuszAction(t *testing.T) {
+	assert := assert.New(t)
+
+	statusz := NewStatusz(
+		OptStatuszCheck("db", func(_ context.Context) error { return nil }),
+		OptStatuszCheck("cache", func(_ context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			return fmt.Errorf("connection refused")
+		}),
+		OptStatuszCheck("queue", func(_ context.Context) error { panic("queue panic") }),
+	)
+
+	before := time.Now().UTC()
+	statusz.Check(context.Background())
+	statusz.Register("search", func(_ context.Context) error { return nil })
+
+	app := MustNew()
+	app.GET("/statusz", statusz.Action)
+
+	contents, res, err := MockGet(app, "/statusz").Bytes()
+	assert.Nil(err)
+	assert.Equal(http.StatusServiceUnavailable, res.StatusCode)
+	assert.Equal(ContentTypeApplicationJSON, res.Header.Get(HeaderContentType))
+
+	var response StatuszResponse
+	assert.Nil(json.Unmarshal(contents, &response), string(contents))
+	assert.Equal(StatusCheckFailing, response.Status)
+	assert.Len(response.Checks, 4)
+
+	cache := response.Checks[0]
+	assert.Equal("cache", cache.Name)
+	assert.Equal(StatusCheckFailing, cache.Status)
+	assert.Equal("connection refused", cache.Error)
+	assert.True(cache.Latency >= 10*time.Millisecond)
+	assert.False(cache.LastChecked.Before(before))
+
+	db := response.Checks[1]
+	assert.Equal("db", db.Name)
+	assert.Equal(StatusCheckOK, db.Status)
+	assert.Empty(db.Error)
+	assert.True(db.Latency < cache.Latency)
+	assert.False(db.LastChecked.Before(before))
+
+	queue := response.Checks[2]
+	assert.Equal("queue", queue.Name)
+	assert.Equal(StatusCheckFailing, queue.Status)
+	assert.Contains(queue.Error, "queue panic")
+
+	search := response.Checks[3]
+	assert.Equal("search", search.Name)
+	assert.Equal(StatusCheckPending, search.Status)
+	assert.True(search.LastChecked.IsZero())
+}
+
+func TestStatuszActionOK(t *testing.T) {
+	assert := assert.New(t)
+
+	statusz := NewStatusz(OptStatuszCheck("db", func(_ context.Context) error { return nil }))
+	statusz.Check(context.Background())
+
+	app := MustNew()
+	app.GET("/statusz", statusz.Action)
+
+	var response StatuszResponse
+	res, err := MockGet(app, "/statusz").JSON(&response)
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal(StatusCheckOK, response.Status)
+	assert.Len(response.Checks, 1)
+}
+
+func TestStatuszCheckTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	statusz := NewStatusz(
+		OptStatuszTimeout(time.Millisecond),
+		OptStatuszCheck("slow", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+	)
+	statusz.Check(context.Background())
+
+	results := statusz.Results()
+	assert.Len(results, 1)
+	assert.Equal(StatusCheckFailing, results[0].Status)
+	assert.Equal(context.DeadlineExceeded.Error(), results[0].Error)
+}
+
+func TestStatuszStart(t *testing.T) {
+	assert := assert.New(t)
+
+	checked := make(chan struct{}, 8)
+	statusz := NewStatusz(
+		OptStatuszInterval(time.Millisecond),
+		OptStatuszCheck("db", func(_ context.Context) error {
+			select {
+			case checked <- struct{}{}:
+			default:
+			}
+			return nil
+		}),
+	)
+	assert.Equal(time.Millisecond, statusz.IntervalOrDefault())
+	assert.Equal(DefaultStatuszTimeout, statusz.TimeoutOrDefault())
+
+	go statusz.Start()
+	<-checked
+	<-checked
+
+	results := statusz.Results()
+	assert.Len(results, 1)
+	assert.Equal(StatusCheckOK, results[0].Status)
+	assert.Nil(statusz.Stop())
+	assert.NotNil(statusz.Stop())
+}
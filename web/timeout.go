@@ -1,17 +1,20 @@
 package web
 
 import (
-	"context"
 	"net/http"
 	"time"
+
+	"github.com/blend/go-sdk/async"
 )
 
 // WithTimeout injects the context for a given action with a timeout context.
+//
+// If the timeout elapses, the cause of the context cancellation, i.e. `async.Cause(r.Context())`, is `ErrRequestTimeout`.
 func WithTimeout(d time.Duration) Middleware {
 	return func(action Action) Action {
 		return func(r *Ctx) Result {
-			ctx, cancel := context.WithTimeout(r.Context(), d)
-			defer cancel()
+			ctx, cancel := async.WithTimeoutCause(r.Context(), d, ErrRequestTimeout)
+			defer cancel(nil)
 
 			r.Request = r.Request.WithContext(ctx)
 
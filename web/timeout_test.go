@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/async"
 )
 
 func TestTimeout(t *testing.T) {
@@ -49,3 +50,20 @@ func TestTimeout(t *testing.T) {
 	assert.Nil(res.Body.Close())
 	assert.Equal(1, atomic.LoadInt32(&didShortFinish))
 }
+
+func TestTimeoutCause(t *testing.T) {
+	assert := assert.New(t)
+
+	causes := make(chan error, 1)
+	app := MustNew(OptUse(WithTimeout(time.Millisecond)))
+	app.GET("/long", func(r *Ctx) Result {
+		<-r.Context().Done()
+		causes <- async.Cause(r.Context())
+		return NoContent
+	})
+
+	res, err := MockGet(app, "/long").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusServiceUnavailable, res.StatusCode)
+	assert.Equal(ErrRequestTimeout, <-causes)
+}
@@ -0,0 +1,52 @@
+package web
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+
+	"github.com/blend/go-sdk/ex"
+)
+
+// TLSConfig is a serializable set of tls options for a web app.
+type TLSConfig struct {
+	// CertPath is the path to the server certificate in PEM format.
+	CertPath string `json:"certPath,omitempty" yaml:"certPath,omitempty" env:"TLS_CERT_PATH"`
+	// KeyPath is the path to the server private key in PEM format.
+	KeyPath string `json:"keyPath,omitempty" yaml:"keyPath,omitempty" env:"TLS_KEY_PATH"`
+	// CAPaths are paths to CA certificates in PEM format used to verify client certificates.
+	CAPaths []string `json:"caPaths,omitempty" yaml:"caPaths,omitempty" env:"TLS_CA_PATHS,csv"`
+}
+
+// HasKeyPair returns if both the cert and key paths are set.
+func (tc TLSConfig) HasKeyPair() bool {
+	return tc.CertPath != "" && tc.KeyPath != ""
+}
+
+// GetConfig returns a tls config for the tls options.
+// It returns nil if the key pair is unset.
+func (tc TLSConfig) GetConfig() (*tls.Config, error) {
+	if !tc.HasKeyPair() {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(tc.CertPath, tc.KeyPath)
+	if err != nil {
+		return nil, ex.New(err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+	if len(tc.CAPaths) > 0 {
+		var certPEMs [][]byte
+		for _, caPath := range tc.CAPaths {
+			contents, err := ioutil.ReadFile(caPath)
+			if err != nil {
+				return nil, ex.New(err)
+			}
+			certPEMs = append(certPEMs, contents)
+		}
+		if err := OptTLSClientCertPool(certPEMs...)(config); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,104 @@
+package web
+
+import (
+	"time"
+
+	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/tracing"
+)
+
+// WithTracing starts a trace with a given tracer for each request to the actions it wraps.
+//
+// Traces are named for the route, e.g. `GET /users/:id`, and are finished after the result is rendered, with
+// the render errors and the `tracing.AttributeHTTPStatusCode` and `tracing.AttributeElapsed` attributes set on the context.
+// The action context is the context returned by the tracer, so child traces can be started from it.
+//
+// The tracer is a `tracing.Tracer`, so the same tracer can be used for cron jobs; see `cron.OptTracer`.
+func WithTracing(tracer tracing.Tracer) Middleware {
+	return func(action Action) Action {
+		return func(r *Ctx) Result {
+			ctx, finisher := tracer.Start(r.Request.Context(), tracingOperationName(r))
+			r.WithContext(ctx)
+
+			traced := &tracedResult{
+				finisher: finisher,
+				started:  time.Now(),
+			}
+			var finished bool
+			defer func() {
+				if finished {
+					return
+				}
+				if p := recover(); p != nil {
+					traced.finish(r, ex.New(p))
+					panic(p)
+				}
+				traced.finish(r, nil)
+			}()
+			traced.Result = action(r)
+			finished = true
+			if traced.Result == nil {
+				traced.finish(r, nil)
+				return nil
+			}
+			return traced
+		}
+	}
+}
+
+// tracingOperationName returns the trace name for a request.
+func tracingOperationName(r *Ctx) string {
+	if r.Route != nil {
+		return r.Route.Method + " " + r.Route.Path
+	}
+	return r.Request.Method + " " + r.Request.URL.Path
+}
+
+// tracedResult finishes a trace after a result is rendered.
+type tracedResult struct {
+	Result
+
+	finisher tracing.TraceFinisher
+	started  time.Time
+	err      error
+}
+
+// PreRender calls the pre render step of the result, if any.
+func (tr *tracedResult) PreRender(ctx *Ctx) error {
+	if typed, ok := tr.Result.(ResultPreRender); ok {
+		err := typed.PreRender(ctx)
+		tr.err = ex.Nest(tr.err, err)
+		return err
+	}
+	return nil
+}
+
+// Render renders the result.
+func (tr *tracedResult) Render(ctx *Ctx) error {
+	err := tr.Result.Render(ctx)
+	tr.err = ex.Nest(tr.err, err)
+	return err
+}
+
+// PostRender calls the post render step of the result, if any, and finishes the trace.
+func (tr *tracedResult) PostRender(ctx *Ctx) (err error) {
+	if typed, ok := tr.Result.(ResultPostRender); ok {
+		err = typed.PostRender(ctx)
+	}
+	tr.finish(ctx, ex.Nest(tr.err, err))
+	return
+}
+
+func (tr *tracedResult) finish(r *Ctx, err error) {
+	attributes := tracing.Attributes{
+		tracing.AttributeHTTPMethod: r.Request.Method,
+		tracing.AttributeElapsed:    time.Since(tr.started),
+	}
+	if r.Route != nil {
+		attributes[tracing.AttributeHTTPRoute] = r.Route.Path
+	}
+	if r.Response != nil {
+		attributes[tracing.AttributeHTTPStatusCode] = r.Response.StatusCode()
+	}
+	tr.finisher.Finish(tracing.WithAttributes(r.Request.Context(), attributes), err)
+}
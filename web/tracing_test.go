@@ -0,0 +1,124 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+	"github.com/blend/go-sdk/tracing"
+	"github.com/blend/go-sdk/webutil"
+)
+
+type mockSpanKey struct{}
+
+type mockSpan struct {
+	Name       string
+	Attributes tracing.Attributes
+	Err        error
+}
+
+type mockSpanTracer struct {
+	sync.Mutex
+	Spans []*mockSpan
+}
+
+func (mst *mockSpanTracer) Start(ctx context.Context, name string) (context.Context, tracing.TraceFinisher) {
+	span := &mockSpan{Name: name}
+	mst.Lock()
+	mst.Spans = append(mst.Spans, span)
+	mst.Unlock()
+	return context.WithValue(ctx, mockSpanKey{}, span), mockSpanFinisher{span}
+}
+
+type mockSpanFinisher struct {
+	span *mockSpan
+}
+
+func (msf mockSpanFinisher) Finish(ctx context.Context, err error) {
+	msf.span.Attributes = tracing.GetAttributes(ctx)
+	msf.span.Err = err
+}
+
+func TestWithTracing(t *testing.T) {
+	assert := assert.New(t)
+
+	tracer := new(mockSpanTracer)
+	app := MustNew()
+	app.GET("/users/:id", func(r *Ctx) Result {
+		span, _ := r.Context().Value(mockSpanKey{}).(*mockSpan)
+		if span == nil {
+			return JSON.BadRequest(fmt.Errorf("span is unset"))
+		}
+		return JSON.Result(map[string]string{"id": r.RouteParams.Get("id")})
+	}, WithTracing(tracer))
+	app.GET("/error", func(r *Ctx) Result {
+		return JSON.InternalError(fmt.Errorf("only a test"))
+	}, WithTracing(tracer))
+	app.GET("/none", func(r *Ctx) Result {
+		r.Response.WriteHeader(http.StatusAccepted)
+		return nil
+	}, WithTracing(tracer))
+
+	res, err := MockGet(app, "/users/1").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+
+	res, err = MockGet(app, "/error").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusInternalServerError, res.StatusCode)
+
+	res, err = MockGet(app, "/none").Discard()
+	assert.Nil(err)
+	assert.Equal(http.StatusAccepted, res.StatusCode)
+
+	assert.Len(tracer.Spans, 3)
+
+	span := tracer.Spans[0]
+	assert.Equal("GET /users/:id", span.Name)
+	assert.Nil(span.Err)
+	assert.Equal(http.StatusOK, span.Attributes[tracing.AttributeHTTPStatusCode])
+	assert.Equal("GET", span.Attributes[tracing.AttributeHTTPMethod])
+	assert.Equal("/users/:id", span.Attributes[tracing.AttributeHTTPRoute])
+	elapsed, ok := span.Attributes[tracing.AttributeElapsed].(time.Duration)
+	assert.True(ok)
+	assert.True(elapsed > 0)
+
+	span = tracer.Spans[1]
+	assert.Equal("GET /error", span.Name)
+	assert.Equal(http.StatusInternalServerError, span.Attributes[tracing.AttributeHTTPStatusCode])
+	assert.NotNil(span.Err)
+	assert.Contains(span.Err.Error(), "only a test")
+
+	span = tracer.Spans[2]
+	assert.Equal("GET /none", span.Name)
+	assert.Nil(span.Err)
+	assert.Equal(http.StatusAccepted, span.Attributes[tracing.AttributeHTTPStatusCode])
+}
+
+func TestWithTracingPanic(t *testing.T) {
+	assert := assert.New(t)
+
+	tracer := new(mockSpanTracer)
+	action := WithTracing(tracer)(func(r *Ctx) Result {
+		panic("only a test")
+	})
+
+	r := NewCtx(NewRawResponseWriter(httptest.NewRecorder()), webutil.NewMockRequest("GET", "/panic"))
+	func() {
+		defer func() {
+			assert.Equal("only a test", recover())
+		}()
+		action(r)
+	}()
+
+	assert.Len(tracer.Spans, 1)
+	assert.Equal("GET /panic", tracer.Spans[0].Name)
+	assert.NotNil(tracer.Spans[0].Err)
+	_, hasRoute := tracer.Spans[0].Attributes[tracing.AttributeHTTPRoute]
+	assert.False(hasRoute)
+}
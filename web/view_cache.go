@@ -1,11 +1,13 @@
 package web
 
 import (
+	"bytes"
 	"html/template"
 	"net/http"
 	"sync"
 
 	"github.com/blend/go-sdk/bufferutil"
+	"github.com/blend/go-sdk/env"
 	"github.com/blend/go-sdk/ex"
 	templatehelpers "github.com/blend/go-sdk/template"
 )
@@ -118,6 +120,38 @@ func (vc *ViewCache) Lookup(name string) (*template.Template, error) {
 	return vc.Templates.Lookup(name), nil
 }
 
+// Render executes a named template to a string.
+// It is useful for rendering views outside of an http response, e.g. for emails.
+// The template is passed a `ViewModel` with the `ViewModel` field set to the data,
+// so the same templates can be used for http responses and other output.
+// Templates are read from the cache, or parsed for each call if the cache is not
+// initialized or is live reloaded.
+func (vc *ViewCache) Render(name string, data interface{}) (string, error) {
+	t, err := vc.Lookup(name)
+	if err != nil {
+		return "", err
+	}
+	if t == nil {
+		return "", ex.New(ErrUnsetViewTemplate, ex.OptMessagef("viewname: %s", name))
+	}
+
+	var buffer *bytes.Buffer
+	if vc.BufferPool != nil {
+		buffer = vc.BufferPool.Get()
+		defer vc.BufferPool.Put(buffer)
+	} else {
+		buffer = new(bytes.Buffer)
+	}
+
+	if err = t.Execute(buffer, &ViewModel{
+		Env:       env.Env(),
+		ViewModel: data,
+	}); err != nil {
+		return "", ex.New(err)
+	}
+	return buffer.String(), nil
+}
+
 // ----------------------------------------------------------------------
 // results
 // ----------------------------------------------------------------------
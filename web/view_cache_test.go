@@ -305,3 +305,50 @@ func TestViewCacheFuncs(t *testing.T) {
 	assert.Nil(opt(vc))
 	assert.Empty(vc.FuncMap)
 }
+
+func TestViewCacheRender(t *testing.T) {
+	assert := assert.New(t)
+
+	vc := NewViewCache(OptViewCacheLiterals(`{{ define "email" }}Hello {{ .ViewModel.Name }}!{{ end }}`))
+	assert.Nil(vc.Initialize())
+
+	output, err := vc.Render("email", map[string]string{"Name": "Bailey"})
+	assert.Nil(err)
+	assert.Equal("Hello Bailey!", output)
+
+	_, err = vc.Render("not-a-view", nil)
+	assert.True(ex.Is(err, ErrUnsetViewTemplate))
+}
+
+func TestViewCacheRenderLiveReload(t *testing.T) {
+	assert := assert.New(t)
+
+	vc := NewViewCache(OptViewCacheLiterals(`{{ define "email" }}Hello {{ .ViewModel }}!{{ end }}`))
+	vc.LiveReload = true
+	assert.Nil(vc.Initialize())
+	assert.Nil(vc.Templates)
+
+	output, err := vc.Render("email", "Bailey")
+	assert.Nil(err)
+	assert.Equal("Hello Bailey!", output)
+
+	vc.Literals = []string{`{{ define "email" }}Goodbye {{ .ViewModel }}!{{ end }}`}
+	output, err = vc.Render("email", "Bailey")
+	assert.Nil(err)
+	assert.Equal("Goodbye Bailey!", output)
+}
+
+func TestViewCacheRenderErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	vc := NewViewCache(OptViewCacheLiterals(`{{ define "email" }}{{ .ViewModel.Foo.Bar }}{{ end }}`))
+	assert.Nil(vc.Initialize())
+	_, err := vc.Render("email", map[string]int{"Foo": 1})
+	assert.NotNil(err)
+	assert.NotNil(ex.As(err))
+
+	vc = NewViewCache(OptViewCacheLiterals(`{{ define "email" }}{{ .ViewModel }`))
+	_, err = vc.Render("email", nil)
+	assert.NotNil(err)
+	assert.NotNil(ex.As(err))
+}
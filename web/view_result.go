@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"time"
 
 	"github.com/blend/go-sdk/env"
 	"github.com/blend/go-sdk/ex"
+	"github.com/blend/go-sdk/webutil"
 )
 
 // ViewResult is a result that renders a view.
@@ -17,6 +19,10 @@ type ViewResult struct {
 	ViewModel  interface{}
 	Views      *ViewCache
 	Template   *template.Template
+	// LastModified is the time the view's underlying resource was last modified.
+	// If set, it is sent as the `Last-Modified` header, and requests whose
+	// `If-Modified-Since` header is not before it are sent a `304 Not Modified`.
+	LastModified time.Time
 }
 
 // Render renders the result to the given response writer.
@@ -38,6 +44,15 @@ func (vr *ViewResult) Render(ctx *Ctx) (err error) {
 
 	ctx.Response.Header().Set(HeaderContentType, ContentTypeHTML)
 
+	if !vr.LastModified.IsZero() {
+		ctx.Response.Header().Set(webutil.HeaderLastModified, vr.LastModified.UTC().Format(http.TimeFormat))
+	}
+	// only successful views are cacheable; e.g. an error view must not be turned into a 304.
+	if isSuccessStatus(vr.StatusCode) && webutil.NotModified(ctx.Request, ctx.Response.Header(), vr.LastModified) {
+		ctx.Response.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	// use a pooled buffer if possible
 	var buffer *bytes.Buffer
 	if vr.Views != nil && vr.Views.BufferPool != nil {
@@ -70,3 +85,8 @@ func (vr *ViewResult) Render(ctx *Ctx) (err error) {
 	}
 	return
 }
+
+// isSuccessStatus returns if a status code is a 2xx status code.
+func isSuccessStatus(statusCode int) bool {
+	return statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices
+}
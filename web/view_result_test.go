@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/blend/go-sdk/assert"
 	"github.com/blend/go-sdk/env"
@@ -172,3 +174,72 @@ func TestViewResultErrorNestedViews(t *testing.T) {
 	err = vr.Render(rc)
 	assert.NotNil(err)
 }
+
+func TestViewResultRenderLastModified(t *testing.T) {
+	assert := assert.New(t)
+
+	testView := template.New("testView")
+	testView.Parse("{{ .ViewModel.Text }}")
+
+	modTime := time.Date(2019, 06, 01, 12, 30, 15, 0, time.UTC)
+	render := func(ifModifiedSince time.Time, etag string) *httptest.ResponseRecorder {
+		req := webutil.NewMockRequest(http.MethodGet, "/")
+		if !ifModifiedSince.IsZero() {
+			req.Header.Set(webutil.HeaderIfModifiedSince, ifModifiedSince.Format(http.TimeFormat))
+		}
+		if etag != "" {
+			req.Header.Set(webutil.HeaderIfNoneMatch, etag)
+		}
+		res := httptest.NewRecorder()
+		rc := NewCtx(NewRawResponseWriter(res), req)
+		rc.Response.Header().Set(webutil.HeaderETag, `"foo"`)
+		assert.Nil((&ViewResult{
+			StatusCode:   http.StatusOK,
+			ViewModel:    testViewModel{Text: "bar"},
+			Template:     testView,
+			LastModified: modTime,
+		}).Render(rc))
+		return res
+	}
+
+	res := render(time.Time{}, "")
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("bar", res.Body.String())
+	assert.Equal(modTime.Format(http.TimeFormat), res.Header().Get(webutil.HeaderLastModified))
+
+	res = render(modTime.Add(time.Hour), "")
+	assert.Equal(http.StatusNotModified, res.Code)
+	assert.Empty(res.Body.String())
+
+	res = render(modTime.Add(-time.Hour), "")
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("bar", res.Body.String())
+
+	res = render(modTime.Add(time.Hour), `"buzz"`)
+	assert.Equal(http.StatusOK, res.Code, "the etag should take precedence")
+	res = render(modTime.Add(-time.Hour), `"foo"`)
+	assert.Equal(http.StatusNotModified, res.Code, "the etag should take precedence")
+}
+
+func TestViewResultRenderErrorNotModified(t *testing.T) {
+	assert := assert.New(t)
+
+	testView := template.New("testView")
+	testView.Parse("{{ .Status.Code }} {{ .ViewModel.Text }}")
+
+	modTime := time.Date(2019, 06, 01, 12, 30, 15, 0, time.UTC)
+	req := webutil.NewMockRequest(http.MethodGet, "/")
+	req.Header.Set(webutil.HeaderIfModifiedSince, modTime.Add(time.Hour).Format(http.TimeFormat))
+	req.Header.Set(webutil.HeaderIfNoneMatch, `"foo"`)
+	res := httptest.NewRecorder()
+	rc := NewCtx(NewRawResponseWriter(res), req)
+	rc.Response.Header().Set(webutil.HeaderETag, `"foo"`)
+	assert.Nil((&ViewResult{
+		StatusCode:   http.StatusInternalServerError,
+		ViewModel:    testViewModel{Text: "error"},
+		Template:     testView,
+		LastModified: modTime,
+	}).Render(rc))
+	assert.Equal(http.StatusInternalServerError, res.Code, "error views should not be not modified")
+	assert.Equal("500 error", res.Body.String())
+}
@@ -28,6 +28,9 @@ var (
 	HeaderCookie                  = http.CanonicalHeaderKey("Cookie")
 	HeaderDate                    = http.CanonicalHeaderKey("Date")
 	HeaderETag                    = http.CanonicalHeaderKey("etag")
+	HeaderIfNoneMatch             = http.CanonicalHeaderKey("If-None-Match")
+	HeaderLastModified            = http.CanonicalHeaderKey("Last-Modified")
+	HeaderIfModifiedSince         = http.CanonicalHeaderKey("If-Modified-Since")
 	HeaderCacheControl            = http.CanonicalHeaderKey("Cache-Control")
 	HeaderConnection              = http.CanonicalHeaderKey("Connection")
 	HeaderContentEncoding         = http.CanonicalHeaderKey("Content-Encoding")
@@ -82,6 +85,9 @@ const (
 	// ContentTypeApplicationFormEncoded is a content type header value.
 	ContentTypeApplicationFormEncoded = "application/x-www-form-urlencoded"
 
+	// ContentTypeMultipartFormData is a content type header value.
+	ContentTypeMultipartFormData = "multipart/form-data"
+
 	// ContentTypeApplicationOctetStream is a content type header value.
 	ContentTypeApplicationOctetStream = "application/octet-stream"
 
@@ -0,0 +1,46 @@
+package webutil
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NotModified returns if a request's conditional headers indicate the client's
+// copy of a resource is current, and a `304 Not Modified` should be returned.
+//
+// If the request has an `If-None-Match` header and the response has an `ETag` header,
+// the etags are compared and `If-Modified-Since` is ignored. Otherwise the `If-Modified-Since`
+// header is compared against the modification time, if it is set.
+// Only `GET` and `HEAD` requests are considered.
+func NotModified(req *http.Request, header http.Header, modTime time.Time) bool {
+	if req == nil || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return false
+	}
+	if ifNoneMatch := req.Header.Get(HeaderIfNoneMatch); ifNoneMatch != "" {
+		if etag := header.Get(HeaderETag); etag != "" {
+			return etagMatches(ifNoneMatch, etag)
+		}
+	}
+	if modTime.IsZero() || modTime.Equal(time.Unix(0, 0)) {
+		return false
+	}
+	ifModifiedSince, err := http.ParseTime(req.Header.Get(HeaderIfModifiedSince))
+	if err != nil {
+		return false
+	}
+	// the header has second resolution, so truncate the modification time to match.
+	return !modTime.Truncate(time.Second).After(ifModifiedSince)
+}
+
+// etagMatches returns if an `If-None-Match` header value matches an etag using the weak comparison.
+func etagMatches(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
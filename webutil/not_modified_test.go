@@ -0,0 +1,51 @@
+package webutil
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/blend/go-sdk/assert"
+)
+
+func TestNotModified(t *testing.T) {
+	assert := assert.New(t)
+
+	modTime := time.Date(2019, 06, 01, 12, 30, 15, 500, time.UTC)
+	req := NewMockRequest(http.MethodGet, "/")
+	assert.False(NotModified(req, http.Header{}, modTime))
+	assert.False(NotModified(nil, http.Header{}, modTime))
+
+	req.Header.Set(HeaderIfModifiedSince, modTime.Add(time.Hour).Format(http.TimeFormat))
+	assert.True(NotModified(req, http.Header{}, modTime))
+	req.Header.Set(HeaderIfModifiedSince, modTime.Format(http.TimeFormat))
+	assert.True(NotModified(req, http.Header{}, modTime))
+	req.Header.Set(HeaderIfModifiedSince, modTime.Add(-time.Hour).Format(http.TimeFormat))
+	assert.False(NotModified(req, http.Header{}, modTime))
+	req.Header.Set(HeaderIfModifiedSince, "not a time")
+	assert.False(NotModified(req, http.Header{}, modTime))
+
+	req.Header.Set(HeaderIfModifiedSince, modTime.Add(time.Hour).Format(http.TimeFormat))
+	assert.False(NotModified(req, http.Header{}, time.Time{}))
+	req.Method = http.MethodPost
+	assert.False(NotModified(req, http.Header{}, modTime))
+}
+
+func TestNotModifiedETagPrecedence(t *testing.T) {
+	assert := assert.New(t)
+
+	modTime := time.Date(2019, 06, 01, 12, 30, 15, 0, time.UTC)
+	req := NewMockRequest(http.MethodGet, "/")
+	req.Header.Set(HeaderIfModifiedSince, modTime.Add(time.Hour).Format(http.TimeFormat))
+	req.Header.Set(HeaderIfNoneMatch, `"foo", W/"bar"`)
+
+	assert.False(NotModified(req, http.Header{HeaderETag: {`"buzz"`}}, modTime))
+	assert.True(NotModified(req, http.Header{HeaderETag: {`"bar"`}}, modTime))
+	assert.True(NotModified(req, http.Header{HeaderETag: {`W/"foo"`}}, modTime))
+	assert.True(NotModified(req, http.Header{}, modTime), "if-modified-since should be used without a response etag")
+
+	req.Header.Set(HeaderIfModifiedSince, modTime.Add(-time.Hour).Format(http.TimeFormat))
+	assert.True(NotModified(req, http.Header{HeaderETag: {`"foo"`}}, modTime))
+	req.Header.Set(HeaderIfNoneMatch, "*")
+	assert.True(NotModified(req, http.Header{HeaderETag: {`"anything"`}}, modTime))
+}